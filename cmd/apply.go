@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+var (
+	applyFileFlag       string
+	applyAllowEmptyFlag bool
+	applyYesFlag        bool
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply -f <manifest>",
+	Short: "Freeze, update and defrost databases to match the manifest",
+	Long: `Creates stacks for the databases declared in the manifest, updates their protection state,
+stop schedule, log retention, tags and notification targets, and deletes the stacks of the databases not declared in it, as shown by the plan command.
+The stacks to be deleted are listed and must be confirmed before any change is made, unless --yes is given.
+A manifest without databases is rejected unless --allow-empty is given, since it would defrost every database.
+The wait and timeout behave in the same way as freeze and defrost.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manifest, err := readManifest(applyFileFlag, applyAllowEmptyFlag)
+
+		if err != nil {
+			return err
+		}
+
+		k, err := ktnh.NewKtnh("", stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		if err := k.SetStackOptions(stackOptions()); err != nil {
+			return fmt.Errorf("invalid --stack-tag or --notification-arn: %w", err)
+		}
+
+		report, err := k.Plan(manifest)
+
+		if err != nil {
+			return fmt.Errorf("failed to plan changes: %w", err)
+		}
+
+		if deletions := report.Deletions(); (0 < len(deletions)) && !applyYesFlag {
+			if err := confirmDeletions(cmd.InOrStdin(), cmd.ErrOrStderr(), deletions); err != nil {
+				return err
+			}
+		}
+
+		err = k.Apply(report, timeoutDuration())
+
+		if len(report.Changes) == 0 && isTableOutput() {
+			slog.Info("No changes; the stacks match the manifest")
+		} else if printErr := printResult(cmd, report); printErr != nil {
+			return printErr
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to apply manifest: %w", err)
+		}
+
+		return nil
+	},
+}
+
+/*
+confirmDeletions lists the stacks to be deleted and asks the user whether to continue.
+Anything other than "y" or "yes", including the end of the input (e.g., in CI), aborts the command.
+*/
+func confirmDeletions(in io.Reader, out io.Writer, deletions []ktnh.PlannedChange) error {
+	fmt.Fprintf(out, "The following %d stacks will be deleted, and their databases will no longer be kept stopped:\n", len(deletions))
+
+	for _, change := range deletions {
+		fmt.Fprintf(out, "  %s (%s %s)\n", change.StackName, change.DBType, change.DBIdentifier)
+	}
+
+	fmt.Fprint(out, "Continue? [y/N]: ")
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+
+	if err == io.EOF {
+		fmt.Fprintln(out)
+	} else if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("apply aborted; use --yes to delete the stacks without confirmation")
+	}
+}
+
+func init() {
+	applyCmd.Flags().StringVarP(&applyFileFlag, "file", "f", "", "path to the manifest file")
+	applyCmd.Flags().BoolVar(&applyAllowEmptyFlag, "allow-empty", false, "allow a manifest without databases, which defrosts all databases under the prefix")
+	applyCmd.Flags().BoolVarP(&applyYesFlag, "yes", "y", false, "delete stacks without confirmation")
+
+	_ = applyCmd.MarkFlagRequired("file")
+
+	rootCmd.AddCommand(applyCmd)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+func Test_confirmDeletions(t *testing.T) {
+	deletions := []ktnh.PlannedChange{
+		{Action: "delete", DBIdentifier: "db1", DBType: "rds", StackName: "ktnh-db1-abcdef"},
+	}
+
+	testCases := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{
+			name:    "Confirmed",
+			input:   "y\n",
+			wantErr: false,
+		},
+		{
+			name:    "Confirmed in full",
+			input:   "Yes\n",
+			wantErr: false,
+		},
+		{
+			name:    "Declined",
+			input:   "n\n",
+			wantErr: true,
+		},
+		{
+			name:    "Default answer",
+			input:   "\n",
+			wantErr: true,
+		},
+		{
+			name:    "No input",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			err := confirmDeletions(strings.NewReader(tc.input), &out, deletions)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+
+			assert.Contains(t, out.String(), "ktnh-db1-abcdef", "Stacks to be deleted should be listed")
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/config"
+)
+
+/*
+loadedConfig holds the settings loaded from the configuration files and the environment variables.
+It is initialized before each command runs.
+*/
+var loadedConfig *config.Config
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage ktnh configuration",
+	Long: `Manages the settings loaded from the configuration files and the KTNH_* environment variables.
+Settings are layered in the following order, where later layers take precedence:
+~/.config/ktnh/config.yaml, ./.ktnh.yaml, KTNH_* environment variables and command-line flags.`,
+	Args: cobra.NoArgs,
+}
+
+var configViewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Display the effective configuration",
+	Long:  "Displays the effective value of each setting after all layers are merged, and where each value came from.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report := &config.Report{}
+
+		for _, key := range configKeys(cmd) {
+			f := cmd.Flags().Lookup(key)
+
+			report.Settings = append(report.Settings, config.Setting{
+				Key:    key,
+				Value:  f.Value.String(),
+				Source: settingSource(f),
+			})
+		}
+
+		report.Settings = append(report.Settings, config.Setting{
+			Key:    config.ProfileKey,
+			Value:  loadedConfig.Profile.Value,
+			Source: loadedConfig.Profile.Source,
+		})
+
+		slices.SortFunc(report.Settings, func(a, b config.Setting) int {
+			return cmp.Compare(a.Key, b.Key)
+		})
+
+		return printResult(cmd, report)
+	},
+}
+
+/*
+applyConfig loads the configuration and applies it to the global flags not given on the command line.
+*/
+func applyConfig(cmd *cobra.Command) error {
+	cfg, err := config.NewLoader(configKeys(cmd)).Load(configProfileFlag)
+
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	for key, value := range cfg.Values {
+		f := cmd.Flags().Lookup(key)
+
+		if f.Changed {
+			continue
+		}
+
+		// NOTE: `Value.Set` is used instead of `FlagSet.Set`, so that the flag is not marked as changed
+		//       and the source of the value can be told apart from the command line.
+		if err := f.Value.Set(value.Value); err != nil {
+			return fmt.Errorf("invalid value '%s' for '%s' from %s: %w", value.Value, key, value.Source, err)
+		}
+	}
+
+	loadedConfig = cfg
+
+	return nil
+}
+
+/*
+configKeys returns the names of the global flags that can be configured, excluding the profile itself.
+*/
+func configKeys(cmd *cobra.Command) []string {
+	var keys []string
+
+	cmd.Root().PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Name == config.ProfileKey {
+			return
+		}
+
+		keys = append(keys, f.Name)
+	})
+
+	return keys
+}
+
+/*
+settingSource returns where the effective value of the flag came from.
+*/
+func settingSource(f *pflag.Flag) string {
+	if f.Changed {
+		return config.SourceFlag
+	}
+
+	if value, ok := loadedConfig.Values[f.Name]; ok {
+		return value.Source
+	}
+
+	return config.SourceDefault
+}
+
+func init() {
+	configCmd.AddCommand(configViewCmd)
+
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyConfig(t *testing.T) {
+	testCases := []struct {
+		name            string
+		projectConfig   string
+		env             map[string]string
+		args            []string
+		expectedPrefix  string
+		expectedTimeout time.Duration
+		expectedSource  string
+		wantErr         bool
+	}{
+		{
+			name:            "Defaults",
+			args:            []string{},
+			expectedPrefix:  "ktnh",
+			expectedTimeout: 15 * time.Minute,
+			expectedSource:  "default",
+			wantErr:         false,
+		},
+		{
+			name:            "Configuration file",
+			projectConfig:   "prefix: proj\nwait-timeout: 5m\n",
+			args:            []string{},
+			expectedPrefix:  "proj",
+			expectedTimeout: 5 * time.Minute,
+			expectedSource:  ".ktnh.yaml",
+			wantErr:         false,
+		},
+		{
+			name:            "Environment variable over file",
+			projectConfig:   "prefix: proj\n",
+			env:             map[string]string{"KTNH_PREFIX": "env"},
+			args:            []string{},
+			expectedPrefix:  "env",
+			expectedTimeout: 15 * time.Minute,
+			expectedSource:  "env KTNH_PREFIX",
+			wantErr:         false,
+		},
+		{
+			name:            "Flag over environment variable",
+			env:             map[string]string{"KTNH_PREFIX": "env"},
+			args:            []string{"--prefix", "flag"},
+			expectedPrefix:  "flag",
+			expectedTimeout: 15 * time.Minute,
+			expectedSource:  "flag",
+			wantErr:         false,
+		},
+		{
+			name:            "Profile",
+			projectConfig:   "prefix: proj\nprofiles:\n  dev:\n    prefix: dev\n",
+			args:            []string{"--config-profile", "dev"},
+			expectedPrefix:  "dev",
+			expectedTimeout: 15 * time.Minute,
+			expectedSource:  ".ktnh.yaml (profile dev)",
+			wantErr:         false,
+		},
+		{
+			name:          "Invalid value",
+			projectConfig: "wait-timeout: soon\n",
+			args:          []string{},
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			t.Chdir(dir)
+			t.Setenv("XDG_CONFIG_HOME", dir)
+
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			if tc.projectConfig != "" {
+				assert.NoError(t, os.WriteFile(".ktnh.yaml", []byte(tc.projectConfig), 0o644))
+			}
+
+			originalProfileFlag := configProfileFlag
+
+			t.Cleanup(func() {
+				configProfileFlag = originalProfileFlag
+				loadedConfig = nil
+			})
+
+			var (
+				prefix  string
+				timeout time.Duration
+				source  string
+			)
+
+			root := &cobra.Command{
+				Use: "test",
+				RunE: func(cmd *cobra.Command, args []string) error {
+					if err := applyConfig(cmd); err != nil {
+						return err
+					}
+
+					source = settingSource(cmd.Flags().Lookup("prefix"))
+
+					return nil
+				},
+			}
+
+			root.PersistentFlags().StringVar(&configProfileFlag, "config-profile", "", "")
+			root.PersistentFlags().StringVar(&prefix, "prefix", "ktnh", "")
+			root.PersistentFlags().DurationVar(&timeout, "wait-timeout", 15*time.Minute, "")
+
+			root.SetArgs(tc.args)
+
+			err := root.Execute()
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expectedPrefix, prefix, "Prefix does not match expected value")
+				assert.Equal(t, tc.expectedTimeout, timeout, "Wait timeout does not match expected value")
+				assert.Equal(t, tc.expectedSource, source, "Source does not match expected value")
+			}
+		})
+	}
+}
+
+func Test_applyConfig_CollectionValues(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Chdir(dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	projectConfig := "stack-tag:\n  env: dev\n  team: db\ntemplate-patch:\n  - a.yaml\n  - b.json\n"
+
+	assert.NoError(t, os.WriteFile(".ktnh.yaml", []byte(projectConfig), 0o644))
+
+	t.Cleanup(func() {
+		loadedConfig = nil
+	})
+
+	var (
+		tags    map[string]string
+		patches []string
+	)
+
+	root := &cobra.Command{
+		Use: "test",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return applyConfig(cmd)
+		},
+	}
+
+	root.PersistentFlags().StringToStringVar(&tags, "stack-tag", nil, "")
+	root.PersistentFlags().StringSliceVar(&patches, "template-patch", nil, "")
+
+	root.SetArgs([]string{})
+
+	err := root.Execute()
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	assert.Equal(t, map[string]string{"env": "dev", "team": "db"}, tags, "Tags do not match expected value")
+	assert.Equal(t, []string{"a.yaml", "b.json"}, patches, "Patches do not match expected value")
+}
@@ -3,17 +3,24 @@ package cmd
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
 )
 
+var (
+	defrostStartFlag bool
+)
+
 var defrostCmd = &cobra.Command{
 	Use:   "defrost <db-identifier>",
 	Short: "Remove indefinite stop configuration for Aurora cluster or RDS instance",
-	Long:  "Removes the CloudFormation stack that enforces automatic stopping, returning the database to normal operational state.",
-	Args:  cobra.ExactArgs(1),
+	Long: `Removes the CloudFormation stack that enforces automatic stopping, returning the database to normal operational state.
+
+With --start, the database is started after the stack is deleted, and its endpoint and port are printed once it is available.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dbIdentifier := args[0]
 
@@ -23,9 +30,27 @@ var defrostCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
 		}
 
+		k.SetStackName(stackNameFlag)
+
 		slog.Info("Defrosting DB", "dbIdentifier", dbIdentifier)
 
-		err = k.Defrost(timeoutDuration())
+		if !defrostStartFlag {
+			err = k.Defrost(timeoutDuration())
+
+			if err != nil {
+				return fmt.Errorf("failed to defrost DB: %w", err)
+			}
+
+			slog.Info("DB defrosted successfully")
+
+			return nil
+		}
+
+		// NOTE: The stack deletion is always waited for, even with `--no-wait`;
+		//       otherwise the state machine could stop the DB again while it is starting.
+		deadline := time.Now().Add(waitTimeoutFlag)
+
+		err = k.Defrost(waitTimeoutFlag)
 
 		if err != nil {
 			return fmt.Errorf("failed to defrost DB: %w", err)
@@ -33,10 +58,26 @@ var defrostCmd = &cobra.Command{
 
 		slog.Info("DB defrosted successfully")
 
-		return nil
+		startTimeout := time.Duration(0)
+
+		if !noWaitFlag {
+			startTimeout = max(time.Until(deadline), time.Second)
+		}
+
+		report, err := k.Start(startTimeout)
+
+		if err != nil {
+			return fmt.Errorf("failed to start DB: %w", err)
+		}
+
+		return printResult(cmd, report)
 	},
 }
 
 func init() {
+	defrostCmd.Flags().BoolVar(&defrostStartFlag, "start", false, "start the DB after the stack is deleted and wait until it is available")
+
+	addStackNameFlag(defrostCmd)
+
 	rootCmd.AddCommand(defrostCmd)
 }
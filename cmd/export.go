@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+var (
+	exportFileFlag string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [-f <manifest>]",
+	Short: "Write the managed databases out as a manifest",
+	Long: `Writes the databases managed under the prefix and their protection state out as a manifest
+for the plan and apply commands. The manifest is always written in YAML, regardless of --output.
+Without --file, the manifest is printed to the standard output.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := ktnh.NewKtnh("", stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		manifest, err := k.Export()
+
+		if err != nil {
+			return fmt.Errorf("failed to export manifest: %w", err)
+		}
+
+		data, err := manifest.Marshal()
+
+		if err != nil {
+			return err
+		}
+
+		if exportFileFlag == "" {
+			cmd.Print(string(data))
+
+			return nil
+		}
+
+		if err := os.WriteFile(exportFileFlag, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write manifest: %w", err)
+		}
+
+		slog.Info("Manifest written", "file", exportFileFlag, "databases", len(manifest.Databases))
+
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFileFlag, "file", "f", "", "path to write the manifest to (default: standard output)")
+
+	rootCmd.AddCommand(exportCmd)
+}
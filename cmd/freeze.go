@@ -7,11 +7,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
-	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/logger"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
 )
 
 var (
-	templateFlag bool
+	stopNowFlag     bool
+	templateFlag    bool
+	verifyFlag      bool
+	waitStoppedFlag bool
 )
 
 var freezeCmd = &cobra.Command{
@@ -28,6 +31,12 @@ var freezeCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
 		}
 
+		k.SetStackName(stackNameFlag)
+
+		if err := k.SetStackOptions(stackOptions()); err != nil {
+			return fmt.Errorf("invalid --stack-tag or --notification-arn: %w", err)
+		}
+
 		templateBody, qualifier, err := k.Template()
 
 		if err != nil {
@@ -35,21 +44,28 @@ var freezeCmd = &cobra.Command{
 		}
 
 		if templateFlag {
-			var output string
-
-			if jsonLogFlag {
-				output, err = logger.FormatAsJSON([]string{"content"}, [][]string{{templateBody}})
+			if isTableOutput() {
+				cmd.Println(templateBody)
 
-				if err != nil {
-					return fmt.Errorf("failed to format template as JSON: %w", err)
-				}
-			} else {
-				output = templateBody
+				return nil
 			}
 
-			cmd.Println(output)
+			return printResult(cmd, &output.Table{
+				Headers: []string{"content"},
+				Rows:    [][]any{{templateBody}},
+			})
+		}
 
-			return nil
+		if verifyFlag && noWaitFlag {
+			return fmt.Errorf("--verify cannot be used with --no-wait")
+		}
+
+		if stopNowFlag && noWaitFlag {
+			return fmt.Errorf("--stop-now cannot be used with --no-wait")
+		}
+
+		if waitStoppedFlag && noWaitFlag {
+			return fmt.Errorf("--wait-stopped cannot be used with --no-wait")
 		}
 
 		slog.Info("Freezing DB", "dbIdentifier", dbIdentifier)
@@ -62,12 +78,54 @@ var freezeCmd = &cobra.Command{
 
 		slog.Info("DB frozen successfully")
 
+		if verifyFlag {
+			slog.Info("Verifying state machine")
+
+			report, err := k.Trigger(timeoutDuration())
+
+			if report != nil {
+				if printErr := printResult(cmd, report); printErr != nil {
+					return printErr
+				}
+			}
+
+			if err != nil {
+				return fmt.Errorf("failed to verify state machine: %w", err)
+			}
+
+			slog.Info("State machine verified successfully")
+		} else if stopNowFlag {
+			// NOTE: With --verify, the verification run has already stopped the DB.
+			slog.Info("Stopping DB now")
+
+			if err := k.StopNow(); err != nil {
+				return fmt.Errorf("failed to stop DB: %w", err)
+			}
+		}
+
+		if !waitStoppedFlag {
+			return nil
+		}
+
+		err = k.WaitStopped(waitTimeoutFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to wait for DB to be stopped: %w", err)
+		}
+
+		slog.Info("DB stopped successfully")
+
 		return nil
 	},
 }
 
 func init() {
+	freezeCmd.Flags().BoolVar(&stopNowFlag, "stop-now", false, "run the state machine right after stack creation to stop the DB immediately")
 	freezeCmd.Flags().BoolVarP(&templateFlag, "template", "t", false, "display CloudFormation template without creating stack")
+	freezeCmd.Flags().BoolVar(&verifyFlag, "verify", false, "run the state machine once after stack creation and fail if it does not succeed")
+	freezeCmd.Flags().BoolVar(&waitStoppedFlag, "wait-stopped", false, "wait until the DB is stopped after stack creation")
+
+	addStackNameFlag(freezeCmd)
 
 	rootCmd.AddCommand(freezeCmd)
 }
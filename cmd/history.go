@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/utils"
+)
+
+var (
+	historySinceFlag string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <db-identifier>",
+	Short: "Display execution history of the state machine for a database",
+	Long: `Displays each execution of the state machine that keeps the specified Aurora cluster or RDS instance stopped,
+including what triggered it, its start and end times, its final state and whether it actually stopped the DB.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbIdentifier := args[0]
+
+		since, err := utils.ParseDuration(historySinceFlag)
+
+		if err != nil || since <= 0 {
+			return fmt.Errorf("invalid --since '%s': must be a positive duration (e.g., 12h, 7d)", historySinceFlag)
+		}
+
+		k, err := ktnh.NewKtnh(dbIdentifier, stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		k.SetStackName(stackNameFlag)
+
+		report, err := k.History(time.Now().Add(-since))
+
+		if err != nil {
+			return fmt.Errorf("failed to retrieve execution history: %w", err)
+		}
+
+		if len(report.Executions) == 0 && isTableOutput() {
+			slog.Info("No executions found", "since", historySinceFlag)
+
+			return nil
+		}
+
+		return printResult(cmd, report)
+	},
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historySinceFlag, "since", "7d", "show executions started within this duration (e.g., 12h, 7d)")
+
+	addStackNameFlag(historyCmd)
+
+	rootCmd.AddCommand(historyCmd)
+}
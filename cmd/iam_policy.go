@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/policy"
+)
+
+var (
+	policyCommandsFlag   []string
+	policyStackNamesFlag []string
+)
+
+var iamPolicyCmd = &cobra.Command{
+	Use:   "iam-policy",
+	Short: "Display the IAM policy required to run ktnh",
+	Long: `Displays a least-privilege IAM policy document that allows running the specified ktnh commands.
+Resource ARNs are scoped to the stack name prefix specified by --prefix.
+Stacks not named after the prefix (e.g., adopted with --stack-name) can be managed only if given with --stack-name.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		document, err := policy.Generate(&policy.Scope{
+			StackNamePrefix: stackPrefixFlag,
+			StackNames:      policyStackNamesFlag,
+		}, policyCommandsFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to generate IAM policy: %w", err)
+		}
+
+		output, err := document.JSON()
+
+		if err != nil {
+			return fmt.Errorf("failed to format IAM policy: %w", err)
+		}
+
+		cmd.Println(output)
+
+		return nil
+	},
+}
+
+func init() {
+	commands := policy.Commands()
+
+	iamPolicyCmd.Flags().StringSliceVar(&policyCommandsFlag, "commands", commands, fmt.Sprintf("commands to include in the policy (%s)", strings.Join(commands, ", ")))
+
+	iamPolicyCmd.Flags().StringSliceVar(&policyStackNamesFlag, "stack-name", nil, "names of stacks not named after --prefix to be allowed to be managed (can be repeated)")
+
+	rootCmd.AddCommand(iamPolicyCmd)
+}
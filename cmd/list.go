@@ -3,18 +3,38 @@ package cmd
 import (
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
-	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/logger"
+)
+
+var (
+	listColumnsFlag     []string
+	listMaintenanceFlag string
+	listNameRegexFlag   string
+	listSortByFlag      string
+	listStackStatusFlag string
+	listStatusFlag      string
+	listTagFlag         map[string]string
+	listTypeFlag        string
 )
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all databases managed by ktnh",
-	Long:  "Lists all Aurora clusters or RDS instances that are being kept in a permanently stopped state by ktnh.",
-	Args:  cobra.NoArgs,
+	Long: `Lists all Aurora clusters or RDS instances that are being kept in a permanently stopped state by ktnh.
+
+By default, the id, type, stack, state and maintenance columns are displayed.
+Use --columns to choose other columns; the required information is fetched in bulk for all databases.
+The last-stopped column is the time of the last stop event of the DB, whether it was stopped by ktnh or manually,
+and is based on RDS events, which are retained only for 14 days.
+
+Databases can be filtered with --type, --maintenance, --status, --name-regex, --tag and --stack-status
+(all conditions must match), and sorted by any column with --sort-by (prefix the column with "-" for descending order).
+Filtering and sorting are applied before formatting, so they work the same way in all output formats.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		k, err := ktnh.NewKtnh("", stackPrefixFlag)
 
@@ -22,36 +42,60 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
 		}
 
-		headers, body, err := k.List()
+		table, err := k.List(&ktnh.ListOption{
+			Columns:     listColumnsFlag,
+			Type:        listTypeFlag,
+			Maintenance: listMaintenanceFlag,
+			Status:      listStatusFlag,
+			NameRegex:   listNameRegexFlag,
+			Tags:        listTagFlag,
+			StackStatus: listStackStatusFlag,
+			SortBy:      listSortByFlag,
+		})
 
 		if err != nil {
 			return fmt.Errorf("failed to list managed databases: %w", err)
 		}
 
-		if len(body) == 0 {
-			slog.Info("No databases are currently being managed by ktnh")
+		if len(table.Rows) == 0 && isTableOutput() {
+			if isListFiltered(cmd) {
+				slog.Info("No managed databases match the filters")
+			} else {
+				slog.Info("No databases are currently being managed by ktnh")
+			}
 
 			return nil
 		}
 
-		var output string
-
-		if jsonLogFlag {
-			output, err = logger.FormatAsJSON(headers, body)
+		return printResult(cmd, table)
+	},
+}
 
-			if err != nil {
-				return fmt.Errorf("failed to format output as JSON: %w", err)
-			}
-		} else {
-			output = logger.FormatAsTable(headers, body)
+/*
+isListFiltered returns whether any filter option is specified.
+*/
+func isListFiltered(cmd *cobra.Command) bool {
+	for _, name := range []string{"type", "maintenance", "status", "name-regex", "tag", "stack-status"} {
+		if cmd.Flags().Changed(name) {
+			return true
 		}
+	}
 
-		cmd.Println(output)
-
-		return nil
-	},
+	return false
 }
 
 func init() {
+	listCmd.Flags().StringSliceVar(&listColumnsFlag, "columns", nil, fmt.Sprintf(
+		"comma-separated columns to display, or 'all' (available: %s)",
+		strings.Join(ktnh.ListColumns(), ", "),
+	))
+	listCmd.Flags().StringVar(&listTypeFlag, "type", "", "show only databases of this type (aurora, rds)")
+	listCmd.Flags().StringVar(&listMaintenanceFlag, "maintenance", "", "show only databases with this maintenance status (required, available, pending, none)")
+	listCmd.Flags().StringVar(&listStatusFlag, "status", "", "show only databases with this DB status (e.g., stopped)")
+	listCmd.Flags().StringVar(&listNameRegexFlag, "name-regex", "", "show only databases whose identifier matches this regular expression")
+	listCmd.Flags().StringToStringVar(&listTagFlag, "tag", nil, "show only databases with this tag (key=value, can be repeated)")
+	listCmd.Flags().StringVar(&listStackStatusFlag, "stack-status", "", "show only databases whose stack has this status (e.g., ROLLBACK_COMPLETE)")
+	listCmd.Flags().StringVar(&listSortByFlag, "sort-by", "", "sort by this column (prefix with '-' for descending order)")
+
 	rootCmd.AddCommand(listCmd)
 }
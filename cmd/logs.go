@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/logs"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/utils"
+)
+
+var (
+	logsFollowFlag bool
+	logsSinceFlag  string
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs <db-identifier>",
+	Short: "Display log events of the state machine for a database",
+	Long: `Displays the log events written by the state machine that keeps the specified Aurora cluster or RDS instance stopped.
+The log group is resolved from the resources of the ktnh stack, and state transitions are printed one per line.
+With --json-log or --output json, the raw log messages are printed one per line instead.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbIdentifier := args[0]
+
+		since, err := utils.ParseDuration(logsSinceFlag)
+
+		if err != nil || since <= 0 {
+			return fmt.Errorf("invalid --since '%s': must be a positive duration (e.g., 30m, 1h, 7d)", logsSinceFlag)
+		}
+
+		if !isTableOutput() && resultPrinter.Format() != output.FormatJSON {
+			return fmt.Errorf("--output '%s' is not supported by logs (available: %s, %s)", outputFlag, output.FormatTable, output.FormatJSON)
+		}
+
+		k, err := ktnh.NewKtnh(dbIdentifier, stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		k.SetStackName(stackNameFlag)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+		defer stop()
+
+		raw := jsonLogFlag || !isTableOutput()
+
+		err = k.Logs(ctx, time.Now().Add(-since), logsFollowFlag, func(event logs.LogEvent) {
+			if raw {
+				cmd.Println(strings.TrimSpace(event.Message))
+			} else {
+				cmd.Println(ktnh.FormatLogEvent(event))
+			}
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to read log events: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	logsCmd.Flags().BoolVarP(&logsFollowFlag, "follow", "f", false, "keep polling for new log events until interrupted")
+	logsCmd.Flags().StringVar(&logsSinceFlag, "since", "1h", "show log events written within this duration (e.g., 30m, 1h, 7d)")
+
+	addStackNameFlag(logsCmd)
+
+	rootCmd.AddCommand(logsCmd)
+}
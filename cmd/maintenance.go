@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+var (
+	maintenanceApplyAllFlag bool
+	maintenanceTimeoutFlag  time.Duration
+)
+
+var maintenanceCmd = &cobra.Command{
+	Use:   "maintenance [<db-identifier>]",
+	Short: "Display pending maintenance actions of databases",
+	Long: `Displays each pending maintenance action of the specified Aurora cluster or RDS instance,
+or of all databases managed by ktnh if no DB identifier is given.
+For Aurora clusters, the actions of the cluster and of its member instances are displayed.
+Actions scheduled by AWS to be applied automatically are categorized as "required", and the others as "available".`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbIdentifier := ""
+
+		if 0 < len(args) {
+			dbIdentifier = args[0]
+		}
+
+		k, err := ktnh.NewKtnh(dbIdentifier, stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		report, err := k.Maintenance()
+
+		if err != nil {
+			return fmt.Errorf("failed to retrieve pending maintenance actions: %w", err)
+		}
+
+		if len(report.Actions) == 0 && isTableOutput() {
+			slog.Info("No pending maintenance actions found")
+
+			return nil
+		}
+
+		return printResult(cmd, report)
+	},
+}
+
+var maintenanceApplyCmd = &cobra.Command{
+	Use:   "apply [<db-identifier> | --all]",
+	Short: "Apply pending maintenance actions to a frozen database",
+	Long: `Applies all pending maintenance actions to the specified Aurora cluster or RDS instance managed by ktnh.
+Protection is paused and the database is started until it is available, then each pending action is applied immediately.
+--wait-timeout limits each stack update and starting the database,
+while --maintenance-timeout limits waiting for the actions to be applied.
+After all actions are applied, or --maintenance-timeout is reached, the database is stopped
+(once it is available again) and protection is resumed.
+The database is stopped and protection is resumed also if applying the actions fails.
+With --all, applies pending maintenance actions to every database managed under the prefix, one at a time.`,
+	Args: allOrSingleArgs(&maintenanceApplyAllFlag),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if noWaitFlag {
+			return fmt.Errorf("maintenance apply cannot be used with --no-wait")
+		}
+
+		if maintenanceTimeoutFlag <= 0 {
+			return fmt.Errorf("--maintenance-timeout must be greater than 0")
+		}
+
+		if maintenanceApplyAllFlag {
+			k, err := ktnh.NewKtnh("", stackPrefixFlag)
+
+			if err != nil {
+				return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+			}
+
+			slog.Info("Applying maintenance to all DBs", "prefix", stackPrefixFlag)
+
+			err = k.ApplyMaintenanceAll(waitTimeoutFlag, maintenanceTimeoutFlag)
+
+			if err != nil {
+				return fmt.Errorf("failed to apply maintenance to all DBs: %w", err)
+			}
+
+			slog.Info("Maintenance applied to all DBs successfully")
+
+			return nil
+		}
+
+		dbIdentifier := args[0]
+
+		k, err := ktnh.NewKtnh(dbIdentifier, stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		k.SetStackName(stackNameFlag)
+
+		slog.Info("Applying maintenance to DB", "dbIdentifier", dbIdentifier)
+
+		err = k.ApplyMaintenance(waitTimeoutFlag, maintenanceTimeoutFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to apply maintenance to DB: %w", err)
+		}
+
+		slog.Info("Maintenance applied successfully")
+
+		return nil
+	},
+}
+
+func init() {
+	maintenanceApplyCmd.Flags().BoolVar(&maintenanceApplyAllFlag, "all", false, "apply pending maintenance actions to all databases managed under the prefix")
+	maintenanceApplyCmd.Flags().DurationVar(&maintenanceTimeoutFlag, "maintenance-timeout", 2*time.Hour, "timeout duration for waiting on pending maintenance actions to be applied")
+
+	addStackNameFlag(maintenanceApplyCmd)
+
+	maintenanceCmd.AddCommand(maintenanceApplyCmd)
+
+	rootCmd.AddCommand(maintenanceCmd)
+}
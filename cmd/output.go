@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+)
+
+/*
+resultPrinter renders command results in the format selected by `--output`.
+It is initialized before each command runs.
+*/
+var resultPrinter *output.Printer
+
+/*
+printResult renders the result with resultPrinter and prints it to the standard output.
+*/
+func printResult(cmd *cobra.Command, result output.Result) error {
+	rendered, err := resultPrinter.Render(result)
+
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	cmd.Println(rendered)
+
+	return nil
+}
+
+/*
+isTableOutput returns whether the results are rendered as a plain text table,
+i.e. the output is intended for humans rather than for other programs.
+*/
+func isTableOutput() bool {
+	return resultPrinter.Format() == output.FormatTable
+}
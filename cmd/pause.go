@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+var (
+	pauseAllFlag bool
+)
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause [<db-identifier> | --all]",
+	Short: "Temporarily stop keeping Aurora cluster or RDS instance stopped",
+	Long: `Disables the event rule and the schedule of the CloudFormation stack through a stack parameter update,
+so that the database can be started and kept running without losing its freeze configuration.
+With --all, pauses protection of every database managed under the prefix.`,
+	Args: allOrSingleArgs(&pauseAllFlag),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if pauseAllFlag {
+			k, err := ktnh.NewKtnh("", stackPrefixFlag)
+
+			if err != nil {
+				return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+			}
+
+			slog.Info("Pausing all DBs", "prefix", stackPrefixFlag)
+
+			err = k.PauseAll(timeoutDuration())
+
+			if err != nil {
+				return fmt.Errorf("failed to pause all DBs: %w", err)
+			}
+
+			slog.Info("All DBs paused successfully")
+
+			return nil
+		}
+
+		dbIdentifier := args[0]
+
+		k, err := ktnh.NewKtnh(dbIdentifier, stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		k.SetStackName(stackNameFlag)
+
+		slog.Info("Pausing DB", "dbIdentifier", dbIdentifier)
+
+		err = k.Pause(timeoutDuration())
+
+		if err != nil {
+			return fmt.Errorf("failed to pause DB: %w", err)
+		}
+
+		slog.Info("DB paused successfully")
+
+		return nil
+	},
+}
+
+/*
+allOrSingleArgs returns an argument validator for commands that take either a single DB identifier
+or the `--all` flag.
+*/
+func allOrSingleArgs(allFlag *bool) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if *allFlag {
+			if len(args) != 0 {
+				return fmt.Errorf("no DB identifier can be specified with --all")
+			}
+
+			return nil
+		}
+
+		return cobra.ExactArgs(1)(cmd, args)
+	}
+}
+
+func init() {
+	pauseCmd.Flags().BoolVar(&pauseAllFlag, "all", false, "pause protection of all databases managed under the prefix")
+
+	addStackNameFlag(pauseCmd)
+
+	rootCmd.AddCommand(pauseCmd)
+}
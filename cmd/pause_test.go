@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_allOrSingleArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		all      bool
+		args     []string
+		expected bool
+	}{
+		{
+			name:     "Single DB",
+			all:      false,
+			args:     []string{"db-1"},
+			expected: true,
+		},
+		{
+			name:     "No DB without --all",
+			all:      false,
+			args:     []string{},
+			expected: false,
+		},
+		{
+			name:     "All",
+			all:      true,
+			args:     []string{},
+			expected: true,
+		},
+		{
+			name:     "DB with --all",
+			all:      true,
+			args:     []string{"db-1"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			all := tc.all
+
+			err := allOrSingleArgs(&all)(pauseCmd, tc.args)
+
+			if tc.expected {
+				assert.NoError(t, err, "Expected arguments to be valid")
+			} else {
+				assert.Error(t, err, "Expected arguments to be invalid")
+			}
+		})
+	}
+}
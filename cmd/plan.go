@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+var (
+	planFileFlag       string
+	planAllowEmptyFlag bool
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan -f <manifest>",
+	Short: "Show changes required to match the manifest",
+	Long: `Compares the databases declared in the manifest with those managed under the prefix,
+and shows the stacks to be created, updated (protection state, stop schedule, log retention, tags and notification targets)
+and deleted by the apply command.
+A manifest without databases is rejected unless --allow-empty is given, in the same way as apply.
+Exits with status code 2 if there are any changes.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manifest, err := readManifest(planFileFlag, planAllowEmptyFlag)
+
+		if err != nil {
+			return err
+		}
+
+		k, err := ktnh.NewKtnh("", stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		if err := k.SetStackOptions(stackOptions()); err != nil {
+			return fmt.Errorf("invalid --stack-tag or --notification-arn: %w", err)
+		}
+
+		report, err := k.Plan(manifest)
+
+		if err != nil {
+			return fmt.Errorf("failed to plan changes: %w", err)
+		}
+
+		if len(report.Changes) == 0 && isTableOutput() {
+			slog.Info("No changes; the stacks match the manifest")
+
+			return nil
+		}
+
+		if err := printResult(cmd, report); err != nil {
+			return err
+		}
+
+		if 0 < len(report.Changes) {
+			return &exitError{
+				code:    exitCodeCondition,
+				message: fmt.Sprintf("%d changes are required to match the manifest", len(report.Changes)),
+			}
+		}
+
+		return nil
+	},
+}
+
+/*
+readManifest reads and parses the manifest file.
+A manifest without databases is rejected unless allowEmpty is true, since it is more likely a mistake
+(e.g., a wrong file or a broken template) than an intent to defrost every database under the prefix.
+*/
+func readManifest(path string, allowEmpty bool) (*ktnh.Manifest, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	manifest, err := ktnh.ParseManifest(data)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest '%s': %w", path, err)
+	}
+
+	if len(manifest.Databases) == 0 && !allowEmpty {
+		return nil, fmt.Errorf("manifest '%s' declares no databases; use --allow-empty to defrost all databases under the prefix", path)
+	}
+
+	return manifest, nil
+}
+
+func init() {
+	planCmd.Flags().StringVarP(&planFileFlag, "file", "f", "", "path to the manifest file")
+	planCmd.Flags().BoolVar(&planAllowEmptyFlag, "allow-empty", false, "allow a manifest without databases, which defrosts all databases under the prefix")
+
+	_ = planCmd.MarkFlagRequired("file")
+
+	rootCmd.AddCommand(planCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readManifest(t *testing.T) {
+	testCases := []struct {
+		name       string
+		content    string
+		allowEmpty bool
+		expected   int
+		wantErr    bool
+	}{
+		{
+			name:       "Databases declared",
+			content:    "databases:\n  - id: db1\n",
+			allowEmpty: false,
+			expected:   1,
+			wantErr:    false,
+		},
+		{
+			name:       "Empty manifest",
+			content:    "",
+			allowEmpty: false,
+			wantErr:    true,
+		},
+		{
+			name:       "Empty list of databases",
+			content:    "databases: []\n",
+			allowEmpty: false,
+			wantErr:    true,
+		},
+		{
+			name:       "Empty manifest allowed",
+			content:    "databases: []\n",
+			allowEmpty: true,
+			expected:   0,
+			wantErr:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "manifest.yaml")
+
+			if err := os.WriteFile(path, []byte(tc.content), 0o644); err != nil {
+				t.Fatalf("failed to write manifest: %v", err)
+			}
+
+			got, err := readManifest(path, tc.allowEmpty)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+
+				return
+			}
+
+			if assert.NoError(t, err, "Unexpected error occurred") {
+				assert.Len(t, got.Databases, tc.expected, "Number of databases does not match expected value")
+			}
+		})
+	}
+}
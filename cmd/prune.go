@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+var (
+	pruneDryRunFlag bool
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete stacks whose database no longer exists",
+	Long: `Deletes the CloudFormation stacks managed under the prefix whose Aurora cluster or RDS instance no longer exists.
+Such stacks are shown as "orphaned" by the list command, and their state machine fails on every run.
+With --dry-run, the orphaned stacks are only listed. The wait and timeout behave in the same way as defrost.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := ktnh.NewKtnh("", stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		table, err := k.Prune(pruneDryRunFlag, timeoutDuration())
+
+		if table != nil {
+			if len(table.Rows) == 0 && isTableOutput() {
+				slog.Info("No orphaned stacks found")
+			} else if printErr := printResult(cmd, table); printErr != nil {
+				return printErr
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to prune orphaned stacks: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	pruneCmd.Flags().BoolVar(&pruneDryRunFlag, "dry-run", false, "list orphaned stacks without deleting them")
+
+	rootCmd.AddCommand(pruneCmd)
+}
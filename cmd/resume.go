@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+var (
+	resumeAllFlag bool
+)
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume [<db-identifier> | --all]",
+	Short: "Resume keeping Aurora cluster or RDS instance stopped",
+	Long: `Re-enables the event rule and the schedule of the CloudFormation stack paused by the pause command.
+With --all, resumes protection of every database managed under the prefix.`,
+	Args: allOrSingleArgs(&resumeAllFlag),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if resumeAllFlag {
+			k, err := ktnh.NewKtnh("", stackPrefixFlag)
+
+			if err != nil {
+				return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+			}
+
+			slog.Info("Resuming all DBs", "prefix", stackPrefixFlag)
+
+			err = k.ResumeAll(timeoutDuration())
+
+			if err != nil {
+				return fmt.Errorf("failed to resume all DBs: %w", err)
+			}
+
+			slog.Info("All DBs resumed successfully")
+
+			return nil
+		}
+
+		dbIdentifier := args[0]
+
+		k, err := ktnh.NewKtnh(dbIdentifier, stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		k.SetStackName(stackNameFlag)
+
+		slog.Info("Resuming DB", "dbIdentifier", dbIdentifier)
+
+		err = k.Resume(timeoutDuration())
+
+		if err != nil {
+			return fmt.Errorf("failed to resume DB: %w", err)
+		}
+
+		slog.Info("DB resumed successfully")
+
+		return nil
+	},
+}
+
+func init() {
+	resumeCmd.Flags().BoolVar(&resumeAllFlag, "all", false, "resume protection of all databases managed under the prefix")
+
+	addStackNameFlag(resumeCmd)
+
+	rootCmd.AddCommand(resumeCmd)
+}
@@ -1,25 +1,58 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/awsfactory"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/logger"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
 )
 
 var (
-	jsonLogFlag     bool
-	noWaitFlag      bool
-	stackPrefixFlag string
-	verboseFlag     bool
-	waitTimeoutFlag time.Duration
+	configProfileFlag   string
+	jsonLogFlag         bool
+	logRetentionFlag    int
+	nameTemplateFlag    string
+	noHeadersFlag       bool
+	noWaitFlag          bool
+	notificationARNFlag []string
+	outputFlag          string
+	regionFlag          string
+	stackNameFlag       string
+	stackPrefixFlag     string
+	stackTagFlag        map[string]string
+	stopScheduleFlag    string
+	templateDirFlag     string
+	templatePatchFlag   []string
+	verboseFlag         bool
+	waitTimeoutFlag     time.Duration
 )
 
+/*
+exitError is an error that makes the application exit with a specific status code.
+It is used by commands whose exit code carries a result (e.g., whether a DB is protected),
+rather than indicating a failure of the command itself.
+*/
+type exitError struct {
+	code    int    // exit status code
+	message string // message to be logged
+}
+
+/*
+exitCodeCondition is the exit status code used when a command completes
+but the checked condition is not satisfied.
+*/
+const exitCodeCondition = 2
+
 var rootCmd = &cobra.Command{
 	Use:   "ktnh",
 	Short: "Keep Aurora clusters or RDS instances stopped permanently",
@@ -29,6 +62,10 @@ It uses CloudFormation to create and manage the necessary AWS resources.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfig(cmd); err != nil {
+			return err
+		}
+
 		if err := validateStackPrefix(); err != nil {
 			return fmt.Errorf("invalid --prefix '%s': %w", stackPrefixFlag, err)
 		}
@@ -39,21 +76,57 @@ It uses CloudFormation to create and manage the necessary AWS resources.`,
 
 		logger.SetLogger(verboseFlag, jsonLogFlag)
 
+		printer, err := output.NewPrinter(outputFlag, noHeadersFlag)
+
+		if err != nil {
+			return fmt.Errorf("invalid --output '%s': %w", outputFlag, err)
+		}
+
+		resultPrinter = printer
+
+		awsfactory.SetRegion(regionFlag)
+
+		if err := cfn.SetNameTemplate(nameTemplateFlag); err != nil {
+			return fmt.Errorf("invalid --name-template '%s': %w", nameTemplateFlag, err)
+		}
+
+		cfn.SetTemplateDir(templateDirFlag)
+		cfn.SetTemplatePatches(templatePatchFlag)
+
+		if err := cfn.SetFreezePolicy(stopScheduleFlag, logRetentionFlag); err != nil {
+			return fmt.Errorf("invalid --stop-schedule or --log-retention: %w", err)
+		}
+
 		return nil
 	},
 }
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&jsonLogFlag, "json-log", "j", false, "output logs in JSON format instead of plain text")
+	rootCmd.PersistentFlags().IntVar(&logRetentionFlag, "log-retention", cfn.DefaultLogRetentionInDays, "retention period in days of the state machine logs of new stacks")
+	rootCmd.PersistentFlags().StringVar(&nameTemplateFlag, "name-template", cfn.DefaultNameTemplate, "Go template for the names of the resources in the stack (fields: .Prefix, .Component, .LogicalID, .DBIdentifier, .DBIdentifierShort, .Qualifier)")
+	rootCmd.PersistentFlags().BoolVar(&noHeadersFlag, "no-headers", false, "omit titles and header rows from table and csv output")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", output.FormatTable, fmt.Sprintf(
+		"output format of command results (%s); use go-template=TEMPLATE for a Go template",
+		strings.Join(output.Formats(), ", "),
+	))
 	rootCmd.PersistentFlags().BoolVar(&noWaitFlag, "no-wait", false, "don't wait for CloudFormation stack operation to complete")
-	rootCmd.PersistentFlags().StringVarP(&stackPrefixFlag, "prefix", "p", "ktnh", "prefix for CloudFormation stack name (1-10 alphanumeric characters)")
+	rootCmd.PersistentFlags().StringSliceVar(&notificationARNFlag, "notification-arn", nil, "ARNs of SNS topics notified of the stack events of new and applied stacks (at most 5)")
+	rootCmd.PersistentFlags().StringVar(&configProfileFlag, "config-profile", "", "configuration profile to use (not an AWS profile)")
+	rootCmd.PersistentFlags().StringVar(&regionFlag, "region", "", "AWS region (default: resolved from the AWS environment and shared configuration)")
+	rootCmd.PersistentFlags().StringVarP(&stackPrefixFlag, "prefix", "p", "ktnh", "prefix for CloudFormation stack name and resource names (1-10 alphanumeric characters)")
+	rootCmd.PersistentFlags().StringToStringVar(&stackTagFlag, "stack-tag", nil, "tags of new and applied stacks (key=value, can be repeated)")
+	rootCmd.PersistentFlags().StringVar(&stopScheduleFlag, "stop-schedule", cfn.DefaultScheduleExpression, "schedule expression to stop the DB periodically in new stacks (rate or cron expression)")
+	rootCmd.PersistentFlags().StringVar(&templateDirFlag, "template-dir", "", "directory whose files override the embedded templates (e.g., cloudformation.yml)")
+	rootCmd.PersistentFlags().StringSliceVar(&templatePatchFlag, "template-patch", nil, "patch files applied in order to the CloudFormation template (JSON Patch or merge patch)")
 	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "enable verbose logging")
-	rootCmd.PersistentFlags().DurationVar(&waitTimeoutFlag, "wait-timeout", 15*time.Minute, "timeout duration for waiting on stack operation")
+	rootCmd.PersistentFlags().DurationVar(&waitTimeoutFlag, "wait-timeout", 15*time.Minute, "timeout duration for waiting on stack operation or state machine execution")
 }
 
 /*
 Execute starts the application and handles any errors.
-It will exit with status code 1 if the command execution fails.
+It will exit with status code 1 if the command execution fails,
+or with the status code carried by exitError.
 */
 func Execute() {
 	rootCmd.SetOut(os.Stdout)
@@ -61,11 +134,28 @@ func Execute() {
 
 	err := rootCmd.Execute()
 
-	if err != nil {
-		slog.Error("Failed to execute command", "err", err)
+	if err == nil {
+		return
+	}
+
+	var exitErr *exitError
 
-		os.Exit(1)
+	if errors.As(err, &exitErr) {
+		slog.Warn(exitErr.message)
+
+		os.Exit(exitErr.code)
 	}
+
+	slog.Error("Failed to execute command", "err", err)
+
+	os.Exit(1)
+}
+
+/*
+Error returns the message of the exitError.
+*/
+func (e *exitError) Error() string {
+	return e.message
 }
 
 /*
@@ -89,6 +179,50 @@ func validateStackPrefix() error {
 	return nil
 }
 
+/*
+addStackNameFlag adds --stack-name to a command operating on the stack of a single DB.
+*/
+func addStackNameFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&stackNameFlag, "stack-name", "", "exact name of the CloudFormation stack, to adopt a stack not named after --prefix (1-128 characters)")
+
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if stackNameFlag == "" {
+			return nil
+		}
+
+		if len(args) == 0 {
+			return fmt.Errorf("--stack-name requires a DB identifier")
+		}
+
+		if err := validateStackName(); err != nil {
+			return fmt.Errorf("invalid --stack-name '%s': %w", stackNameFlag, err)
+		}
+
+		return nil
+	}
+}
+
+/*
+validateStackName validates whether the --stack-name value is a valid CloudFormation stack name.
+*/
+func validateStackName() error {
+	if 128 < len(stackNameFlag) {
+		return fmt.Errorf("--stack-name must be at most 128 characters long")
+	}
+
+	match, err := regexp.MatchString("^[A-Za-z][-A-Za-z0-9]*$", stackNameFlag)
+
+	if err != nil {
+		return fmt.Errorf("failed to validate --stack-name: %w", err)
+	}
+
+	if !match {
+		return fmt.Errorf("--stack-name must start with a letter and only contain alphanumeric characters and hyphens")
+	}
+
+	return nil
+}
+
 /*
 validateWaitTimeout validates whether the --wait-timeout value is valid.
 */
@@ -100,6 +234,16 @@ func validateWaitTimeout() error {
 	return nil
 }
 
+/*
+stackOptions returns the default tags and notification targets of the stacks given by --stack-tag and --notification-arn.
+*/
+func stackOptions() cfn.StackOptions {
+	return cfn.StackOptions{
+		Tags:             stackTagFlag,
+		NotificationARNs: notificationARNFlag,
+	}
+}
+
 /*
 timeoutDuration returns the timeout duration for waiting on stack operations.
 */
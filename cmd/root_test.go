@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -66,6 +67,60 @@ func Test_validateStackPrefix(t *testing.T) {
 	}
 }
 
+func Test_validateStackName(t *testing.T) {
+	testCases := []struct {
+		name      string
+		stackName string
+		expected  bool
+	}{
+		{
+			name:      "Service Catalog stack",
+			stackName: "SC-123456789012-pp-abcdefghijklm",
+			expected:  true,
+		},
+		{
+			name:      "Exactly 128 chars",
+			stackName: "A" + strings.Repeat("b", 127),
+			expected:  true,
+		},
+		{
+			name:      "129 chars (too long)",
+			stackName: "A" + strings.Repeat("b", 128),
+			expected:  false,
+		},
+		{
+			name:      "Starts with digit",
+			stackName: "1stack",
+			expected:  false,
+		},
+		{
+			name:      "Contains underscore",
+			stackName: "my_stack",
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalStackNameFlag := stackNameFlag
+
+			t.Cleanup(func() {
+				stackNameFlag = originalStackNameFlag
+			})
+
+			stackNameFlag = tc.stackName
+
+			err := validateStackName()
+
+			if tc.expected {
+				assert.NoError(t, err, "Stack name '%s' should be valid", tc.stackName)
+			} else {
+				assert.Error(t, err, "Stack name '%s' should be invalid", tc.stackName)
+			}
+		})
+	}
+}
+
 func Test_validateWaitTimeout(t *testing.T) {
 	testCases := []struct {
 		name        string
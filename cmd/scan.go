@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+var (
+	scanFreezeFlag bool
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Find stopped databases that are not protected by ktnh",
+	Long: `Finds the stopped Aurora clusters and RDS instances in the region that have no ktnh stack,
+and estimates when each of them will be started automatically by AWS (7 days after it was stopped).
+With --freeze, all of them are frozen at once.
+Exits with status code 2 if any database remains at risk of being started automatically.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := ktnh.NewKtnh("", stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		if err := k.SetStackOptions(stackOptions()); err != nil {
+			return fmt.Errorf("invalid --stack-tag or --notification-arn: %w", err)
+		}
+
+		report, err := k.Scan(scanFreezeFlag, timeoutDuration())
+
+		if report != nil {
+			if len(report.Databases) == 0 && isTableOutput() {
+				slog.Info("No stopped databases at risk of automatic start found")
+			} else if printErr := printResult(cmd, report); printErr != nil {
+				return printErr
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to scan databases: %w", err)
+		}
+
+		if atRisk := report.AtRisk(); 0 < atRisk {
+			return &exitError{
+				code:    exitCodeCondition,
+				message: fmt.Sprintf("%d stopped databases are not protected by ktnh", atRisk),
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	scanCmd.Flags().BoolVar(&scanFreezeFlag, "freeze", false, "freeze all stopped databases that are not protected")
+
+	rootCmd.AddCommand(scanCmd)
+}
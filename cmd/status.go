@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+var (
+	executionCountFlag int
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status <db-identifier>",
+	Short: "Display detailed status of a database managed by ktnh",
+	Long: `Displays the status of the specified Aurora cluster or RDS instance together with its CloudFormation stack,
+event rule, schedule, recent state machine executions, pending maintenance and stack resources.
+Exits with status code 2 if the database is not effectively kept stopped by ktnh.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbIdentifier := args[0]
+
+		k, err := ktnh.NewKtnh(dbIdentifier, stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		k.SetStackName(stackNameFlag)
+
+		report, err := k.Status(executionCountFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to retrieve DB status: %w", err)
+		}
+
+		if err := printResult(cmd, report); err != nil {
+			return err
+		}
+
+		if !report.Protected {
+			return &exitError{
+				code:    exitCodeCondition,
+				message: fmt.Sprintf("DB '%s' is not protected by ktnh", dbIdentifier),
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	statusCmd.Flags().IntVarP(&executionCountFlag, "executions", "n", 5, "number of recent state machine executions to display")
+
+	addStackNameFlag(statusCmd)
+
+	rootCmd.AddCommand(statusCmd)
+}
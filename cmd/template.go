@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+)
+
+var (
+	templateDBTypeFlag    string
+	templateDiagramFlag   string
+	templateFormatFlag    string
+	templateGenericFlag   bool
+	templateQualifierFlag string
+	templateValidateFlag  bool
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template {<db-identifier> --db-type aurora|rds | --generic}",
+	Short: "Generate CloudFormation template without accessing AWS",
+	Long: `Generates the CloudFormation template created by the freeze command, without AWS credentials or access.
+Since the DB is not looked up, its type must be given with --db-type.
+A qualifier is generated unless given with --qualifier. With --format json, the template is written in JSON.
+With --format terraform, the same resources are written as a Terraform configuration instead.
+
+With --generic, a single template that is not bound to a specific DB is generated instead.
+The DB identifier, the DB type, the schedule and the log retention are given as stack parameters,
+so that the template can be published as is (e.g., as a Service Catalog product).
+
+With --validate, the CloudFormation template is validated instead of displayed, and all problems found are reported.
+The local checks (e.g., the state machine definition and the lengths of IAM role names) are run first,
+and then the ValidateTemplate API is called, which requires AWS credentials.
+Exits with status code 2 if there are any problems.
+
+With --diagram mermaid|dot, the state machine in the template is drawn as a state diagram instead.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if (templateDiagramFlag != "") && (templateGenericFlag || templateValidateFlag || cmd.Flags().Changed("format")) {
+			return fmt.Errorf("--diagram cannot be used with --generic, --validate or --format")
+		}
+
+		if templateGenericFlag {
+			if (templateDBTypeFlag != "") || (templateQualifierFlag != "") {
+				return fmt.Errorf("--db-type and --qualifier cannot be used with --generic")
+			}
+
+			return cobra.NoArgs(cmd, args)
+		}
+
+		if templateDBTypeFlag == "" {
+			return fmt.Errorf("--db-type is required unless --generic is given")
+		}
+
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if templateValidateFlag {
+			return runTemplateValidation(cmd, args)
+		}
+
+		var content string
+
+		if templateDiagramFlag != "" {
+			diagram, err := ktnh.GenerateDiagram(stackPrefixFlag, args[0], templateDBTypeFlag, templateDiagramFlag)
+
+			if err != nil {
+				return fmt.Errorf("failed to generate state diagram: %w", err)
+			}
+
+			content = diagram
+		} else {
+			templateBody, err := generateTemplate(args)
+
+			if err != nil {
+				return fmt.Errorf("failed to generate CloudFormation template: %w", err)
+			}
+
+			content = templateBody
+		}
+
+		if isTableOutput() {
+			cmd.Println(content)
+
+			return nil
+		}
+
+		return printResult(cmd, &output.Table{
+			Headers: []string{"content"},
+			Rows:    [][]any{{content}},
+		})
+	},
+}
+
+/*
+generateTemplate generates the template for the DB given by the arguments, or the generic template.
+*/
+func generateTemplate(args []string) (string, error) {
+	if templateGenericFlag {
+		return ktnh.GenerateGenericTemplate(stackPrefixFlag, templateFormatFlag)
+	}
+
+	templateBody, qualifier, err := ktnh.GenerateTemplate(stackPrefixFlag, args[0], templateDBTypeFlag, templateQualifierFlag, templateFormatFlag)
+
+	if err != nil {
+		return "", err
+	}
+
+	slog.Debug("Generated CloudFormation template", "qualifier", qualifier)
+
+	return templateBody, nil
+}
+
+/*
+runTemplateValidation validates the template and reports the problems found.
+The ValidateTemplate API is called whenever the template is rendered, even if the local checks fail,
+and its problems are reported along with those of the local checks.
+*/
+func runTemplateValidation(cmd *cobra.Command, args []string) error {
+	if templateFormatFlag == ktnh.TemplateFormatTerraform {
+		return fmt.Errorf("--validate cannot be used with --format %s", ktnh.TemplateFormatTerraform)
+	}
+
+	report := &ktnh.TemplateValidationReport{
+		Problems: []cfn.TemplateProblem{},
+	}
+
+	templateBody, err := generateTemplate(args)
+
+	var templateErr *cfn.TemplateError
+
+	switch {
+	case errors.As(err, &templateErr):
+		report = ktnh.NewTemplateValidationReport(templateErr)
+		templateBody = templateErr.TemplateBody
+	case err != nil:
+		return fmt.Errorf("failed to generate CloudFormation template: %w", err)
+	}
+
+	k, err := ktnh.NewKtnh("", stackPrefixFlag)
+
+	if err != nil {
+		return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+	}
+
+	report.Add(k.ValidateTemplate(templateBody).Problems...)
+
+	if len(report.Problems) == 0 && isTableOutput() {
+		slog.Info("No problems found; the template is valid")
+
+		return nil
+	}
+
+	if err := printResult(cmd, report); err != nil {
+		return err
+	}
+
+	if 0 < len(report.Problems) {
+		return &exitError{
+			code:    exitCodeCondition,
+			message: fmt.Sprintf("%d problems are found in the template", len(report.Problems)),
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	templateCmd.Flags().StringVar(&templateDBTypeFlag, "db-type", "", "type of the DB (aurora or rds)")
+	templateCmd.Flags().StringVar(&templateDiagramFlag, "diagram", "", "draw the state machine as a state diagram (mermaid or dot) instead of the template")
+	templateCmd.Flags().StringVar(&templateFormatFlag, "format", ktnh.TemplateFormatYAML, "format of the template (yaml, json or terraform)")
+	templateCmd.Flags().BoolVar(&templateGenericFlag, "generic", false, "generate a template whose DB is given by stack parameters")
+	templateCmd.Flags().StringVar(&templateQualifierFlag, "qualifier", "", "qualifier of the stack (1-6 alphanumeric characters; generated if not given)")
+	templateCmd.Flags().BoolVar(&templateValidateFlag, "validate", false, "validate the template and report all problems instead of displaying it")
+
+	rootCmd.AddCommand(templateCmd)
+}
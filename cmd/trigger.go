@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/ktnh"
+)
+
+var (
+	triggerWaitFlag bool
+)
+
+var triggerCmd = &cobra.Command{
+	Use:   "trigger <db-identifier>",
+	Short: "Start an execution of the state machine for a database",
+	Long: `Starts an execution of the state machine that keeps the specified Aurora cluster or RDS instance stopped,
+in the same way as the event rule and the schedule do. Note that this stops the DB if it is available.
+With --wait, waits for the execution to finish and fails if it does not succeed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbIdentifier := args[0]
+
+		k, err := ktnh.NewKtnh(dbIdentifier, stackPrefixFlag)
+
+		if err != nil {
+			return fmt.Errorf("failed to initialize ktnh instance: %w", err)
+		}
+
+		k.SetStackName(stackNameFlag)
+
+		var timeout time.Duration
+
+		if triggerWaitFlag {
+			timeout = waitTimeoutFlag
+		}
+
+		report, err := k.Trigger(timeout)
+
+		if report != nil {
+			if printErr := printResult(cmd, report); printErr != nil {
+				return printErr
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to trigger state machine: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	triggerCmd.Flags().BoolVar(&triggerWaitFlag, "wait", false, "wait for the execution to finish (up to --wait-timeout)")
+
+	addStackNameFlag(triggerCmd)
+
+	rootCmd.AddCommand(triggerCmd)
+}
@@ -1,7 +1,8 @@
 /*
 Package awsfactory provides a factory for creating AWS service clients.
 
-It uses the AWS SDK for Go v2 to create clients for services like RDS and CloudFormation.
+It uses the AWS SDK for Go v2 to create clients for services like RDS, CloudFormation,
+Step Functions, EventBridge, EventBridge Scheduler and CloudWatch Logs.
 */
 package awsfactory
 
@@ -23,8 +24,20 @@ var (
 
 	// counter counts how many times the AWS configuration has been loaded
 	counter int
+
+	// region overrides the region of the AWS configuration (empty to use the default)
+	region string
 )
 
+/*
+SetRegion sets the region used by all AWS service clients, overriding the default region
+resolved from the environment and the shared configuration.
+It must be called before any AWS service client is created.
+*/
+func SetRegion(r string) {
+	region = r
+}
+
 /*
 loadAWSConfig loads the AWS configuration.
 It uses a sync.Once to ensure the configuration is loaded only once.
@@ -39,13 +52,19 @@ func loadAWSConfig() error {
 
 		counter++
 
-		cfg, err = config.LoadDefaultConfig(ctx)
+		var optFns []func(*config.LoadOptions) error
+
+		if region != "" {
+			optFns = append(optFns, config.WithRegion(region))
+		}
+
+		cfg, err = config.LoadDefaultConfig(ctx, optFns...)
 
 		if err != nil {
 			return
 		}
 
-		slog.Debug("AWS configuration loaded successfully")
+		slog.Debug("AWS configuration loaded successfully", "region", cfg.Region)
 	})
 
 	return err
@@ -60,4 +79,6 @@ func resetConfiguration() {
 	once = sync.Once{}
 
 	counter = 0
+
+	region = ""
 }
@@ -29,3 +29,20 @@ func Test_loadAWSConfig(t *testing.T) {
 
 	assert.Equal(t, 0, counter, "Counter should be reset to 0")
 }
+
+func Test_SetRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "dummy-key-id")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "dummy-secret-key")
+
+	resetConfiguration()
+
+	t.Cleanup(resetConfiguration)
+
+	SetRegion("ap-northeast-1")
+
+	err := loadAWSConfig()
+
+	assert.NoError(t, err, "Should not return error when loading AWS config")
+	assert.Equal(t, "ap-northeast-1", cfg.Region, "Region should be overridden")
+}
@@ -14,9 +14,11 @@ CloudFormationFactory defines the main interface for creating AWS CloudFormation
 */
 type CloudFormationFactory interface {
 	GetClient() CloudFormationClient
+	NewDescribeStacksPaginator(params *cloudformation.DescribeStacksInput) (DescribeStacksPaginator, error)
 	NewListStacksPaginator(params *cloudformation.ListStacksInput) (ListStacksPaginator, error)
 	NewStackCreateCompleteWaiter() (StackCreateCompleteWaiter, error)
 	NewStackDeleteCompleteWaiter() (StackDeleteCompleteWaiter, error)
+	NewStackUpdateCompleteWaiter() (StackUpdateCompleteWaiter, error)
 }
 
 /*
@@ -25,9 +27,20 @@ CloudFormationClient defines the interface for CloudFormation operations.
 type CloudFormationClient interface {
 	CreateStack(ctx context.Context, params *cloudformation.CreateStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.CreateStackOutput, error)
 	DeleteStack(ctx context.Context, params *cloudformation.DeleteStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DeleteStackOutput, error)
+	DescribeStackResources(ctx context.Context, params *cloudformation.DescribeStackResourcesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackResourcesOutput, error)
 	DescribeStacks(ctx context.Context, params *cloudformation.DescribeStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error)
 	GetTemplate(ctx context.Context, params *cloudformation.GetTemplateInput, optFns ...func(*cloudformation.Options)) (*cloudformation.GetTemplateOutput, error)
 	ListStacks(ctx context.Context, params *cloudformation.ListStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStacksOutput, error)
+	UpdateStack(ctx context.Context, params *cloudformation.UpdateStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.UpdateStackOutput, error)
+	ValidateTemplate(ctx context.Context, params *cloudformation.ValidateTemplateInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ValidateTemplateOutput, error)
+}
+
+/*
+DescribeStacksPaginator defines the interface for paginating through stack descriptions.
+*/
+type DescribeStacksPaginator interface {
+	HasMorePages() bool
+	NextPage(ctx context.Context, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error)
 }
 
 /*
@@ -52,6 +65,13 @@ type StackDeleteCompleteWaiter interface {
 	Wait(ctx context.Context, params *cloudformation.DescribeStacksInput, maxWaitDur time.Duration, optFns ...func(*cloudformation.StackDeleteCompleteWaiterOptions)) error
 }
 
+/*
+StackUpdateCompleteWaiter defines the interface for waiting for a stack update to complete.
+*/
+type StackUpdateCompleteWaiter interface {
+	Wait(ctx context.Context, params *cloudformation.DescribeStacksInput, maxWaitDur time.Duration, optFns ...func(*cloudformation.StackUpdateCompleteWaiterOptions)) error
+}
+
 /*
 defaultCloudFormationFactory is the default implementation of the CloudFormationFactory interface.
 */
@@ -100,6 +120,25 @@ func (f *defaultCloudFormationFactory) GetClient() CloudFormationClient {
 	return f.client
 }
 
+/*
+NewDescribeStacksPaginator creates a new instance of the DescribeStacksPaginator.
+*/
+func (f *defaultCloudFormationFactory) NewDescribeStacksPaginator(params *cloudformation.DescribeStacksInput) (DescribeStacksPaginator, error) {
+	slog.Debug("Creating new DescribeStacks paginator")
+
+	client, err := f.getTypedClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get typed client: %w", err)
+	}
+
+	paginator := cloudformation.NewDescribeStacksPaginator(client, params)
+
+	slog.Debug("DescribeStacks paginator created successfully")
+
+	return paginator, nil
+}
+
 /*
 NewListStacksPaginator creates a new instance of the ListStacksPaginator.
 */
@@ -157,6 +196,25 @@ func (f *defaultCloudFormationFactory) NewStackDeleteCompleteWaiter() (StackDele
 	return waiter, nil
 }
 
+/*
+NewStackUpdateCompleteWaiter creates a new instance of the StackUpdateCompleteWaiter.
+*/
+func (f *defaultCloudFormationFactory) NewStackUpdateCompleteWaiter() (StackUpdateCompleteWaiter, error) {
+	slog.Debug("Creating new StackUpdateComplete waiter")
+
+	client, err := f.getTypedClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get typed client: %w", err)
+	}
+
+	waiter := cloudformation.NewStackUpdateCompleteWaiter(client)
+
+	slog.Debug("StackUpdateComplete waiter created successfully")
+
+	return waiter, nil
+}
+
 /*
 getTypedClient returns the CloudFormation client as the concrete type *cloudformation.Client.
 */
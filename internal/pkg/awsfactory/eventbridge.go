@@ -0,0 +1,71 @@
+package awsfactory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+)
+
+/*
+EventBridgeFactory defines the main interface for creating Amazon EventBridge service clients and helpers.
+*/
+type EventBridgeFactory interface {
+	GetClient() EventBridgeClient
+}
+
+/*
+EventBridgeClient defines the interface for EventBridge operations.
+*/
+type EventBridgeClient interface {
+	DescribeRule(ctx context.Context, params *eventbridge.DescribeRuleInput, optFns ...func(*eventbridge.Options)) (*eventbridge.DescribeRuleOutput, error)
+}
+
+/*
+defaultEventBridgeFactory is the default implementation of the EventBridgeFactory interface.
+*/
+type defaultEventBridgeFactory struct {
+	client EventBridgeClient // EventBridge client
+}
+
+/*
+NewEventBridgeFactory creates and returns a new instance of defaultEventBridgeFactory.
+*/
+func NewEventBridgeFactory() (EventBridgeFactory, error) {
+	client, err := initializeEventBridgeClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize EventBridge client: %w", err)
+	}
+
+	return &defaultEventBridgeFactory{
+		client: client,
+	}, nil
+}
+
+/*
+initializeEventBridgeClient initializes the EventBridge client.
+*/
+func initializeEventBridgeClient() (EventBridgeClient, error) {
+	slog.Debug("Initializing EventBridge client")
+
+	err := loadAWSConfig()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	client := eventbridge.NewFromConfig(cfg)
+
+	slog.Debug("EventBridge client initialized")
+
+	return client, nil
+}
+
+/*
+GetClient returns an instance of the EventBridge client.
+*/
+func (f *defaultEventBridgeFactory) GetClient() EventBridgeClient {
+	return f.client
+}
@@ -0,0 +1,116 @@
+package awsfactory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+/*
+CloudWatchLogsFactory defines the main interface for creating Amazon CloudWatch Logs service clients and helpers.
+*/
+type CloudWatchLogsFactory interface {
+	GetClient() CloudWatchLogsClient
+	NewFilterLogEventsPaginator(params *cloudwatchlogs.FilterLogEventsInput) (FilterLogEventsPaginator, error)
+}
+
+/*
+CloudWatchLogsClient defines the interface for CloudWatch Logs operations.
+*/
+type CloudWatchLogsClient interface {
+	FilterLogEvents(ctx context.Context, params *cloudwatchlogs.FilterLogEventsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.FilterLogEventsOutput, error)
+}
+
+/*
+FilterLogEventsPaginator defines the interface for paginating through log events.
+*/
+type FilterLogEventsPaginator interface {
+	HasMorePages() bool
+	NextPage(ctx context.Context, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.FilterLogEventsOutput, error)
+}
+
+/*
+defaultCloudWatchLogsFactory is the default implementation of the CloudWatchLogsFactory interface.
+*/
+type defaultCloudWatchLogsFactory struct {
+	client CloudWatchLogsClient // CloudWatch Logs client
+}
+
+/*
+NewCloudWatchLogsFactory creates and returns a new instance of defaultCloudWatchLogsFactory.
+*/
+func NewCloudWatchLogsFactory() (CloudWatchLogsFactory, error) {
+	client, err := initializeCloudWatchLogsClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize CloudWatch Logs client: %w", err)
+	}
+
+	return &defaultCloudWatchLogsFactory{
+		client: client,
+	}, nil
+}
+
+/*
+initializeCloudWatchLogsClient initializes the CloudWatch Logs client.
+*/
+func initializeCloudWatchLogsClient() (CloudWatchLogsClient, error) {
+	slog.Debug("Initializing CloudWatch Logs client")
+
+	err := loadAWSConfig()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	client := cloudwatchlogs.NewFromConfig(cfg)
+
+	slog.Debug("CloudWatch Logs client initialized")
+
+	return client, nil
+}
+
+/*
+GetClient returns an instance of the CloudWatch Logs client.
+*/
+func (f *defaultCloudWatchLogsFactory) GetClient() CloudWatchLogsClient {
+	return f.client
+}
+
+/*
+NewFilterLogEventsPaginator creates a new instance of the FilterLogEventsPaginator.
+*/
+func (f *defaultCloudWatchLogsFactory) NewFilterLogEventsPaginator(params *cloudwatchlogs.FilterLogEventsInput) (FilterLogEventsPaginator, error) {
+	slog.Debug("Creating new FilterLogEvents paginator")
+
+	client, err := f.getTypedClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get typed client: %w", err)
+	}
+
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(client, params)
+
+	slog.Debug("FilterLogEvents paginator created successfully")
+
+	return paginator, nil
+}
+
+/*
+getTypedClient returns the CloudWatch Logs client as the concrete type *cloudwatchlogs.Client.
+*/
+func (f *defaultCloudWatchLogsFactory) getTypedClient() (*cloudwatchlogs.Client, error) {
+	slog.Debug("Retrieving typed CloudWatch Logs client")
+
+	typedClient, ok := f.client.(*cloudwatchlogs.Client)
+
+	if !ok {
+		return nil, fmt.Errorf("invalid CloudWatch Logs client type")
+	}
+
+	slog.Debug("Typed CloudWatch Logs client retrieved successfully")
+
+	return typedClient, nil
+}
@@ -14,6 +14,8 @@ RDSFactory defines the main interface for creating Amazon RDS service clients an
 type RDSFactory interface {
 	GetClient() RDSClient
 	NewDescribeDBClustersPaginator(params *rds.DescribeDBClustersInput) (DescribeDBClustersPaginator, error)
+	NewDescribeDBInstancesPaginator(params *rds.DescribeDBInstancesInput) (DescribeDBInstancesPaginator, error)
+	NewDescribeEventsPaginator(params *rds.DescribeEventsInput) (DescribeEventsPaginator, error)
 	NewDescribePendingMaintenanceActionsPaginator(params *rds.DescribePendingMaintenanceActionsInput) (DescribePendingMaintenanceActionsPaginator, error)
 }
 
@@ -21,9 +23,14 @@ type RDSFactory interface {
 RDSClient defines the interface for RDS operations.
 */
 type RDSClient interface {
+	ApplyPendingMaintenanceAction(ctx context.Context, params *rds.ApplyPendingMaintenanceActionInput, optFns ...func(*rds.Options)) (*rds.ApplyPendingMaintenanceActionOutput, error)
 	DescribeDBClusters(ctx context.Context, params *rds.DescribeDBClustersInput, optFns ...func(*rds.Options)) (*rds.DescribeDBClustersOutput, error)
 	DescribeDBInstances(ctx context.Context, params *rds.DescribeDBInstancesInput, optFns ...func(*rds.Options)) (*rds.DescribeDBInstancesOutput, error)
 	DescribePendingMaintenanceActions(ctx context.Context, params *rds.DescribePendingMaintenanceActionsInput, optFns ...func(*rds.Options)) (*rds.DescribePendingMaintenanceActionsOutput, error)
+	StartDBCluster(ctx context.Context, params *rds.StartDBClusterInput, optFns ...func(*rds.Options)) (*rds.StartDBClusterOutput, error)
+	StartDBInstance(ctx context.Context, params *rds.StartDBInstanceInput, optFns ...func(*rds.Options)) (*rds.StartDBInstanceOutput, error)
+	StopDBCluster(ctx context.Context, params *rds.StopDBClusterInput, optFns ...func(*rds.Options)) (*rds.StopDBClusterOutput, error)
+	StopDBInstance(ctx context.Context, params *rds.StopDBInstanceInput, optFns ...func(*rds.Options)) (*rds.StopDBInstanceOutput, error)
 }
 
 /*
@@ -34,6 +41,22 @@ type DescribeDBClustersPaginator interface {
 	NextPage(ctx context.Context, optFns ...func(*rds.Options)) (*rds.DescribeDBClustersOutput, error)
 }
 
+/*
+DescribeDBInstancesPaginator defines the interface for paginating through DB instances.
+*/
+type DescribeDBInstancesPaginator interface {
+	HasMorePages() bool
+	NextPage(ctx context.Context, optFns ...func(*rds.Options)) (*rds.DescribeDBInstancesOutput, error)
+}
+
+/*
+DescribeEventsPaginator defines the interface for paginating through RDS events.
+*/
+type DescribeEventsPaginator interface {
+	HasMorePages() bool
+	NextPage(ctx context.Context, optFns ...func(*rds.Options)) (*rds.DescribeEventsOutput, error)
+}
+
 /*
 DescribePendingMaintenanceActionsPaginator defines the interface for paginating through pending maintenance actions.
 */
@@ -109,6 +132,44 @@ func (f *defaultRDSFactory) NewDescribeDBClustersPaginator(params *rds.DescribeD
 	return paginator, nil
 }
 
+/*
+NewDescribeDBInstancesPaginator creates a new instance of the DescribeDBInstancesPaginator.
+*/
+func (f *defaultRDSFactory) NewDescribeDBInstancesPaginator(params *rds.DescribeDBInstancesInput) (DescribeDBInstancesPaginator, error) {
+	slog.Debug("Creating new DescribeDBInstances paginator")
+
+	client, err := f.getTypedClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get typed client: %w", err)
+	}
+
+	paginator := rds.NewDescribeDBInstancesPaginator(client, params)
+
+	slog.Debug("DescribeDBInstances paginator created successfully")
+
+	return paginator, nil
+}
+
+/*
+NewDescribeEventsPaginator creates a new instance of the DescribeEventsPaginator.
+*/
+func (f *defaultRDSFactory) NewDescribeEventsPaginator(params *rds.DescribeEventsInput) (DescribeEventsPaginator, error) {
+	slog.Debug("Creating new DescribeEvents paginator")
+
+	client, err := f.getTypedClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get typed client: %w", err)
+	}
+
+	paginator := rds.NewDescribeEventsPaginator(client, params)
+
+	slog.Debug("DescribeEvents paginator created successfully")
+
+	return paginator, nil
+}
+
 /*
 NewDescribePendingMaintenanceActionsPaginator creates a new instance of the DescribePendingMaintenanceActionsPaginator.
 */
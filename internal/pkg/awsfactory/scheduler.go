@@ -0,0 +1,71 @@
+package awsfactory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/service/scheduler"
+)
+
+/*
+SchedulerFactory defines the main interface for creating Amazon EventBridge Scheduler service clients and helpers.
+*/
+type SchedulerFactory interface {
+	GetClient() SchedulerClient
+}
+
+/*
+SchedulerClient defines the interface for EventBridge Scheduler operations.
+*/
+type SchedulerClient interface {
+	GetSchedule(ctx context.Context, params *scheduler.GetScheduleInput, optFns ...func(*scheduler.Options)) (*scheduler.GetScheduleOutput, error)
+}
+
+/*
+defaultSchedulerFactory is the default implementation of the SchedulerFactory interface.
+*/
+type defaultSchedulerFactory struct {
+	client SchedulerClient // EventBridge Scheduler client
+}
+
+/*
+NewSchedulerFactory creates and returns a new instance of defaultSchedulerFactory.
+*/
+func NewSchedulerFactory() (SchedulerFactory, error) {
+	client, err := initializeSchedulerClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize EventBridge Scheduler client: %w", err)
+	}
+
+	return &defaultSchedulerFactory{
+		client: client,
+	}, nil
+}
+
+/*
+initializeSchedulerClient initializes the EventBridge Scheduler client.
+*/
+func initializeSchedulerClient() (SchedulerClient, error) {
+	slog.Debug("Initializing EventBridge Scheduler client")
+
+	err := loadAWSConfig()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	client := scheduler.NewFromConfig(cfg)
+
+	slog.Debug("EventBridge Scheduler client initialized")
+
+	return client, nil
+}
+
+/*
+GetClient returns an instance of the EventBridge Scheduler client.
+*/
+func (f *defaultSchedulerFactory) GetClient() SchedulerClient {
+	return f.client
+}
@@ -0,0 +1,147 @@
+package awsfactory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+)
+
+/*
+SFNFactory defines the main interface for creating AWS Step Functions service clients and helpers.
+*/
+type SFNFactory interface {
+	GetClient() SFNClient
+	NewListExecutionsPaginator(params *sfn.ListExecutionsInput) (ListExecutionsPaginator, error)
+	NewGetExecutionHistoryPaginator(params *sfn.GetExecutionHistoryInput) (GetExecutionHistoryPaginator, error)
+}
+
+/*
+SFNClient defines the interface for Step Functions operations.
+*/
+type SFNClient interface {
+	DescribeExecution(ctx context.Context, params *sfn.DescribeExecutionInput, optFns ...func(*sfn.Options)) (*sfn.DescribeExecutionOutput, error)
+	GetExecutionHistory(ctx context.Context, params *sfn.GetExecutionHistoryInput, optFns ...func(*sfn.Options)) (*sfn.GetExecutionHistoryOutput, error)
+	ListExecutions(ctx context.Context, params *sfn.ListExecutionsInput, optFns ...func(*sfn.Options)) (*sfn.ListExecutionsOutput, error)
+	StartExecution(ctx context.Context, params *sfn.StartExecutionInput, optFns ...func(*sfn.Options)) (*sfn.StartExecutionOutput, error)
+}
+
+/*
+ListExecutionsPaginator defines the interface for paginating through state machine executions.
+*/
+type ListExecutionsPaginator interface {
+	HasMorePages() bool
+	NextPage(ctx context.Context, optFns ...func(*sfn.Options)) (*sfn.ListExecutionsOutput, error)
+}
+
+/*
+GetExecutionHistoryPaginator defines the interface for paginating through execution history events.
+*/
+type GetExecutionHistoryPaginator interface {
+	HasMorePages() bool
+	NextPage(ctx context.Context, optFns ...func(*sfn.Options)) (*sfn.GetExecutionHistoryOutput, error)
+}
+
+/*
+defaultSFNFactory is the default implementation of the SFNFactory interface.
+*/
+type defaultSFNFactory struct {
+	client SFNClient // Step Functions client
+}
+
+/*
+NewSFNFactory creates and returns a new instance of defaultSFNFactory.
+*/
+func NewSFNFactory() (SFNFactory, error) {
+	client, err := initializeSFNClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Step Functions client: %w", err)
+	}
+
+	return &defaultSFNFactory{
+		client: client,
+	}, nil
+}
+
+/*
+initializeSFNClient initializes the Step Functions client.
+*/
+func initializeSFNClient() (SFNClient, error) {
+	slog.Debug("Initializing Step Functions client")
+
+	err := loadAWSConfig()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	client := sfn.NewFromConfig(cfg)
+
+	slog.Debug("Step Functions client initialized")
+
+	return client, nil
+}
+
+/*
+GetClient returns an instance of the Step Functions client.
+*/
+func (f *defaultSFNFactory) GetClient() SFNClient {
+	return f.client
+}
+
+/*
+NewListExecutionsPaginator creates a new instance of the ListExecutionsPaginator.
+*/
+func (f *defaultSFNFactory) NewListExecutionsPaginator(params *sfn.ListExecutionsInput) (ListExecutionsPaginator, error) {
+	slog.Debug("Creating new ListExecutions paginator")
+
+	client, err := f.getTypedClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get typed client: %w", err)
+	}
+
+	paginator := sfn.NewListExecutionsPaginator(client, params)
+
+	slog.Debug("ListExecutions paginator created successfully")
+
+	return paginator, nil
+}
+
+/*
+NewGetExecutionHistoryPaginator creates a new instance of the GetExecutionHistoryPaginator.
+*/
+func (f *defaultSFNFactory) NewGetExecutionHistoryPaginator(params *sfn.GetExecutionHistoryInput) (GetExecutionHistoryPaginator, error) {
+	slog.Debug("Creating new GetExecutionHistory paginator")
+
+	client, err := f.getTypedClient()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get typed client: %w", err)
+	}
+
+	paginator := sfn.NewGetExecutionHistoryPaginator(client, params)
+
+	slog.Debug("GetExecutionHistory paginator created successfully")
+
+	return paginator, nil
+}
+
+/*
+getTypedClient returns the Step Functions client as the concrete type *sfn.Client.
+*/
+func (f *defaultSFNFactory) getTypedClient() (*sfn.Client, error) {
+	slog.Debug("Retrieving typed Step Functions client")
+
+	typedClient, ok := f.client.(*sfn.Client)
+
+	if !ok {
+		return nil, fmt.Errorf("invalid Step Functions client type")
+	}
+
+	slog.Debug("Typed Step Functions client retrieved successfully")
+
+	return typedClient, nil
+}
@@ -22,6 +22,10 @@ type CloudFormation struct {
 const (
 	generatorName    = "koreru-toki-no-hiho" // name of the generator
 	generatorVersion = "1"                   // current version of the generator
+
+	// descriptionPrefix starts the descriptions of the generated templates,
+	// by which stacks not named after the prefix are told apart from other stacks without retrieving their templates
+	descriptionPrefix = "ktnh - "
 )
 
 /*
@@ -0,0 +1,153 @@
+package cfn
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+templateFiles maps the names of the files that can override the embedded templates
+to the names of the templates defined in templateStr (see `gen/main.go`).
+*/
+var templateFiles = map[string]string{
+	"statemachine.aurora.json":   "stateMachineAurora",
+	"statemachine.rds.json":      "stateMachineRDS",
+	"cloudformation.yml":         "cloudformation",
+	"cloudformation.generic.yml": "cloudformationGeneric",
+	"terraform.tf":               "terraform",
+}
+
+/*
+requiredLogicalIDs lists the resources that ktnh refers to in the stack.
+*/
+var requiredLogicalIDs = []string{
+	LogicalIDStateMachine,
+	LogicalIDStateMachineLogGroup,
+	LogicalIDAutoStartEventRule,
+	LogicalIDPeriodicStopSchedule,
+}
+
+var (
+	// templateDir is the directory holding the files that override the embedded templates (empty to use the embedded ones)
+	templateDir string
+
+	// templatePatchFiles are the paths of the patches applied to the rendered CloudFormation templates
+	templatePatchFiles []string
+)
+
+/*
+SetTemplateDir sets the directory whose files override the embedded templates.
+The files are named after the sources of the embedded templates (e.g., `cloudformation.yml`),
+and the missing ones fall back to the embedded templates.
+*/
+func SetTemplateDir(dir string) {
+	templateDir = dir
+}
+
+/*
+SetTemplatePatches sets the patches applied in order to the rendered CloudFormation templates.
+*/
+func SetTemplatePatches(paths []string) {
+	templatePatchFiles = paths
+}
+
+/*
+loadTemplateOverrides reads the files in the template directory, and returns their contents
+keyed by the template name. Returns nil if no directory is set.
+*/
+func loadTemplateOverrides() (map[string]string, error) {
+	if templateDir == "" {
+		return nil, nil
+	}
+
+	overrides := map[string]string{}
+
+	for filename, name := range templateFiles {
+		path := filepath.Join(templateDir, filename)
+
+		content, err := os.ReadFile(path)
+
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file '%s': %w", path, err)
+		}
+
+		slog.Debug("Overriding embedded template", "name", name, "path", path)
+
+		overrides[name] = strings.TrimSpace(string(content))
+	}
+
+	if len(overrides) == 0 {
+		return nil, fmt.Errorf("no template files found in '%s'", templateDir)
+	}
+
+	return overrides, nil
+}
+
+/*
+loadTemplatePatches reads and parses the patch files.
+*/
+func loadTemplatePatches() ([]*templatePatch, error) {
+	patches := make([]*templatePatch, 0, len(templatePatchFiles))
+
+	for _, path := range templatePatchFiles {
+		data, err := os.ReadFile(path)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template patch '%s': %w", path, err)
+		}
+
+		patch, err := parseTemplatePatch(path, data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, patch)
+	}
+
+	return patches, nil
+}
+
+/*
+renderCloudFormation renders the named CloudFormation template, applies the patches,
+and checks the result (see `CheckTemplate`). Returns a TemplateError if any problem is found.
+*/
+func renderCloudFormation(name string, data templateData, dbType string) (string, error) {
+	templateBody, err := renderTemplate(name, data)
+
+	if err != nil {
+		return "", err
+	}
+
+	patches, err := loadTemplatePatches()
+
+	if err != nil {
+		return "", err
+	}
+
+	templateBody, err = applyTemplatePatches(templateBody, patches)
+
+	if err != nil {
+		return "", err
+	}
+
+	problems := CheckTemplate(templateBody, &MetadataVerifyOption{
+		DBIdentifier: data.DBIdentifier,
+		DBType:       dbType,
+	})
+
+	if len(problems) != 0 {
+		return "", &TemplateError{Problems: problems, TemplateBody: templateBody}
+	}
+
+	return templateBody, nil
+}
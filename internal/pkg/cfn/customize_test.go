@@ -0,0 +1,142 @@
+package cfn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateTemplateBody_Customized(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		dir      bool
+		patches  []string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name: "Template directory",
+			files: map[string]string{
+				"statemachine.rds.json": `{"Comment": "custom state machine for {{ .DBIdentifier }}", "StartAt": "Done", "States": {"Done": {"Type": "Succeed"}}}`,
+			},
+			dir:      true,
+			expected: `"Comment": "custom state machine for rds-db-identifier"`,
+			wantErr:  false,
+		},
+		{
+			name: "Patch",
+			files: map[string]string{
+				"patch.yaml": "Resources:\n  StateMachineLogGroup:\n    Properties:\n      RetentionInDays: 90\n",
+			},
+			patches:  []string{"patch.yaml"},
+			expected: "RetentionInDays: 90",
+			wantErr:  false,
+		},
+		{
+			name: "Patch removing required resource",
+			files: map[string]string{
+				"patch.json": `[{"op": "remove", "path": "/Resources/StateMachine"}]`,
+			},
+			patches: []string{"patch.json"},
+			wantErr: true,
+		},
+		{
+			name: "Invalid state machine",
+			files: map[string]string{
+				"statemachine.rds.json": `{"StartAt": "Missing", "States": {}}`,
+			},
+			dir:     true,
+			wantErr: true,
+		},
+		{
+			name:    "Missing patch",
+			patches: []string{"missing.yaml"},
+			wantErr: true,
+		},
+		{
+			name:    "Empty template directory",
+			dir:     true,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			for filename, content := range tc.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0o644); err != nil {
+					t.Fatalf("failed to write test file: %v", err)
+				}
+			}
+
+			t.Cleanup(func() {
+				SetTemplateDir("")
+				SetTemplatePatches(nil)
+			})
+
+			if tc.dir {
+				SetTemplateDir(tmpDir)
+			}
+
+			var patches []string
+
+			for _, patch := range tc.patches {
+				patches = append(patches, filepath.Join(tmpDir, patch))
+			}
+
+			SetTemplatePatches(patches)
+
+			got, err := GenerateTemplateBody("ktnh", "rds-db-identifier", "rds-db-ide", "rds", "ghijklm")
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Contains(t, got, tc.expected, "Customization should be reflected in the template")
+			}
+		})
+	}
+}
+
+func Test_GenerateTemplateBody_TemplateError(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	patch := filepath.Join(tmpDir, "patch.json")
+
+	if err := os.WriteFile(patch, []byte(`[{"op": "remove", "path": "/Resources/StateMachine"}]`), 0o644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	t.Cleanup(func() {
+		SetTemplatePatches(nil)
+	})
+
+	SetTemplatePatches([]string{patch})
+
+	_, err := GenerateTemplateBody("ktnh", "rds-db-identifier", "rds-db-ide", "rds", "ghijklm")
+
+	var templateErr *TemplateError
+
+	if assert.ErrorAs(t, err, &templateErr, "Expected a TemplateError to be returned") {
+		assert.NotEmpty(t, templateErr.Problems, "Problems should be reported")
+		assert.Contains(t, templateErr.TemplateBody, "StateMachineLogGroup:", "Rendered template should be kept in the error")
+		assert.NotContains(t, templateErr.TemplateBody, "  StateMachine:", "Patches should be applied to the kept template")
+	}
+}
+
+func Test_GenerateTerraform_Patched(t *testing.T) {
+	t.Cleanup(func() {
+		SetTemplatePatches(nil)
+	})
+
+	SetTemplatePatches([]string{"patch.yaml"})
+
+	_, err := GenerateTerraform("ktnh", "rds-db-identifier", "rds-db-ide", "rds", "ghijklm")
+
+	assert.Error(t, err, "Patches should not be applied to Terraform configuration")
+}
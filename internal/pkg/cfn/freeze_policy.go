@@ -0,0 +1,69 @@
+package cfn
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+const (
+	DefaultScheduleExpression = "rate(6 hours)" // default schedule of the periodic stop
+	DefaultLogRetentionInDays = 14              // default retention period of the state machine logs in days
+)
+
+/*
+logRetentionDays lists the retention periods in days accepted by CloudWatch Logs.
+*/
+var logRetentionDays = []int{1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653}
+
+/*
+scheduleExpressionPattern matches the rate and cron expressions of EventBridge Scheduler.
+Quotes are rejected, since the expression is embedded in the templates as a quoted string.
+*/
+var scheduleExpressionPattern = regexp.MustCompile(`^(?:rate|cron)\([^'"]+\)$`)
+
+var (
+	scheduleExpression = DefaultScheduleExpression // schedule of the periodic stop (see `SetFreezePolicy`)
+	logRetentionInDays = DefaultLogRetentionInDays // retention period of the state machine logs (see `SetFreezePolicy`)
+)
+
+/*
+SetFreezePolicy sets the schedule of the periodic stop and the retention period of the state machine logs
+used by the templates. They are the defaults of the stack parameters of the same names.
+*/
+func SetFreezePolicy(schedule string, retentionInDays int) error {
+	if err := ValidateScheduleExpression(schedule); err != nil {
+		return err
+	}
+
+	if err := ValidateLogRetention(retentionInDays); err != nil {
+		return err
+	}
+
+	scheduleExpression = schedule
+	logRetentionInDays = retentionInDays
+
+	return nil
+}
+
+/*
+ValidateScheduleExpression validates whether the schedule is a rate or cron expression of EventBridge Scheduler.
+*/
+func ValidateScheduleExpression(schedule string) error {
+	if !scheduleExpressionPattern.MatchString(schedule) {
+		return fmt.Errorf("schedule '%s' must be a rate or cron expression (e.g., 'rate(6 hours)')", schedule)
+	}
+
+	return nil
+}
+
+/*
+ValidateLogRetention validates whether the retention period in days is accepted by CloudWatch Logs.
+*/
+func ValidateLogRetention(retentionInDays int) error {
+	if !slices.Contains(logRetentionDays, retentionInDays) {
+		return fmt.Errorf("log retention %d must be one of %v days", retentionInDays, logRetentionDays)
+	}
+
+	return nil
+}
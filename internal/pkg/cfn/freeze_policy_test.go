@@ -0,0 +1,87 @@
+package cfn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetFreezePolicy(t *testing.T) {
+	testCases := []struct {
+		name               string
+		scheduleExpression string
+		logRetentionInDays int
+		wantErr            bool
+	}{
+		{
+			name:               "Default",
+			scheduleExpression: DefaultScheduleExpression,
+			logRetentionInDays: DefaultLogRetentionInDays,
+			wantErr:            false,
+		},
+		{
+			name:               "Cron expression",
+			scheduleExpression: "cron(0 */3 * * ? *)",
+			logRetentionInDays: 90,
+			wantErr:            false,
+		},
+		{
+			name:               "Not a schedule expression",
+			scheduleExpression: "6 hours",
+			logRetentionInDays: 14,
+			wantErr:            true,
+		},
+		{
+			name:               "Quoted schedule expression",
+			scheduleExpression: "rate(6 hours)'",
+			logRetentionInDays: 14,
+			wantErr:            true,
+		},
+		{
+			name:               "Unsupported log retention",
+			scheduleExpression: "rate(6 hours)",
+			logRetentionInDays: 10,
+			wantErr:            true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				_ = SetFreezePolicy(DefaultScheduleExpression, DefaultLogRetentionInDays)
+			})
+
+			err := SetFreezePolicy(tc.scheduleExpression, tc.logRetentionInDays)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+		})
+	}
+}
+
+func Test_GenerateTemplateBody_FreezePolicy(t *testing.T) {
+	t.Cleanup(func() {
+		_ = SetFreezePolicy(DefaultScheduleExpression, DefaultLogRetentionInDays)
+	})
+
+	err := SetFreezePolicy("rate(12 hours)", 30)
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	templateBody, err := GenerateTemplateBody("ktnh", "rds-db-identifier", "rds-db-ide", "rds", "ghijklm")
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	assert.Contains(t, templateBody, "Default: 'rate(12 hours)'", "Schedule parameter should default to the freeze policy")
+	assert.Contains(t, templateBody, "Default: 30", "Log retention parameter should default to the freeze policy")
+
+	genericBody, err := GenerateGenericTemplateBody("ktnh")
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	assert.Contains(t, genericBody, "Default: 'rate(12 hours)'", "Schedule parameter should default to the freeze policy")
+	assert.Contains(t, genericBody, "Default: 30", "Log retention parameter should default to the freeze policy")
+}
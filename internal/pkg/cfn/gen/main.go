@@ -15,6 +15,8 @@ func main() {
 	statemachineAuroraContent := readFile("./statemachine.aurora.json")
 	statemachineRdsContent := readFile("./statemachine.rds.json")
 	cloudformationContent := readFile("./cloudformation.yml")
+	cloudformationGenericContent := readFile("./cloudformation.generic.yml")
+	terraformContent := readFile("./terraform.tf")
 
 	code := `// Code generated by gen/main.go; DO NOT EDIT.
 
@@ -33,6 +35,14 @@ const templateStr = ` + "`" + `
 %s
 {{- end -}}
 
+{{- define "cloudformationGeneric" -}}
+%s
+{{- end -}}
+
+{{- define "terraform" -}}
+%s
+{{- end -}}
+
 {{- template "cloudformation" . -}}
 ` + "`"
 
@@ -41,6 +51,8 @@ const templateStr = ` + "`" + `
 		escapeBackticks(statemachineAuroraContent),
 		escapeBackticks(statemachineRdsContent),
 		escapeBackticks(cloudformationContent),
+		escapeBackticks(cloudformationGenericContent),
+		escapeBackticks(terraformContent),
 	)
 
 	writeFile("../template_def.go", output)
@@ -0,0 +1,228 @@
+# Generated by {{ .GeneratorName }} version {{ .GeneratorVersion }}
+# DBIdentifier: {{ .DBIdentifier }}
+# DBType: {{ .DBType }}
+
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.40"
+    }
+  }
+}
+
+variable "protection_state" {
+  type        = string
+  description = "State of the event rule and the schedule (DISABLED while protection is paused)"
+  default     = "ENABLED"
+
+  validation {
+    condition     = contains(["ENABLED", "DISABLED"], var.protection_state)
+    error_message = "The protection_state must be either ENABLED or DISABLED."
+  }
+}
+
+data "aws_partition" "current" {}
+
+data "aws_region" "current" {}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_iam_role" "state_machine_execution" {
+  name        = "{{ .Names.StateMachineExecutionRole }}"
+  description = "Execution role for the ktnh state machine"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = "sts:AssumeRole"
+        Principal = {
+          Service = "states.amazonaws.com"
+        }
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "state_machine_execution_rds" {
+  name = "rds"
+  role = aws_iam_role.state_machine_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "rds:DescribeDB{{ if eq .DBType "aurora" }}Cluster{{ else }}Instance{{ end }}s",
+          "rds:StopDB{{ if eq .DBType "aurora" }}Cluster{{ else }}Instance{{ end }}",
+        ]
+        Resource = [
+          "arn:${data.aws_partition.current.partition}:rds:${data.aws_region.current.id}:${data.aws_caller_identity.current.account_id}:{{ if eq .DBType "aurora" }}cluster{{ else }}db{{ end }}:{{ .DBIdentifier }}",
+        ]
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "state_machine_execution_logs" {
+  name = "logs"
+  role = aws_iam_role.state_machine_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "logs:CreateLogDelivery",
+          "logs:GetLogDelivery",
+          "logs:UpdateLogDelivery",
+          "logs:DeleteLogDelivery",
+          "logs:ListLogDeliveries",
+          "logs:PutLogEvents",
+          "logs:PutResourcePolicy",
+          "logs:DescribeResourcePolicies",
+          "logs:DescribeLogGroups",
+        ]
+        Resource = "*"
+      },
+    ]
+  })
+}
+
+resource "aws_cloudwatch_log_group" "state_machine" {
+  name              = "{{ .Names.StateMachineLogGroup }}"
+  retention_in_days = {{ .LogRetentionInDays }}
+}
+
+locals {
+  # The partition is substituted for "${Partition}" in the same way as DefinitionSubstitutions of CloudFormation.
+  state_machine_definition = <<-EOT
+    {{- if eq .DBType "aurora" }}
+    {{-   include "stateMachineAurora" . | escapeHCL | indent 4 | printf "\n%s" }}
+    {{- else }}
+    {{-   include "stateMachineRDS" . | escapeHCL | indent 4 | printf "\n%s" }}
+    {{- end }}
+  EOT
+}
+
+resource "aws_sfn_state_machine" "state_machine" {
+  name     = "{{ .Names.StateMachine }}"
+  role_arn = aws_iam_role.state_machine_execution.arn
+
+  definition = replace(local.state_machine_definition, "$${Partition}", data.aws_partition.current.partition)
+
+  logging_configuration {
+    level                  = "ALL"
+    include_execution_data = true
+    log_destination        = "${aws_cloudwatch_log_group.state_machine.arn}:*"
+  }
+
+  depends_on = [
+    aws_iam_role_policy.state_machine_execution_rds,
+    aws_iam_role_policy.state_machine_execution_logs,
+  ]
+}
+
+resource "aws_iam_role" "events" {
+  name        = "{{ .Names.EventsRole }}"
+  description = "Role used by EventBridge rule and scheduler to trigger the ktnh state machine"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = "sts:AssumeRole"
+        Principal = {
+          Service = [
+            "events.amazonaws.com",
+            "scheduler.amazonaws.com",
+          ]
+        }
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "events_statemachine" {
+  name = "statemachine"
+  role = aws_iam_role.events.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "states:StartExecution",
+        ]
+        Resource = aws_sfn_state_machine.state_machine.arn
+      },
+    ]
+  })
+}
+
+resource "aws_cloudwatch_event_rule" "rds_auto_start" {
+  name        = "{{ .Names.AutoStartEventRule }}"
+  description = "Rule to capture Aurora cluster or RDS instance auto-start events and trigger Step Functions"
+  state       = var.protection_state
+
+  event_pattern = jsonencode({
+    source = [
+      "aws.rds",
+    ]
+    detail-type = [
+      "RDS DB {{ if eq .DBType "aurora" }}Cluster{{ else }}Instance{{ end }} Event",
+    ]
+    detail = {
+      EventID = [
+        "RDS-EVENT-{{ if eq .DBType "aurora" }}0153{{ else }}0154{{ end }}",
+      ]
+      SourceIdentifier = [
+        "{{ .DBIdentifier }}",
+      ]
+    }
+  })
+}
+
+resource "aws_cloudwatch_event_target" "rds_auto_start" {
+  rule      = aws_cloudwatch_event_rule.rds_auto_start.name
+  target_id = "stop"
+  arn       = aws_sfn_state_machine.state_machine.arn
+  role_arn  = aws_iam_role.events.arn
+
+  retry_policy {
+    maximum_event_age_in_seconds = 86400
+    maximum_retry_attempts       = 185
+  }
+}
+
+resource "aws_scheduler_schedule" "periodic_stop" {
+  name                = "{{ .Names.PeriodicStopSchedule }}"
+  description         = "Schedule to stop Aurora cluster or RDS instance periodically as a backup mechanism"
+  state               = var.protection_state
+  schedule_expression = "{{ .ScheduleExpression }}"
+
+  target {
+    arn      = aws_sfn_state_machine.state_machine.arn
+    role_arn = aws_iam_role.events.arn
+
+    retry_policy {
+      maximum_event_age_in_seconds = 1800
+      maximum_retry_attempts       = 3
+    }
+  }
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+}
+
+output "state_machine_arn" {
+  description = "ARN of the ktnh state machine"
+  value       = aws_sfn_state_machine.state_machine.arn
+}
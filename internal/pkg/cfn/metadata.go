@@ -4,12 +4,19 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"regexp"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"gopkg.in/yaml.v3"
 )
 
+/*
+metadataPlaceholderPattern matches a metadata value that refers to a stack parameter (e.g., "${DBIdentifier}").
+*/
+var metadataPlaceholderPattern = regexp.MustCompile(`^\$\{([A-Za-z0-9]+)\}$`)
+
 /*
 MetadataVerifyOption defines options for metadata verification.
 */
@@ -24,6 +31,7 @@ ktnhMetadata defines the structure of the `Metadata.KTNH` section in CloudFormat
 type ktnhMetadata struct {
 	Generator    string `yaml:"Generator"`    // generator name
 	Version      string `yaml:"Version"`      // version of the generator
+	Prefix       string `yaml:"Prefix"`       // prefix under which the stack is managed (empty for stacks created before it was recorded)
 	DBIdentifier string `yaml:"DBIdentifier"` // DB cluster/instance identifier
 	DBType       string `yaml:"DBType"`       // type of the DB (see `internal/pkg/rds`)
 }
@@ -60,7 +68,8 @@ func VerifyMetadata(metadata *ktnhMetadata, option *MetadataVerifyOption) (bool,
 		return false, nil
 	}
 
-	if (option.DBIdentifier != "") && (metadata.DBIdentifier != option.DBIdentifier) {
+	// NOTE: DB identifiers are case-insensitive, and the metadata keeps the case given on freeze.
+	if (option.DBIdentifier != "") && !strings.EqualFold(metadata.DBIdentifier, option.DBIdentifier) {
 		slog.Debug("DB identifier mismatch",
 			"expected", option.DBIdentifier,
 			"actual", metadata.DBIdentifier,
@@ -147,11 +156,16 @@ func (c *CloudFormation) GetKTNHMetadata(stackName string) (*ktnhMetadata, error
 		return nil, fmt.Errorf("failed to extract metadata from template: %w", err)
 	}
 
+	if err := c.resolveMetadataPlaceholders(stackName, &template.Metadata.KTNH); err != nil {
+		return nil, fmt.Errorf("failed to resolve metadata: %w", err)
+	}
+
 	slog.Debug("Metadata retrieved successfully")
 
 	slog.Debug("Extracted metadata from template",
 		"Generator", template.Metadata.KTNH.Generator,
 		"Version", template.Metadata.KTNH.Version,
+		"Prefix", template.Metadata.KTNH.Prefix,
 		"DBIdentifier", template.Metadata.KTNH.DBIdentifier,
 		"DBType", template.Metadata.KTNH.DBType,
 	)
@@ -159,6 +173,53 @@ func (c *CloudFormation) GetKTNHMetadata(stackName string) (*ktnhMetadata, error
 	return &template.Metadata.KTNH, nil
 }
 
+/*
+resolveMetadataPlaceholders replaces the placeholders in the metadata with the parameter values of the stack.
+Stacks created from the generic template declare the DB identifier and the DB type this way,
+since the `Metadata` section cannot refer to parameters by itself.
+The stack is described only if the metadata contains any placeholder.
+*/
+func (c *CloudFormation) resolveMetadataPlaceholders(stackName string, metadata *ktnhMetadata) error {
+	var stack *Stack
+
+	for _, field := range []*string{&metadata.DBIdentifier, &metadata.DBType} {
+		match := metadataPlaceholderPattern.FindStringSubmatch(*field)
+
+		if match == nil {
+			continue
+		}
+
+		if stack == nil {
+			s, err := c.DescribeStack(stackName)
+
+			if err != nil {
+				return err
+			}
+
+			stack = s
+		}
+
+		value, ok := stack.Parameters[match[1]]
+
+		if !ok {
+			return fmt.Errorf("parameter '%s' referred to by metadata is not defined in stack '%s'", match[1], stackName)
+		}
+
+		slog.Debug("Resolved metadata placeholder", "parameter", match[1], "value", value)
+
+		*field = value
+	}
+
+	return nil
+}
+
+/*
+metadataPlaceholder returns the placeholder that refers to the stack parameter in the metadata.
+*/
+func metadataPlaceholder(parameter string) string {
+	return "${" + parameter + "}"
+}
+
 /*
 getStackTemplate retrieves the template body of a given stack.
 */
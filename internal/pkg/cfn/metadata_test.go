@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
@@ -55,6 +56,90 @@ func Test_GetKTNHMetadata(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:      "Generic template",
+			stackName: "generic-stack",
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				templateBody := strings.Join([]string{
+					"Metadata:",
+					"  KTNH:",
+					"    Generator: 'test-generator'",
+					"    Version: '10'",
+					"    Prefix: 'SC'",
+					"    DBIdentifier: '${DBIdentifier}'",
+					"    DBType: '${DBType}'",
+				}, "\n")
+
+				c.On("GetTemplate", mock.Anything, &cloudformation.GetTemplateInput{
+					StackName: aws.String("generic-stack"),
+				}, mock.Anything).
+					Return(&cloudformation.GetTemplateOutput{
+						TemplateBody: aws.String(templateBody),
+					}, nil)
+
+				c.On("DescribeStacks", mock.Anything, &cloudformation.DescribeStacksInput{
+					StackName: aws.String("generic-stack"),
+				}, mock.Anything).
+					Return(&cloudformation.DescribeStacksOutput{
+						Stacks: []types.Stack{
+							{
+								StackName: aws.String("generic-stack"),
+								Parameters: []types.Parameter{
+									{ParameterKey: aws.String("DBIdentifier"), ParameterValue: aws.String("test-db")},
+									{ParameterKey: aws.String("DBType"), ParameterValue: aws.String("rds")},
+								},
+							},
+						},
+					}, nil).
+					Once()
+			},
+			expected: &ktnhMetadata{
+				Generator:    "test-generator",
+				Version:      "10",
+				Prefix:       "SC",
+				DBIdentifier: "test-db",
+				DBType:       "rds",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Undefined parameter",
+			stackName: "undefined-parameter-stack",
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				templateBody := strings.Join([]string{
+					"Metadata:",
+					"  KTNH:",
+					"    Generator: 'test-generator'",
+					"    Version: '10'",
+					"    DBIdentifier: '${Unknown}'",
+					"    DBType: 'aurora'",
+				}, "\n")
+
+				c.On("GetTemplate", mock.Anything, &cloudformation.GetTemplateInput{
+					StackName: aws.String("undefined-parameter-stack"),
+				}, mock.Anything).
+					Return(&cloudformation.GetTemplateOutput{
+						TemplateBody: aws.String(templateBody),
+					}, nil)
+
+				c.On("DescribeStacks", mock.Anything, &cloudformation.DescribeStacksInput{
+					StackName: aws.String("undefined-parameter-stack"),
+				}, mock.Anything).
+					Return(&cloudformation.DescribeStacksOutput{
+						Stacks: []types.Stack{
+							{StackName: aws.String("undefined-parameter-stack")},
+						},
+					}, nil)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
 		{
 			name:      "API error",
 			stackName: "api-error-stack",
@@ -145,6 +230,21 @@ func Test_VerifyMetadata(t *testing.T) {
 			expected: true,
 			wantErr:  false,
 		},
+		{
+			name: "DB identifier in different case",
+			metadata: &ktnhMetadata{
+				Generator:    "koreru-toki-no-hiho",
+				Version:      "1",
+				DBIdentifier: "My-DB",
+				DBType:       "rds",
+			},
+			option: &MetadataVerifyOption{
+				DBIdentifier: "my-db",
+				DBType:       "rds",
+			},
+			expected: true,
+			wantErr:  false,
+		},
 		{
 			name: "No options",
 			metadata: &ktnhMetadata{
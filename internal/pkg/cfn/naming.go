@@ -0,0 +1,213 @@
+package cfn
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"text/template"
+)
+
+/*
+DefaultNameTemplate is the naming template of the physical resource names.
+With the default prefix, it gives the names used before the naming template was introduced
+(e.g., "ktnh-sfn-my-db-abc123" for the execution role of the state machine, and "ktnh-my-db-abc123" for the state machine).
+*/
+const DefaultNameTemplate = `{{ .Prefix }}{{ with .Component }}-{{ . }}{{ end }}{{ with .DBIdentifierShort }}-{{ . }}{{ end }}-{{ .Qualifier }}`
+
+/*
+maxResourceNameLength is the maximum length of the physical resource names,
+which is the shortest limit among the resources (IAM roles, EventBridge rules and schedules).
+*/
+const maxResourceNameLength = 64
+
+/*
+resourceComponents maps the logical IDs of the named resources to the components of their names.
+*/
+var resourceComponents = map[string]string{
+	LogicalIDStateMachineExecutionRole: "sfn",
+	LogicalIDStateMachineLogGroup:      "sfn",
+	LogicalIDStateMachine:              "",
+	LogicalIDEventsRole:                "events",
+	LogicalIDAutoStartEventRule:        "autostart",
+	LogicalIDPeriodicStopSchedule:      "periodicstop",
+}
+
+var (
+	// resourceNamePattern matches the characters allowed in the names of all the named resources
+	resourceNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+	// namePlaceholderPattern matches the placeholders of `!Sub` in the names of the generic template
+	namePlaceholderPattern = regexp.MustCompile(`\$\{[A-Za-z0-9]+\}`)
+
+	// wildcardsPattern matches the span between the first and the last wildcards of a name pattern
+	wildcardsPattern = regexp.MustCompile(`\*.*\*`)
+)
+
+/*
+nameTemplate is the parsed naming template (see `SetNameTemplate`).
+*/
+var nameTemplate = template.Must(parseNameTemplate(DefaultNameTemplate))
+
+/*
+NameData represents the data used to populate the naming template.
+*/
+type NameData struct {
+	Prefix            string // prefix given by `--prefix`
+	Component         string // component of the name (e.g., "sfn", "events"; empty for the state machine)
+	LogicalID         string // logical ID of the resource (e.g., "StateMachineExecutionRole")
+	DBIdentifier      string // DB cluster/instance identifier
+	DBIdentifierShort string // shortened DB identifier
+	Qualifier         string // unique qualifier specific to the stack
+}
+
+/*
+resourceNames holds the physical names of the resources, referred to by the templates as `.Names`.
+*/
+type resourceNames struct {
+	StateMachineExecutionRole string // name of the execution role of the state machine
+	StateMachineLogGroup      string // name of the log group for the state machine
+	StateMachine              string // name of the state machine
+	EventsRole                string // name of the role used by the event rule and the schedule
+	AutoStartEventRule        string // name of the event rule for auto-start events
+	PeriodicStopSchedule      string // name of the schedule for periodic stop
+}
+
+/*
+parseNameTemplate parses the naming template. Fields that do not exist are reported as errors.
+*/
+func parseNameTemplate(text string) (*template.Template, error) {
+	return template.New("name").Option("missingkey=error").Parse(text)
+}
+
+/*
+SetNameTemplate sets the naming template of the physical resource names, which is a Go template
+populated with NameData (see `DefaultNameTemplate`).
+The template is checked by rendering sample names, which must be valid and must differ between stacks.
+*/
+func SetNameTemplate(text string) error {
+	t, err := parseNameTemplate(text)
+
+	if err != nil {
+		return fmt.Errorf("failed to parse naming template: %w", err)
+	}
+
+	sample := func(qualifier string) (*resourceNames, error) {
+		return renderResourceNames(t, &NameData{
+			Prefix:            "ktnh",
+			DBIdentifier:      "my-db",
+			DBIdentifierShort: "my-db",
+			Qualifier:         qualifier,
+		})
+	}
+
+	names1, err := sample("abc123")
+
+	if err != nil {
+		return err
+	}
+
+	names2, err := sample("def456")
+
+	if err != nil {
+		return err
+	}
+
+	if names1.StateMachine == names2.StateMachine {
+		return fmt.Errorf("naming template must refer to .Qualifier, so that the names differ between stacks")
+	}
+
+	nameTemplate = t
+
+	return nil
+}
+
+/*
+newResourceNames renders the physical names of the resources with the naming template.
+*/
+func newResourceNames(data *NameData) (*resourceNames, error) {
+	return renderResourceNames(nameTemplate, data)
+}
+
+/*
+renderResourceNames renders the physical names of the resources with the given naming template,
+and validates them. Placeholders of `!Sub` (e.g., "${StackID}" in the generic template) are left to CloudFormation.
+*/
+func renderResourceNames(t *template.Template, data *NameData) (*resourceNames, error) {
+	render := func(logicalID string) (string, error) {
+		d := *data
+
+		d.Component = resourceComponents[logicalID]
+		d.LogicalID = logicalID
+
+		var buf bytes.Buffer
+
+		if err := t.Execute(&buf, &d); err != nil {
+			return "", fmt.Errorf("failed to render name of '%s': %w", logicalID, err)
+		}
+
+		name := buf.String()
+
+		if literal := namePlaceholderPattern.ReplaceAllString(name, ""); (literal != "") && !resourceNamePattern.MatchString(literal) {
+			return "", fmt.Errorf("name '%s' of '%s' must consist of alphanumeric characters, '.', '_' and '-'", name, logicalID)
+		}
+
+		if !namePlaceholderPattern.MatchString(name) && (maxResourceNameLength < len(name)) {
+			return "", fmt.Errorf("name '%s' of '%s' exceeds %d characters", name, logicalID, maxResourceNameLength)
+		}
+
+		return name, nil
+	}
+
+	var names resourceNames
+
+	for _, item := range []struct {
+		logicalID string  // logical ID of the resource
+		name      *string // field to hold the name
+	}{
+		{LogicalIDStateMachineExecutionRole, &names.StateMachineExecutionRole},
+		{LogicalIDStateMachineLogGroup, &names.StateMachineLogGroup},
+		{LogicalIDStateMachine, &names.StateMachine},
+		{LogicalIDEventsRole, &names.EventsRole},
+		{LogicalIDAutoStartEventRule, &names.AutoStartEventRule},
+		{LogicalIDPeriodicStopSchedule, &names.PeriodicStopSchedule},
+	} {
+		name, err := render(item.logicalID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		*item.name = name
+	}
+
+	if names.StateMachineExecutionRole == names.EventsRole {
+		return nil, fmt.Errorf("names of '%s' and '%s' must differ (refer to .Component or .LogicalID)", LogicalIDStateMachineExecutionRole, LogicalIDEventsRole)
+	}
+
+	return &names, nil
+}
+
+/*
+ResourceNamePattern returns the pattern of the names of the resource with the given logical ID,
+in which the parts specific to a DB or a stack are replaced with a single wildcard (e.g., "ktnh-sfn-*").
+It is used to scope IAM policies to the resources created with the prefix.
+*/
+func ResourceNamePattern(stackNamePrefix string, logicalID string) string {
+	var buf bytes.Buffer
+
+	err := nameTemplate.Execute(&buf, &NameData{
+		Prefix:            stackNamePrefix,
+		Component:         resourceComponents[logicalID],
+		LogicalID:         logicalID,
+		DBIdentifier:      "*",
+		DBIdentifierShort: "*",
+		Qualifier:         "*",
+	})
+
+	// NOTE: the template has been rendered successfully in `SetNameTemplate`, so this is not expected to happen.
+	if err != nil {
+		return stackNamePrefix + "-*"
+	}
+
+	return wildcardsPattern.ReplaceAllString(buf.String(), "*")
+}
@@ -0,0 +1,142 @@
+package cfn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetNameTemplate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		nameTemplate string
+		wantErr      bool
+	}{
+		{
+			name:         "Default",
+			nameTemplate: DefaultNameTemplate,
+			wantErr:      false,
+		},
+		{
+			name:         "Logical ID",
+			nameTemplate: "team-{{ .Prefix }}-{{ .LogicalID }}-{{ .Qualifier }}",
+			wantErr:      false,
+		},
+		{
+			name:         "Syntax error",
+			nameTemplate: "{{ .Prefix ",
+			wantErr:      true,
+		},
+		{
+			name:         "Unknown field",
+			nameTemplate: "{{ .Unknown }}-{{ .Qualifier }}",
+			wantErr:      true,
+		},
+		{
+			name:         "Qualifier missing",
+			nameTemplate: "{{ .Prefix }}-{{ .LogicalID }}",
+			wantErr:      true,
+		},
+		{
+			name:         "Same role names",
+			nameTemplate: "{{ .Prefix }}-{{ .Qualifier }}",
+			wantErr:      true,
+		},
+		{
+			name:         "Invalid characters",
+			nameTemplate: "{{ .Prefix }}/{{ .LogicalID }}/{{ .Qualifier }}",
+			wantErr:      true,
+		},
+		{
+			name:         "Too long",
+			nameTemplate: strings.Repeat("x", 50) + "-{{ .LogicalID }}-{{ .Qualifier }}",
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				_ = SetNameTemplate(DefaultNameTemplate)
+			})
+
+			err := SetNameTemplate(tc.nameTemplate)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+		})
+	}
+}
+
+func Test_GenerateTemplateBody_Named(t *testing.T) {
+	t.Cleanup(func() {
+		_ = SetNameTemplate(DefaultNameTemplate)
+	})
+
+	err := SetNameTemplate("{{ .Prefix }}-{{ .LogicalID }}-{{ .DBIdentifierShort }}-{{ .Qualifier }}")
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	templateBody, err := GenerateTemplateBody("team", "rds-db-identifier", "rds-db-ide", "rds", "ghijklm")
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	for _, expected := range []string{
+		"RoleName: 'team-StateMachineExecutionRole-rds-db-ide-ghijklm'",
+		"LogGroupName: 'team-StateMachineLogGroup-rds-db-ide-ghijklm'",
+		"StateMachineName: 'team-StateMachine-rds-db-ide-ghijklm'",
+		"RoleName: 'team-EventsRole-rds-db-ide-ghijklm'",
+		"Name: 'team-RDSAutoStartEventRule-rds-db-ide-ghijklm'",
+		"Name: 'team-PeriodicStopSchedule-rds-db-ide-ghijklm'",
+	} {
+		assert.Contains(t, templateBody, expected, "Resource names should be rendered with the naming template")
+	}
+
+	assert.NotContains(t, templateBody, "'ktnh-", "Resource names should not use the default prefix")
+}
+
+func Test_ResourceNamePattern(t *testing.T) {
+	testCases := []struct {
+		name         string
+		nameTemplate string
+		logicalID    string
+		expected     string
+	}{
+		{
+			name:         "Default for role",
+			nameTemplate: DefaultNameTemplate,
+			logicalID:    LogicalIDStateMachineExecutionRole,
+			expected:     "A-sfn-*",
+		},
+		{
+			name:         "Default for state machine",
+			nameTemplate: DefaultNameTemplate,
+			logicalID:    LogicalIDStateMachine,
+			expected:     "A-*",
+		},
+		{
+			name:         "Custom",
+			nameTemplate: "team-{{ .Prefix }}-{{ .DBIdentifier }}-{{ .LogicalID }}-{{ .Qualifier }}",
+			logicalID:    LogicalIDEventsRole,
+			expected:     "team-A-*",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				_ = SetNameTemplate(DefaultNameTemplate)
+			})
+
+			err := SetNameTemplate(tc.nameTemplate)
+
+			assert.NoError(t, err, "Unexpected error occurred")
+
+			assert.Equal(t, tc.expected, ResourceNamePattern("A", tc.logicalID), "Pattern does not match expected value")
+		})
+	}
+}
@@ -4,31 +4,154 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 )
 
+/*
+StackSummary holds the attributes of a CloudFormation stack returned by the ListStacks API.
+*/
+type StackSummary struct {
+	Name        string // stack name
+	Description string // description of the template
+}
+
 /*
 stackEvaluator is a function type that evaluates whether a stack should be included in the results
 when listing CloudFormation stacks.
 */
-type stackEvaluator func(stackName string) bool
+type stackEvaluator func(summary StackSummary) bool
 
 /*
-CreateStack creates a new CloudFormation stack without waiting for completion.
+Logical IDs of the resources defined in the generated template.
 */
-func (c *CloudFormation) CreateStack(stackName string, templateBody string) error {
-	slog.Debug("Starting CloudFormation stack creation", "stackName", stackName)
+const (
+	LogicalIDStateMachineExecutionRole = "StateMachineExecutionRole" // execution role of the state machine
+	LogicalIDStateMachine              = "StateMachine"              // Step Functions state machine
+	LogicalIDStateMachineLogGroup      = "StateMachineLogGroup"      // log group for the state machine
+	LogicalIDEventsRole                = "EventsRole"                // role used by the event rule and the schedule
+	LogicalIDAutoStartEventRule        = "RDSAutoStartEventRule"     // EventBridge rule for auto-start events
+	LogicalIDPeriodicStopSchedule      = "PeriodicStopSchedule"      // EventBridge Scheduler schedule for periodic stop
+)
 
-	ctx := context.Background()
+/*
+Parameters defined in the generated template and their values.
+*/
+const (
+	ParameterProtectionState    = "ProtectionState"    // state of the event rule and the schedule
+	ParameterScheduleExpression = "ScheduleExpression" // schedule of the periodic stop
+	ParameterLogRetentionInDays = "LogRetentionInDays" // retention period of the state machine logs in days
+	ParameterDBIdentifier       = "DBIdentifier"       // DB cluster/instance identifier (generic template only)
+	ParameterDBType             = "DBType"             // type of the DB (generic template only)
+
+	ProtectionStateEnabled  = "ENABLED"  // protection is active
+	ProtectionStateDisabled = "DISABLED" // protection is paused
+)
+
+/*
+Stack holds the attributes of a CloudFormation stack.
+*/
+type Stack struct {
+	Name         string            // stack name
+	Status       string            // stack status
+	CreationTime time.Time         // time when the stack was created
+	Parameters   map[string]string // parameter values keyed by parameter name
+	Options      StackOptions      // tags and notification targets of the stack
+}
+
+/*
+StackOptions holds the settings of a CloudFormation stack other than the template and its parameters.
+Tags with the reserved `aws:` prefix (e.g., those added by Service Catalog) are not included,
+since they cannot be changed by the user.
+*/
+type StackOptions struct {
+	Tags             map[string]string `json:"tags"`             // stack tags, which CloudFormation propagates to the stack resources
+	NotificationARNs []string          `json:"notificationArns"` // ARNs of the SNS topics notified of the stack events
+}
+
+/*
+reservedTagPrefix starts the keys of the tags reserved by AWS.
+*/
+const reservedTagPrefix = "aws:"
+
+/*
+Equal reports whether both options have the same tags and notification targets, regardless of their order.
+*/
+func (o StackOptions) Equal(other StackOptions) bool {
+	return maps.Equal(o.Tags, other.Tags) &&
+		slices.Equal(slices.Sorted(slices.Values(o.NotificationARNs)), slices.Sorted(slices.Values(other.NotificationARNs)))
+}
+
+/*
+toInput converts the options into the tags and notification targets of the CreateStack or UpdateStack API.
+*/
+func (o StackOptions) toInput() ([]types.Tag, []string) {
+	tags := make([]types.Tag, 0, len(o.Tags))
+
+	for _, key := range slices.Sorted(maps.Keys(o.Tags)) {
+		tags = append(tags, types.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(o.Tags[key]),
+		})
+	}
+
+	notificationARNs := slices.Clone(o.NotificationARNs)
 
-	_, err := c.factory.GetClient().CreateStack(ctx, &cloudformation.CreateStackInput{
+	if notificationARNs == nil {
+		notificationARNs = []string{}
+	}
+
+	return tags, notificationARNs
+}
+
+/*
+StackResource holds the attributes of a resource in a CloudFormation stack.
+*/
+type StackResource struct {
+	LogicalID  string // logical ID of the resource in the template
+	PhysicalID string // physical ID (name or ARN) of the resource
+	Type       string // resource type (e.g., "AWS::IAM::Role")
+	Status     string // resource status
+}
+
+/*
+CreateStack creates a new CloudFormation stack without waiting for completion.
+Parameters not given keep the defaults defined in the template.
+The stack is created without tags and notification targets if options is nil.
+*/
+func (c *CloudFormation) CreateStack(stackName string, templateBody string, parameters map[string]string, options *StackOptions) error {
+	slog.Debug("Starting CloudFormation stack creation",
+		"stackName", stackName,
+		"parameters", parameters,
+		"options", options,
+	)
+
+	input := &cloudformation.CreateStackInput{
 		StackName:    aws.String(stackName),
 		TemplateBody: aws.String(templateBody),
 		Capabilities: []types.Capability{types.CapabilityCapabilityNamedIam},
-	})
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(parameters)) {
+		input.Parameters = append(input.Parameters, types.Parameter{
+			ParameterKey:   aws.String(key),
+			ParameterValue: aws.String(parameters[key]),
+		})
+	}
+
+	if (options != nil) && !options.Equal(StackOptions{}) {
+		input.Tags, input.NotificationARNs = options.toInput()
+	}
+
+	ctx := context.Background()
+
+	_, err := c.factory.GetClient().CreateStack(ctx, input)
 
 	if err != nil {
 		return fmt.Errorf("failed to execute CreateStack API for stack '%s': %w", stackName, err)
@@ -72,7 +195,7 @@ func (c *CloudFormation) ListStacks(evaluator stackEvaluator) ([]string, error)
 	if evaluator == nil {
 		slog.Debug("No evaluator provided, returning all stacks")
 
-		evaluator = func(stackName string) bool {
+		evaluator = func(summary StackSummary) bool {
 			return true
 		}
 	}
@@ -119,14 +242,17 @@ func (c *CloudFormation) ListStacks(evaluator stackEvaluator) ([]string, error)
 		}
 
 		for _, stack := range output.StackSummaries {
-			stackName := aws.ToString(stack.StackName)
+			summary := StackSummary{
+				Name:        aws.ToString(stack.StackName),
+				Description: aws.ToString(stack.TemplateDescription),
+			}
 
-			slog.Debug("Evaluating stack", "stackName", stackName)
+			slog.Debug("Evaluating stack", "stackName", summary.Name)
 
-			result := evaluator(stackName)
+			result := evaluator(summary)
 
 			if result {
-				matchingStacks = append(matchingStacks, stackName)
+				matchingStacks = append(matchingStacks, summary.Name)
 			}
 
 			slog.Debug("Stack evaluation result", "matches", result)
@@ -137,3 +263,216 @@ func (c *CloudFormation) ListStacks(evaluator stackEvaluator) ([]string, error)
 
 	return matchingStacks, nil
 }
+
+/*
+IsKTNHCandidate returns whether the stack may have been created from a template generated by ktnh,
+judging from the description of the template. The `Metadata.KTNH` section has to be verified to be sure.
+*/
+func (s StackSummary) IsKTNHCandidate() bool {
+	return strings.HasPrefix(s.Description, descriptionPrefix)
+}
+
+/*
+DescribeStack returns the attributes of a CloudFormation stack.
+*/
+func (c *CloudFormation) DescribeStack(stackName string) (*Stack, error) {
+	slog.Debug("Describing CloudFormation stack", "stackName", stackName)
+
+	ctx := context.Background()
+
+	output, err := c.factory.GetClient().DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+		StackName: aws.String(stackName),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute DescribeStacks API for stack '%s': %w", stackName, err)
+	}
+
+	if len(output.Stacks) == 0 {
+		return nil, fmt.Errorf("stack '%s' not found", stackName)
+	}
+
+	slog.Debug("CloudFormation stack described successfully")
+
+	return toStack(output.Stacks[0]), nil
+}
+
+/*
+DescribeStacks returns the attributes of all CloudFormation stacks keyed by stack name.
+It is used to avoid describing stacks one by one when many of them are involved.
+*/
+func (c *CloudFormation) DescribeStacks() (map[string]*Stack, error) {
+	slog.Debug("Describing all CloudFormation stacks")
+
+	paginator, err := c.factory.NewDescribeStacksPaginator(&cloudformation.DescribeStacksInput{})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DescribeStacks paginator: %w", err)
+	}
+
+	stacks := map[string]*Stack{}
+
+	ctx := context.Background()
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute DescribeStacks API: %w", err)
+		}
+
+		for _, stack := range output.Stacks {
+			stacks[aws.ToString(stack.StackName)] = toStack(stack)
+		}
+	}
+
+	slog.Debug("Described all CloudFormation stacks", "count", len(stacks))
+
+	return stacks, nil
+}
+
+/*
+toStack converts a stack returned by the DescribeStacks API into a Stack.
+*/
+func toStack(stack types.Stack) *Stack {
+	parameters := make(map[string]string, len(stack.Parameters))
+
+	for _, parameter := range stack.Parameters {
+		parameters[aws.ToString(parameter.ParameterKey)] = aws.ToString(parameter.ParameterValue)
+	}
+
+	var tags map[string]string
+
+	for _, tag := range stack.Tags {
+		key := aws.ToString(tag.Key)
+
+		if strings.HasPrefix(key, reservedTagPrefix) {
+			continue
+		}
+
+		if tags == nil {
+			tags = map[string]string{}
+		}
+
+		tags[key] = aws.ToString(tag.Value)
+	}
+
+	return &Stack{
+		Name:         aws.ToString(stack.StackName),
+		Status:       string(stack.StackStatus),
+		CreationTime: aws.ToTime(stack.CreationTime),
+		Parameters:   parameters,
+		Options: StackOptions{
+			Tags:             tags,
+			NotificationARNs: stack.NotificationARNs,
+		},
+	}
+}
+
+/*
+UpdateStackParameters updates parameters of a CloudFormation stack without waiting for completion.
+The template is kept as is, and parameters not given in overrides keep their previous values.
+If options is not nil, the tags and notification targets of the stack are replaced with them;
+otherwise, they are kept as is.
+Since the change goes through CloudFormation, it is not detected as drift.
+*/
+func (c *CloudFormation) UpdateStackParameters(stack *Stack, overrides map[string]string, options *StackOptions) error {
+	slog.Debug("Starting CloudFormation stack update",
+		"stackName", stack.Name,
+		"overrides", overrides,
+		"options", options,
+	)
+
+	for key := range overrides {
+		if _, ok := stack.Parameters[key]; !ok {
+			return fmt.Errorf("parameter '%s' is not defined in stack '%s'", key, stack.Name)
+		}
+	}
+
+	keys := slices.Sorted(maps.Keys(stack.Parameters))
+
+	parameters := make([]types.Parameter, len(keys))
+
+	for i, key := range keys {
+		if value, ok := overrides[key]; ok {
+			parameters[i] = types.Parameter{
+				ParameterKey:   aws.String(key),
+				ParameterValue: aws.String(value),
+			}
+		} else {
+			parameters[i] = types.Parameter{
+				ParameterKey:     aws.String(key),
+				UsePreviousValue: aws.Bool(true),
+			}
+		}
+	}
+
+	input := &cloudformation.UpdateStackInput{
+		StackName:           aws.String(stack.Name),
+		UsePreviousTemplate: aws.Bool(true),
+		Parameters:          parameters,
+		Capabilities:        []types.Capability{types.CapabilityCapabilityNamedIam},
+	}
+
+	// NOTE: empty lists are sent as is, since CloudFormation removes all tags or notification targets for them.
+	if options != nil {
+		input.Tags, input.NotificationARNs = options.toInput()
+	}
+
+	ctx := context.Background()
+
+	_, err := c.factory.GetClient().UpdateStack(ctx, input)
+
+	if err != nil {
+		return fmt.Errorf("failed to execute UpdateStack API for stack '%s': %w", stack.Name, err)
+	}
+
+	slog.Debug("CloudFormation stack update initiated successfully")
+
+	return nil
+}
+
+/*
+ListStackResources returns the resources of a CloudFormation stack.
+*/
+func (c *CloudFormation) ListStackResources(stackName string) ([]StackResource, error) {
+	slog.Debug("Listing CloudFormation stack resources", "stackName", stackName)
+
+	ctx := context.Background()
+
+	output, err := c.factory.GetClient().DescribeStackResources(ctx, &cloudformation.DescribeStackResourcesInput{
+		StackName: aws.String(stackName),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute DescribeStackResources API for stack '%s': %w", stackName, err)
+	}
+
+	resources := make([]StackResource, len(output.StackResources))
+
+	for i, resource := range output.StackResources {
+		resources[i] = StackResource{
+			LogicalID:  aws.ToString(resource.LogicalResourceId),
+			PhysicalID: aws.ToString(resource.PhysicalResourceId),
+			Type:       aws.ToString(resource.ResourceType),
+			Status:     string(resource.ResourceStatus),
+		}
+	}
+
+	slog.Debug("CloudFormation stack resources listed successfully", "count", len(resources))
+
+	return resources, nil
+}
+
+/*
+FindPhysicalID returns the physical ID of the resource with the given logical ID.
+*/
+func FindPhysicalID(resources []StackResource, logicalID string) (string, bool) {
+	for _, resource := range resources {
+		if resource.LogicalID == logicalID {
+			return resource.PhysicalID, resource.PhysicalID != ""
+		}
+	}
+
+	return "", false
+}
@@ -2,6 +2,7 @@ package cfn
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
@@ -17,6 +18,8 @@ func Test_CreateStack(t *testing.T) {
 		name         string
 		stackName    string
 		templateBody string
+		parameters   map[string]string
+		options      *StackOptions
 		mockSetup    func(*appmock.MockCloudFormationFactory, *appmock.MockCloudFormationClient)
 		wantErr      bool
 	}{
@@ -41,6 +44,66 @@ func Test_CreateStack(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:         "With parameters",
+			stackName:    "parameters-stack",
+			templateBody: "{a: 1}",
+			parameters: map[string]string{
+				"ProtectionState": "DISABLED",
+				"Other":           "value",
+			},
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &cloudformation.CreateStackInput{
+					StackName:    aws.String("parameters-stack"),
+					TemplateBody: aws.String("{a: 1}"),
+					Capabilities: []types.Capability{types.CapabilityCapabilityNamedIam},
+					Parameters: []types.Parameter{
+						{ParameterKey: aws.String("Other"), ParameterValue: aws.String("value")},
+						{ParameterKey: aws.String("ProtectionState"), ParameterValue: aws.String("DISABLED")},
+					},
+				}
+
+				result := &cloudformation.CreateStackOutput{}
+
+				c.On("CreateStack", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:         "With options",
+			stackName:    "options-stack",
+			templateBody: "{a: 1}",
+			options: &StackOptions{
+				Tags: map[string]string{
+					"team": "db",
+					"env":  "dev",
+				},
+				NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:topic"},
+			},
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &cloudformation.CreateStackInput{
+					StackName:    aws.String("options-stack"),
+					TemplateBody: aws.String("{a: 1}"),
+					Capabilities: []types.Capability{types.CapabilityCapabilityNamedIam},
+					Tags: []types.Tag{
+						{Key: aws.String("env"), Value: aws.String("dev")},
+						{Key: aws.String("team"), Value: aws.String("db")},
+					},
+					NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:topic"},
+				}
+
+				c.On("CreateStack", mock.Anything, params, mock.Anything).
+					Return(&cloudformation.CreateStackOutput{}, nil)
+			},
+			wantErr: false,
+		},
 		{
 			name:         "API error",
 			stackName:    "api-error-stack",
@@ -73,7 +136,7 @@ func Test_CreateStack(t *testing.T) {
 
 			c := NewCloudFormation(mockFactory)
 
-			err := c.CreateStack(tc.stackName, tc.templateBody)
+			err := c.CreateStack(tc.stackName, tc.templateBody, tc.parameters, tc.options)
 
 			if tc.wantErr {
 				assert.Error(t, err, "Expected an error to be returned")
@@ -165,8 +228,8 @@ func Test_ListStacks(t *testing.T) {
 	}{
 		{
 			name: "With evaluator",
-			evaluator: func(stackName string) bool {
-				return stackName == "stack1" || stackName == "stack3"
+			evaluator: func(summary StackSummary) bool {
+				return summary.Name == "stack1" || summary.Name == "stack3"
 			},
 			mockSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockListStacksPaginator) {
 				f.On("NewListStacksPaginator", mock.Anything).
@@ -200,6 +263,48 @@ func Test_ListStacks(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Evaluator with description",
+			evaluator: func(summary StackSummary) bool {
+				return summary.IsKTNHCandidate()
+			},
+			mockSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockListStacksPaginator) {
+				f.On("NewListStacksPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				result := &cloudformation.ListStacksOutput{
+					StackSummaries: []types.StackSummary{
+						{
+							StackName:           aws.String("stack1"),
+							TemplateDescription: aws.String("ktnh - Keep an Aurora cluster or an RDS instance stopped permanently"),
+						},
+						{
+							StackName:           aws.String("stack2"),
+							TemplateDescription: aws.String("Other stack"),
+						},
+						{
+							StackName: aws.String("stack3"),
+						},
+					},
+				}
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(result, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			expectedStacks: []string{
+				"stack1",
+			},
+			wantErr: false,
+		},
 		{
 			name:      "Multiple pages",
 			evaluator: nil,
@@ -281,7 +386,7 @@ func Test_ListStacks(t *testing.T) {
 		},
 		{
 			name: "Evaluator filters all stacks",
-			evaluator: func(stackName string) bool {
+			evaluator: func(summary StackSummary) bool {
 				return false
 			},
 			mockSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockListStacksPaginator) {
@@ -370,3 +475,489 @@ func Test_ListStacks(t *testing.T) {
 		})
 	}
 }
+
+func Test_DescribeStack(t *testing.T) {
+	creationTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		stackName string
+		mockSetup func(*appmock.MockCloudFormationFactory, *appmock.MockCloudFormationClient)
+		expected  *Stack
+		wantErr   bool
+	}{
+		{
+			name:      "Success",
+			stackName: "stack-1",
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &cloudformation.DescribeStacksInput{
+					StackName: aws.String("stack-1"),
+				}
+
+				result := &cloudformation.DescribeStacksOutput{
+					Stacks: []types.Stack{
+						{
+							StackName:    aws.String("stack-1"),
+							StackStatus:  types.StackStatusCreateComplete,
+							CreationTime: aws.Time(creationTime),
+							Parameters: []types.Parameter{
+								{
+									ParameterKey:   aws.String("ProtectionState"),
+									ParameterValue: aws.String("ENABLED"),
+								},
+							},
+							Tags: []types.Tag{
+								{Key: aws.String("env"), Value: aws.String("dev")},
+								{Key: aws.String("aws:servicecatalog:provisioningPrincipalArn"), Value: aws.String("arn:aws:iam::123456789012:user/user")},
+							},
+							NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:topic"},
+						},
+					},
+				}
+
+				c.On("DescribeStacks", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			expected: &Stack{
+				Name:         "stack-1",
+				Status:       "CREATE_COMPLETE",
+				CreationTime: creationTime,
+				Parameters: map[string]string{
+					"ProtectionState": "ENABLED",
+				},
+				Options: StackOptions{
+					Tags: map[string]string{
+						"env": "dev",
+					},
+					NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:topic"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Not found",
+			stackName: "stack-2",
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.DescribeStacksOutput{}, nil)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name:      "API error",
+			stackName: "stack-3",
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.DescribeStacksOutput{}, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudFormationFactory)
+			mockClient := new(appmock.MockCloudFormationClient)
+
+			tc.mockSetup(mockFactory, mockClient)
+
+			c := NewCloudFormation(mockFactory)
+
+			got, err := c.DescribeStack(tc.stackName)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Stack does not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_DescribeStacks(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mockSetup func(*appmock.MockCloudFormationFactory, *appmock.MockDescribeStacksPaginator)
+		expected  map[string]*Stack
+		wantErr   bool
+	}{
+		{
+			name: "Success",
+			mockSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockDescribeStacksPaginator) {
+				f.On("NewDescribeStacksPaginator", &cloudformation.DescribeStacksInput{}).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&cloudformation.DescribeStacksOutput{
+						Stacks: []types.Stack{
+							{
+								StackName:   aws.String("stack-1"),
+								StackStatus: types.StackStatusUpdateComplete,
+								Parameters: []types.Parameter{
+									{
+										ParameterKey:   aws.String("ProtectionState"),
+										ParameterValue: aws.String("DISABLED"),
+									},
+								},
+							},
+							{
+								StackName:   aws.String("stack-2"),
+								StackStatus: types.StackStatusCreateComplete,
+							},
+						},
+					}, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			expected: map[string]*Stack{
+				"stack-1": {
+					Name:   "stack-1",
+					Status: "UPDATE_COMPLETE",
+					Parameters: map[string]string{
+						"ProtectionState": "DISABLED",
+					},
+				},
+				"stack-2": {
+					Name:       "stack-2",
+					Status:     "CREATE_COMPLETE",
+					Parameters: map[string]string{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Paginator error",
+			mockSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockDescribeStacksPaginator) {
+				f.On("NewDescribeStacksPaginator", mock.Anything).
+					Return(nil, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "API error",
+			mockSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockDescribeStacksPaginator) {
+				f.On("NewDescribeStacksPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&cloudformation.DescribeStacksOutput{}, assert.AnError).
+					Once()
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudFormationFactory)
+			mockPaginator := new(appmock.MockDescribeStacksPaginator)
+
+			tc.mockSetup(mockFactory, mockPaginator)
+
+			c := NewCloudFormation(mockFactory)
+
+			got, err := c.DescribeStacks()
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Stacks do not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockPaginator.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_UpdateStackParameters(t *testing.T) {
+	stack := &Stack{
+		Name: "stack-1",
+		Parameters: map[string]string{
+			"ProtectionState": "ENABLED",
+			"Other":           "value",
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		overrides map[string]string
+		options   *StackOptions
+		mockSetup func(*appmock.MockCloudFormationFactory, *appmock.MockCloudFormationClient)
+		wantErr   bool
+	}{
+		{
+			name: "Success",
+			overrides: map[string]string{
+				"ProtectionState": "DISABLED",
+			},
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &cloudformation.UpdateStackInput{
+					StackName:           aws.String("stack-1"),
+					UsePreviousTemplate: aws.Bool(true),
+					Parameters: []types.Parameter{
+						{
+							ParameterKey:     aws.String("Other"),
+							UsePreviousValue: aws.Bool(true),
+						},
+						{
+							ParameterKey:   aws.String("ProtectionState"),
+							ParameterValue: aws.String("DISABLED"),
+						},
+					},
+					Capabilities: []types.Capability{types.CapabilityCapabilityNamedIam},
+				}
+
+				c.On("UpdateStack", mock.Anything, params, mock.Anything).
+					Return(&cloudformation.UpdateStackOutput{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Remove options",
+			overrides: map[string]string{},
+			options:   &StackOptions{},
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &cloudformation.UpdateStackInput{
+					StackName:           aws.String("stack-1"),
+					UsePreviousTemplate: aws.Bool(true),
+					Parameters: []types.Parameter{
+						{
+							ParameterKey:     aws.String("Other"),
+							UsePreviousValue: aws.Bool(true),
+						},
+						{
+							ParameterKey:     aws.String("ProtectionState"),
+							UsePreviousValue: aws.Bool(true),
+						},
+					},
+					Capabilities:     []types.Capability{types.CapabilityCapabilityNamedIam},
+					Tags:             []types.Tag{},
+					NotificationARNs: []string{},
+				}
+
+				c.On("UpdateStack", mock.Anything, params, mock.Anything).
+					Return(&cloudformation.UpdateStackOutput{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Undefined parameter",
+			overrides: map[string]string{
+				"Unknown": "value",
+			},
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {},
+			wantErr:   true,
+		},
+		{
+			name: "API error",
+			overrides: map[string]string{
+				"ProtectionState": "DISABLED",
+			},
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("UpdateStack", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.UpdateStackOutput{}, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudFormationFactory)
+			mockClient := new(appmock.MockCloudFormationClient)
+
+			tc.mockSetup(mockFactory, mockClient)
+
+			c := NewCloudFormation(mockFactory)
+
+			err := c.UpdateStackParameters(stack, tc.overrides, tc.options)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_ListStackResources(t *testing.T) {
+	testCases := []struct {
+		name      string
+		stackName string
+		mockSetup func(*appmock.MockCloudFormationFactory, *appmock.MockCloudFormationClient)
+		expected  []StackResource
+		wantErr   bool
+	}{
+		{
+			name:      "Success",
+			stackName: "stack-1",
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &cloudformation.DescribeStackResourcesInput{
+					StackName: aws.String("stack-1"),
+				}
+
+				result := &cloudformation.DescribeStackResourcesOutput{
+					StackResources: []types.StackResource{
+						{
+							LogicalResourceId:  aws.String("StateMachine"),
+							PhysicalResourceId: aws.String("arn:aws:states:ap-northeast-1:123456789012:stateMachine:sm"),
+							ResourceType:       aws.String("AWS::StepFunctions::StateMachine"),
+							ResourceStatus:     types.ResourceStatusCreateComplete,
+						},
+					},
+				}
+
+				c.On("DescribeStackResources", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			expected: []StackResource{
+				{
+					LogicalID:  "StateMachine",
+					PhysicalID: "arn:aws:states:ap-northeast-1:123456789012:stateMachine:sm",
+					Type:       "AWS::StepFunctions::StateMachine",
+					Status:     "CREATE_COMPLETE",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "API error",
+			stackName: "stack-2",
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeStackResources", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.DescribeStackResourcesOutput{}, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudFormationFactory)
+			mockClient := new(appmock.MockCloudFormationClient)
+
+			tc.mockSetup(mockFactory, mockClient)
+
+			c := NewCloudFormation(mockFactory)
+
+			got, err := c.ListStackResources(tc.stackName)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Stack resources do not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_FindPhysicalID(t *testing.T) {
+	resources := []StackResource{
+		{LogicalID: "A", PhysicalID: "a-physical"},
+		{LogicalID: "B", PhysicalID: ""},
+	}
+
+	got, ok := FindPhysicalID(resources, "A")
+
+	assert.True(t, ok, "Resource 'A' should be found")
+	assert.Equal(t, "a-physical", got, "Physical ID does not match")
+
+	_, ok = FindPhysicalID(resources, "B")
+
+	assert.False(t, ok, "Resource without physical ID should not be found")
+
+	_, ok = FindPhysicalID(resources, "C")
+
+	assert.False(t, ok, "Unknown resource should not be found")
+}
+
+func Test_StackOptions_Equal(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        StackOptions
+		b        StackOptions
+		expected bool
+	}{
+		{
+			name:     "Empty",
+			a:        StackOptions{},
+			b:        StackOptions{Tags: map[string]string{}, NotificationARNs: []string{}},
+			expected: true,
+		},
+		{
+			name:     "Notification targets in different order",
+			a:        StackOptions{NotificationARNs: []string{"arn-1", "arn-2"}},
+			b:        StackOptions{NotificationARNs: []string{"arn-2", "arn-1"}},
+			expected: true,
+		},
+		{
+			name:     "Different tags",
+			a:        StackOptions{Tags: map[string]string{"env": "dev"}},
+			b:        StackOptions{Tags: map[string]string{"env": "prd"}},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.a.Equal(tc.b), "Equality does not match expected value")
+		})
+	}
+}
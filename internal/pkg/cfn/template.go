@@ -6,56 +6,180 @@ import (
 	"log/slog"
 	"strings"
 	"text/template"
+
+	"gopkg.in/yaml.v3"
 )
 
 /*
 templateData represents the data used to populate CloudFormation templates.
 */
 type templateData struct {
-	GeneratorName     string // generator name
-	GeneratorVersion  string // version of the generator
-	DBIdentifier      string // DB cluster/instance identifier
-	DBIdentifierShort string // shortened DB identifier for display
-	DBType            string // type of the DB (see `internal/pkg/rds`)
-	Qualifier         string // unique qualifier specific to the stack
+	GeneratorName      string         // generator name
+	GeneratorVersion   string         // version of the generator
+	Prefix             string         // prefix of the stack name and the resource names
+	DBIdentifier       string         // DB cluster/instance identifier
+	DBIdentifierShort  string         // shortened DB identifier for display
+	DBType             string         // type of the DB (see `internal/pkg/rds`)
+	Qualifier          string         // unique qualifier specific to the stack
+	Names              *resourceNames // physical names of the resources (see `SetNameTemplate`)
+	ScheduleExpression string         // schedule of the periodic stop (see `SetFreezePolicy`)
+	LogRetentionInDays int            // retention period of the state machine logs in days (see `SetFreezePolicy`)
 }
 
 /*
 GenerateTemplateBody generates a CloudFormation template.
 */
-func GenerateTemplateBody(dbIdentifier string, dbIdentifierShort string, dbType string, qualifier string) (string, error) {
+func GenerateTemplateBody(stackNamePrefix string, dbIdentifier string, dbIdentifierShort string, dbType string, qualifier string) (string, error) {
 	slog.Debug("Generating CloudFormation template",
+		"stackNamePrefix", stackNamePrefix,
 		"dbIdentifier", dbIdentifier,
 		"dbIdentifierShort", dbIdentifierShort,
 		"dbType", dbType,
 		"qualifier", qualifier,
 	)
 
-	t := template.New("cfn")
+	data, err := newTemplateData(stackNamePrefix, dbIdentifier, dbIdentifierShort, dbType, qualifier)
 
-	t, err := t.Funcs(customFuncMap(t)).Parse(templateStr)
+	if err != nil {
+		return "", err
+	}
+
+	templateBody, err := renderCloudFormation("cloudformation", data, dbType)
 
 	if err != nil {
-		return "", fmt.Errorf("failed to parse Golang template: %w", err)
+		return "", err
+	}
+
+	slog.Debug("CloudFormation template generated successfully")
+
+	return templateBody, nil
+}
+
+/*
+GenerateGenericTemplateBody generates a CloudFormation template that is not bound to a specific DB.
+The DB identifier, the DB type, the schedule and the log retention are given by the stack parameters,
+and the resource names are derived from the stack ID, so that the template can be deployed as is
+(e.g., as a Service Catalog product).
+The `Metadata.KTNH` section refers to the stack parameters by placeholders (see `GetKTNHMetadata`).
+*/
+func GenerateGenericTemplateBody(stackNamePrefix string) (string, error) {
+	slog.Debug("Generating generic CloudFormation template", "stackNamePrefix", stackNamePrefix)
+
+	// NOTE: the state machine definitions refer to the DB identifier via `DefinitionSubstitutions`,
+	//       and the names refer to the stack ID via `!Sub` in place of the qualifier.
+	data, err := newTemplateData(stackNamePrefix, metadataPlaceholder(ParameterDBIdentifier), "", "", "${StackID}")
+
+	if err != nil {
+		return "", err
+	}
+
+	templateBody, err := renderCloudFormation("cloudformationGeneric", data, metadataPlaceholder(ParameterDBType))
+
+	if err != nil {
+		return "", err
 	}
 
-	data := templateData{
-		GeneratorName:     generatorName,
-		GeneratorVersion:  generatorVersion,
+	slog.Debug("Generic CloudFormation template generated successfully")
+
+	return templateBody, nil
+}
+
+/*
+GenerateTerraform generates a Terraform configuration with the same resources and names
+as the CloudFormation template. The protection state is given by the `protection_state` variable
+instead of the stack parameter.
+Template patches are not applied, since they are written for CloudFormation templates.
+*/
+func GenerateTerraform(stackNamePrefix string, dbIdentifier string, dbIdentifierShort string, dbType string, qualifier string) (string, error) {
+	slog.Debug("Generating Terraform configuration",
+		"stackNamePrefix", stackNamePrefix,
+		"dbIdentifier", dbIdentifier,
+		"dbIdentifierShort", dbIdentifierShort,
+		"dbType", dbType,
+		"qualifier", qualifier,
+	)
+
+	if len(templatePatchFiles) != 0 {
+		return "", fmt.Errorf("template patches cannot be applied to Terraform configuration")
+	}
+
+	data, err := newTemplateData(stackNamePrefix, dbIdentifier, dbIdentifierShort, dbType, qualifier)
+
+	if err != nil {
+		return "", err
+	}
+
+	configuration, err := renderTemplate("terraform", data)
+
+	if err != nil {
+		return "", err
+	}
+
+	slog.Debug("Terraform configuration generated successfully")
+
+	return configuration, nil
+}
+
+/*
+newTemplateData creates the data used to populate the templates, including the physical names of the resources.
+*/
+func newTemplateData(stackNamePrefix string, dbIdentifier string, dbIdentifierShort string, dbType string, qualifier string) (templateData, error) {
+	names, err := newResourceNames(&NameData{
+		Prefix:            stackNamePrefix,
 		DBIdentifier:      dbIdentifier,
 		DBIdentifierShort: dbIdentifierShort,
-		DBType:            dbType,
 		Qualifier:         qualifier,
+	})
+
+	if err != nil {
+		return templateData{}, fmt.Errorf("failed to generate resource names: %w", err)
+	}
+
+	return templateData{
+		GeneratorName:      generatorName,
+		GeneratorVersion:   generatorVersion,
+		Prefix:             stackNamePrefix,
+		DBIdentifier:       dbIdentifier,
+		DBIdentifierShort:  dbIdentifierShort,
+		DBType:             dbType,
+		Qualifier:          qualifier,
+		Names:              names,
+		ScheduleExpression: scheduleExpression,
+		LogRetentionInDays: logRetentionInDays,
+	}, nil
+}
+
+/*
+renderTemplate executes the named template defined in templateStr with the data.
+Templates given in the template directory replace the embedded ones of the same name.
+*/
+func renderTemplate(name string, data templateData) (string, error) {
+	t := template.New("cfn")
+
+	t, err := t.Funcs(customFuncMap(t)).Parse(templateStr)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Golang template: %w", err)
+	}
+
+	overrides, err := loadTemplateOverrides()
+
+	if err != nil {
+		return "", err
+	}
+
+	for overrideName, content := range overrides {
+		if _, err := t.New(overrideName).Parse(content); err != nil {
+			return "", fmt.Errorf("failed to parse Golang template '%s' in '%s': %w", overrideName, templateDir, err)
+		}
 	}
 
 	var buf bytes.Buffer
 
-	if err = t.Execute(&buf, data); err != nil {
+	if err = t.ExecuteTemplate(&buf, name, data); err != nil {
 		return "", fmt.Errorf("failed to execute Golang template: %w", err)
 	}
 
-	slog.Debug("CloudFormation template generated successfully")
-
 	return buf.String(), nil
 }
 
@@ -83,5 +207,38 @@ func customFuncMap(t *template.Template) template.FuncMap {
 		return indent + strings.Replace(str, "\n", "\n"+indent, -1)
 	}
 
+	// escapeHCL: escapes the template sequences of HCL ("${" and "%{") to embed a string in a heredoc.
+	fm["escapeHCL"] = func(str string) string {
+		return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(str)
+	}
+
 	return fm
 }
+
+/*
+StateMachineDefinition returns the definition of the state machine in the CloudFormation template.
+The definition must be given as a string, as in the templates generated for a DB.
+*/
+func StateMachineDefinition(templateBody string) (string, error) {
+	var template struct {
+		Resources map[string]*templateResource `yaml:"Resources"`
+	}
+
+	if err := yaml.Unmarshal([]byte(templateBody), &template); err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	resource, ok := template.Resources[LogicalIDStateMachine]
+
+	if !ok || (resource == nil) {
+		return "", fmt.Errorf("resource '%s' not found in template", LogicalIDStateMachine)
+	}
+
+	definition, ok := resource.Properties["DefinitionString"]
+
+	if !ok || (definition.Kind != yaml.ScalarNode) || isIntrinsicFunctionTag(definition.Tag) {
+		return "", fmt.Errorf("DefinitionString of '%s' is not a string", LogicalIDStateMachine)
+	}
+
+	return definition.Value, nil
+}
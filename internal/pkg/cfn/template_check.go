@@ -0,0 +1,252 @@
+package cfn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+maxRoleNameLength is the maximum length of IAM role names.
+*/
+const maxRoleNameLength = 64
+
+const (
+	CheckYAML       = "yaml"       // the template is valid YAML
+	CheckMetadata   = "metadata"   // the `Metadata.KTNH` section is valid for the DB
+	CheckParameters = "parameters" // the parameters referred to by ktnh are defined
+	CheckResources  = "resources"  // the resources referred to by ktnh are defined
+	CheckDefinition = "definition" // the state machine definition is valid
+	CheckIAM        = "iam"        // the IAM roles are valid
+	CheckAPI        = "api"        // the template is accepted by the ValidateTemplate API
+)
+
+/*
+stateTypes lists the state types of Amazon States Language.
+*/
+var stateTypes = []string{"Choice", "Fail", "Map", "Parallel", "Pass", "Succeed", "Task", "Wait"}
+
+/*
+TemplateProblem holds a problem found in a CloudFormation template.
+*/
+type TemplateProblem struct {
+	Check   string `json:"check"`   // name of the check that found the problem (e.g., "definition")
+	Message string `json:"message"` // description of the problem
+}
+
+/*
+TemplateError is returned when a rendered template has problems, and holds all of them
+along with the template, so that it can still be validated by other means (e.g., the ValidateTemplate API).
+*/
+type TemplateError struct {
+	Problems     []TemplateProblem // problems found in the template
+	TemplateBody string            // rendered template in which the problems are found
+}
+
+/*
+Error returns all problems in a single message.
+*/
+func (e *TemplateError) Error() string {
+	messages := make([]string, len(e.Problems))
+
+	for i, problem := range e.Problems {
+		messages[i] = fmt.Sprintf("[%s] %s", problem.Check, problem.Message)
+	}
+
+	return "invalid template: " + strings.Join(messages, "; ")
+}
+
+/*
+templateResource defines the structure of a resource in CloudFormation templates.
+*/
+type templateResource struct {
+	Type       string               `yaml:"Type"`       // resource type (e.g., "AWS::IAM::Role")
+	Properties map[string]yaml.Node `yaml:"Properties"` // resource properties
+}
+
+/*
+CheckTemplate checks the template locally without accessing AWS, and returns all problems found.
+It covers what the ValidateTemplate API does not check: the state machine definition, the lengths of IAM role names,
+and whether ktnh can manage the stack (the `Metadata.KTNH` section for the DB, the description by which the stack is found,
+the `ProtectionState` parameter and the resources referred to by ktnh).
+*/
+func CheckTemplate(templateBody string, option *MetadataVerifyOption) []TemplateProblem {
+	var template struct {
+		cloudFormationTemplate `yaml:",inline"`
+
+		Description string                       `yaml:"Description"`
+		Parameters  map[string]yaml.Node         `yaml:"Parameters"`
+		Resources   map[string]*templateResource `yaml:"Resources"`
+	}
+
+	if err := yaml.Unmarshal([]byte(templateBody), &template); err != nil {
+		return []TemplateProblem{{Check: CheckYAML, Message: err.Error()}}
+	}
+
+	var problems []TemplateProblem
+
+	add := func(check string, format string, args ...any) {
+		problems = append(problems, TemplateProblem{Check: check, Message: fmt.Sprintf(format, args...)})
+	}
+
+	verified, err := VerifyMetadata(&template.Metadata.KTNH, option)
+
+	if err != nil {
+		add(CheckMetadata, "%s", err)
+	} else if !verified {
+		add(CheckMetadata, "metadata does not match the DB '%s' (%s)", option.DBIdentifier, option.DBType)
+	}
+
+	// NOTE: stacks not named after the prefix are found by the description (see `StackSummary.IsKTNHCandidate`).
+	if !strings.HasPrefix(template.Description, descriptionPrefix) {
+		add(CheckMetadata, "description must start with '%s'", descriptionPrefix)
+	}
+
+	if _, ok := template.Parameters[ParameterProtectionState]; !ok {
+		add(CheckParameters, "parameter '%s' is missing", ParameterProtectionState)
+	}
+
+	for _, logicalID := range requiredLogicalIDs {
+		if _, ok := template.Resources[logicalID]; !ok {
+			add(CheckResources, "resource '%s' is missing", logicalID)
+		}
+	}
+
+	for _, logicalID := range slices.Sorted(maps.Keys(template.Resources)) {
+		resource := template.Resources[logicalID]
+
+		if resource == nil {
+			continue
+		}
+
+		switch resource.Type {
+		case "AWS::StepFunctions::StateMachine":
+			definition, ok := resource.Properties["DefinitionString"]
+
+			if !ok {
+				add(CheckDefinition, "%s: DefinitionString is missing", logicalID)
+
+				continue
+			}
+
+			for _, message := range checkDefinitionNode(&definition) {
+				add(CheckDefinition, "%s: %s", logicalID, message)
+			}
+		case "AWS::IAM::Role":
+			roleName, ok := resource.Properties["RoleName"]
+
+			// NOTE: names built by intrinsic functions are left to CloudFormation, since their lengths are not known.
+			if ok && (roleName.Kind == yaml.ScalarNode) && !isIntrinsicFunctionTag(roleName.Tag) && (maxRoleNameLength < len(roleName.Value)) {
+				add(CheckIAM, "%s: role name '%s' exceeds %d characters", logicalID, roleName.Value, maxRoleNameLength)
+			}
+		}
+	}
+
+	slog.Debug("Template checked", "problems", len(problems))
+
+	return problems
+}
+
+/*
+checkDefinitionNode checks the state machine definition given as a string,
+or as the branches of `!If` (as in the generic template), and returns the problems found.
+*/
+func checkDefinitionNode(node *yaml.Node) []string {
+	switch {
+	case (node.Kind == yaml.ScalarNode) && !isIntrinsicFunctionTag(node.Tag):
+		return checkDefinition(node.Value)
+	case (node.Tag == "!If") && (node.Kind == yaml.SequenceNode) && (len(node.Content) == 3):
+		return append(checkDefinitionNode(node.Content[1]), checkDefinitionNode(node.Content[2])...)
+	default:
+		return []string{"DefinitionString must be a string"}
+	}
+}
+
+/*
+checkDefinition checks that the definition is valid JSON with the structure of Amazon States Language:
+the start state and the states referred to by `Next` and `Default` exist, and every state has a known type.
+*/
+func checkDefinition(definition string) []string {
+	var asl struct {
+		StartAt string                     `json:"StartAt"`
+		States  map[string]json.RawMessage `json:"States"`
+	}
+
+	if err := json.Unmarshal([]byte(definition), &asl); err != nil {
+		return []string{fmt.Sprintf("definition is not valid JSON: %s", err)}
+	}
+
+	var messages []string
+
+	if len(asl.States) == 0 {
+		messages = append(messages, "definition has no States")
+	}
+
+	if _, ok := asl.States[asl.StartAt]; !ok {
+		messages = append(messages, fmt.Sprintf("StartAt '%s' is not defined in States", asl.StartAt))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(asl.States)) {
+		var state struct {
+			Type    string `json:"Type"`
+			Next    string `json:"Next"`
+			Default string `json:"Default"`
+			Choices []struct {
+				Next string `json:"Next"`
+			} `json:"Choices"`
+		}
+
+		if err := json.Unmarshal(asl.States[name], &state); err != nil {
+			messages = append(messages, fmt.Sprintf("state '%s' is invalid: %s", name, err))
+
+			continue
+		}
+
+		if !slices.Contains(stateTypes, state.Type) {
+			messages = append(messages, fmt.Sprintf("state '%s' has unknown Type '%s'", name, state.Type))
+		}
+
+		targets := []string{state.Next, state.Default}
+
+		for _, choice := range state.Choices {
+			targets = append(targets, choice.Next)
+		}
+
+		for _, target := range targets {
+			if _, ok := asl.States[target]; (target != "") && !ok {
+				messages = append(messages, fmt.Sprintf("state '%s' refers to undefined state '%s'", name, target))
+			}
+		}
+	}
+
+	return messages
+}
+
+/*
+ValidateTemplate validates the template with the ValidateTemplate API.
+*/
+func (c *CloudFormation) ValidateTemplate(templateBody string) error {
+	slog.Debug("Validating CloudFormation template")
+
+	ctx := context.Background()
+
+	_, err := c.factory.GetClient().ValidateTemplate(ctx, &cloudformation.ValidateTemplateInput{
+		TemplateBody: aws.String(templateBody),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute ValidateTemplate API: %w", err)
+	}
+
+	slog.Debug("CloudFormation template validated successfully")
+
+	return nil
+}
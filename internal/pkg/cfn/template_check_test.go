@@ -0,0 +1,176 @@
+package cfn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_CheckTemplate(t *testing.T) {
+	rdsOption := &MetadataVerifyOption{
+		DBIdentifier: "rds-db-identifier",
+		DBType:       "rds",
+	}
+
+	testCases := []struct {
+		name     string
+		filename string
+		replacer *strings.Replacer
+		option   *MetadataVerifyOption
+		expected []string
+	}{
+		{
+			name:     "Valid",
+			filename: "rds.yml",
+			replacer: strings.NewReplacer(),
+			option:   rdsOption,
+			expected: nil,
+		},
+		{
+			name:     "Valid generic template",
+			filename: "generic.yml",
+			replacer: strings.NewReplacer(),
+			option: &MetadataVerifyOption{
+				DBIdentifier: "${DBIdentifier}",
+				DBType:       "${DBType}",
+			},
+			expected: nil,
+		},
+		{
+			name:     "Invalid YAML",
+			filename: "rds.yml",
+			replacer: strings.NewReplacer("Resources:", "Resources: ["),
+			option:   rdsOption,
+			expected: []string{CheckYAML},
+		},
+		{
+			name:     "Metadata removed",
+			filename: "rds.yml",
+			replacer: strings.NewReplacer("  KTNH:", "  Other:"),
+			option:   rdsOption,
+			expected: []string{CheckMetadata},
+		},
+		{
+			name:     "DB identifier changed",
+			filename: "rds.yml",
+			replacer: strings.NewReplacer("DBIdentifier: 'rds-db-identifier'", "DBIdentifier: 'other'"),
+			option:   rdsOption,
+			expected: []string{CheckMetadata},
+		},
+		{
+			name:     "Description changed",
+			filename: "rds.yml",
+			replacer: strings.NewReplacer("Description: 'ktnh - ", "Description: 'Keep "),
+			option:   rdsOption,
+			expected: []string{CheckMetadata},
+		},
+		{
+			name:     "Parameter and resource removed",
+			filename: "rds.yml",
+			replacer: strings.NewReplacer("  ProtectionState:", "  Other:", "  PeriodicStopSchedule:", "  Other:"),
+			option:   rdsOption,
+			expected: []string{CheckParameters, CheckResources},
+		},
+		{
+			name:     "Invalid definition JSON",
+			filename: "rds.yml",
+			replacer: strings.NewReplacer(`"StartAt": "Setup",`, `"StartAt": "Setup"`),
+			option:   rdsOption,
+			expected: []string{CheckDefinition},
+		},
+		{
+			name:     "Undefined states",
+			filename: "rds.yml",
+			replacer: strings.NewReplacer(`"StartAt": "Setup",`, `"StartAt": "Missing",`, `"Next": "DescribeDB`, `"Next": "Undefined`),
+			option:   rdsOption,
+			expected: []string{CheckDefinition, CheckDefinition, CheckDefinition},
+		},
+		{
+			name:     "Long role name",
+			filename: "rds.yml",
+			replacer: strings.NewReplacer("RoleName: 'ktnh-sfn-", "RoleName: 'ktnh-sfn-"+strings.Repeat("x", 50)),
+			option:   rdsOption,
+			expected: []string{CheckIAM},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			templateBody := tc.replacer.Replace(readTestFile(t, tc.filename))
+
+			problems := CheckTemplate(templateBody, tc.option)
+
+			var checks []string
+
+			for _, problem := range problems {
+				checks = append(checks, problem.Check)
+			}
+
+			assert.Equal(t, tc.expected, checks, "Problems do not match expected value")
+		})
+	}
+}
+
+func Test_TemplateError_Error(t *testing.T) {
+	err := &TemplateError{
+		Problems: []TemplateProblem{
+			{Check: CheckResources, Message: "resource 'StateMachine' is missing"},
+			{Check: CheckIAM, Message: "role name is too long"},
+		},
+	}
+
+	assert.Equal(t, "invalid template: [resources] resource 'StateMachine' is missing; [iam] role name is too long", err.Error(), "Error message does not match expected value")
+}
+
+func Test_ValidateTemplate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{
+			name:    "Valid",
+			err:     nil,
+			wantErr: false,
+		},
+		{
+			name:    "API error",
+			err:     assert.AnError,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudFormationFactory)
+			mockClient := new(appmock.MockCloudFormationClient)
+
+			mockFactory.On("GetClient").
+				Return(mockClient)
+
+			mockClient.On("ValidateTemplate", mock.Anything, &cloudformation.ValidateTemplateInput{
+				TemplateBody: aws.String("template-body"),
+			}, mock.Anything).
+				Return(&cloudformation.ValidateTemplateOutput{}, tc.err)
+
+			c := NewCloudFormation(mockFactory)
+
+			err := c.ValidateTemplate("template-body")
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
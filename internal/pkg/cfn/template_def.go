@@ -39,7 +39,7 @@ const templateStr = `
     },
     "DescribeDBStatus": {
       "Type": "Task",
-      "Resource": "arn:aws:states:::aws-sdk:rds:describeDBClusters",
+      "Resource": "arn:${Partition}:states:::aws-sdk:rds:describeDBClusters",
       "Arguments": {
         "DbClusterIdentifier": "{{ .DBIdentifier }}"
       },
@@ -80,7 +80,7 @@ const templateStr = `
     },
     "StopDB": {
       "Type": "Task",
-      "Resource": "arn:aws:states:::aws-sdk:rds:stopDBCluster",
+      "Resource": "arn:${Partition}:states:::aws-sdk:rds:stopDBCluster",
       "Arguments": {
         "DbClusterIdentifier": "{{ .DBIdentifier }}"
       },
@@ -135,7 +135,7 @@ const templateStr = `
     },
     "DescribeDBStatus": {
       "Type": "Task",
-      "Resource": "arn:aws:states:::aws-sdk:rds:describeDBInstances",
+      "Resource": "arn:${Partition}:states:::aws-sdk:rds:describeDBInstances",
       "Arguments": {
         "DbInstanceIdentifier": "{{ .DBIdentifier }}"
       },
@@ -176,7 +176,7 @@ const templateStr = `
     },
     "StopDB": {
       "Type": "Task",
-      "Resource": "arn:aws:states:::aws-sdk:rds:stopDBInstance",
+      "Resource": "arn:${Partition}:states:::aws-sdk:rds:stopDBInstance",
       "Arguments": {
         "DbInstanceIdentifier": "{{ .DBIdentifier }}"
       },
@@ -196,14 +196,33 @@ Metadata:
   KTNH:
     Generator: '{{ .GeneratorName }}'
     Version: '{{ .GeneratorVersion }}'
+    Prefix: '{{ .Prefix }}'
     DBIdentifier: '{{ .DBIdentifier }}'
     DBType: '{{ .DBType }}'
 
+Parameters:
+  ScheduleExpression:
+    Type: 'String'
+    Description: 'Schedule expression to stop the DB periodically as a backup mechanism'
+    Default: '{{ .ScheduleExpression }}'
+  LogRetentionInDays:
+    Type: 'Number'
+    Description: 'Retention period of the state machine logs in days'
+    AllowedValues: [1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653]
+    Default: {{ .LogRetentionInDays }}
+  ProtectionState:
+    Type: 'String'
+    Description: 'State of the event rule and the schedule (DISABLED while protection is paused)'
+    AllowedValues:
+      - 'ENABLED'
+      - 'DISABLED'
+    Default: 'ENABLED'
+
 Resources:
   StateMachineExecutionRole:
     Type: 'AWS::IAM::Role'
     Properties:
-      RoleName: 'ktnh-sfn-{{ .DBIdentifierShort }}-{{ .Qualifier }}'
+      RoleName: '{{ .Names.StateMachineExecutionRole }}'
       Description: 'Execution role for the ktnh state machine'
       AssumeRolePolicyDocument:
         Version: '2012-10-17'
@@ -222,7 +241,7 @@ Resources:
                   - 'rds:DescribeDB{{ if eq .DBType "aurora" }}Cluster{{ else }}Instance{{ end }}s'
                   - 'rds:StopDB{{ if eq .DBType "aurora" }}Cluster{{ else }}Instance{{ end }}'
                 Resource:
-                  - !Sub 'arn:aws:rds:${AWS::Region}:${AWS::AccountId}:{{ if eq .DBType "aurora" }}cluster{{ else }}db{{ end }}:{{ .DBIdentifier }}'
+                  - !Sub 'arn:${AWS::Partition}:rds:${AWS::Region}:${AWS::AccountId}:{{ if eq .DBType "aurora" }}cluster{{ else }}db{{ end }}:{{ .DBIdentifier }}'
         - PolicyName: 'logs'
           PolicyDocument:
             Version: '2012-10-17'
@@ -243,19 +262,21 @@ Resources:
   StateMachineLogGroup:
     Type: 'AWS::Logs::LogGroup'
     Properties:
-      LogGroupName: 'ktnh-sfn-{{ .DBIdentifierShort }}-{{ .Qualifier }}'
-      RetentionInDays: 14
+      LogGroupName: '{{ .Names.StateMachineLogGroup }}'
+      RetentionInDays: !Ref 'LogRetentionInDays'
 
   StateMachine:
     Type: 'AWS::StepFunctions::StateMachine'
     Properties:
-      StateMachineName: 'ktnh-{{ .DBIdentifierShort }}-{{ .Qualifier }}'
+      StateMachineName: '{{ .Names.StateMachine }}'
       DefinitionString: |-
         {{- if eq .DBType "aurora" }}
         {{-   include "stateMachineAurora" . | indent 8 | printf "\n%s" }}
         {{- else }}
         {{-   include "stateMachineRDS" . | indent 8 | printf "\n%s" }}
         {{- end }}
+      DefinitionSubstitutions:
+        Partition: !Ref 'AWS::Partition'
       RoleArn: !GetAtt 'StateMachineExecutionRole.Arn'
       LoggingConfiguration:
         Level: 'ALL'
@@ -267,7 +288,7 @@ Resources:
   EventsRole:
     Type: 'AWS::IAM::Role'
     Properties:
-      RoleName: 'ktnh-events-{{ .DBIdentifierShort }}-{{ .Qualifier }}'
+      RoleName: '{{ .Names.EventsRole }}'
       Description: 'Role used by EventBridge rule and scheduler to trigger the ktnh state machine'
       AssumeRolePolicyDocument:
         Version: '2012-10-17'
@@ -291,9 +312,9 @@ Resources:
   RDSAutoStartEventRule:
     Type: 'AWS::Events::Rule'
     Properties:
-      Name: 'ktnh-autostart-{{ .DBIdentifierShort }}-{{ .Qualifier }}'
+      Name: '{{ .Names.AutoStartEventRule }}'
       Description: 'Rule to capture Aurora cluster or RDS instance auto-start events and trigger Step Functions'
-      State: 'ENABLED'
+      State: !Ref 'ProtectionState'
       EventPattern:
         source:
           - 'aws.rds'
@@ -315,10 +336,10 @@ Resources:
   PeriodicStopSchedule:
     Type: 'AWS::Scheduler::Schedule'
     Properties:
-      Name: 'ktnh-periodicstop-{{ .DBIdentifierShort }}-{{ .Qualifier }}'
-      Description: 'Schedule to stop Aurora cluster or RDS instance every 6 hours as a backup mechanism'
-      State: 'ENABLED'
-      ScheduleExpression: 'rate(6 hours)'
+      Name: '{{ .Names.PeriodicStopSchedule }}'
+      Description: 'Schedule to stop Aurora cluster or RDS instance periodically as a backup mechanism'
+      State: !Ref 'ProtectionState'
+      ScheduleExpression: !Ref 'ScheduleExpression'
       Target:
         Arn: !GetAtt 'StateMachine.Arn'
         RoleArn: !GetAtt 'EventsRole.Arn'
@@ -330,5 +351,447 @@ Resources:
 
 {{- end -}}
 
+{{- define "cloudformationGeneric" -}}
+---
+AWSTemplateFormatVersion: '2010-09-09'
+Description: 'ktnh - Keep an Aurora cluster or an RDS instance stopped permanently'
+
+Metadata:
+  KTNH:
+    Generator: '{{ .GeneratorName }}'
+    Version: '{{ .GeneratorVersion }}'
+    Prefix: '{{ .Prefix }}'
+    DBIdentifier: '${DBIdentifier}'
+    DBType: '${DBType}'
+  AWS::CloudFormation::Interface:
+    ParameterGroups:
+      - Label:
+          default: 'Database'
+        Parameters:
+          - 'DBIdentifier'
+          - 'DBType'
+      - Label:
+          default: 'Protection'
+        Parameters:
+          - 'ScheduleExpression'
+          - 'LogRetentionInDays'
+          - 'ProtectionState'
+
+Parameters:
+  DBIdentifier:
+    Type: 'String'
+    Description: 'Identifier of the Aurora cluster or the RDS instance to keep stopped'
+    AllowedPattern: '^[a-z][a-z0-9-]{0,62}$'
+    ConstraintDescription: 'must be a lowercase DB identifier (e.g., my-db), as it is matched case-sensitively in the event rule and the IAM policies'
+  DBType:
+    Type: 'String'
+    Description: 'Type of the DB (aurora for an Aurora cluster, rds for an RDS instance)'
+    AllowedValues:
+      - 'aurora'
+      - 'rds'
+  ScheduleExpression:
+    Type: 'String'
+    Description: 'Schedule expression to stop the DB periodically as a backup mechanism'
+    Default: '{{ .ScheduleExpression }}'
+  LogRetentionInDays:
+    Type: 'Number'
+    Description: 'Retention period of the state machine logs in days'
+    AllowedValues: [1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653]
+    Default: {{ .LogRetentionInDays }}
+  ProtectionState:
+    Type: 'String'
+    Description: 'State of the event rule and the schedule (DISABLED while protection is paused)'
+    AllowedValues:
+      - 'ENABLED'
+      - 'DISABLED'
+    Default: 'ENABLED'
+
+Conditions:
+  IsAurora: !Equals [!Ref 'DBType', 'aurora']
+
+Resources:
+  StateMachineExecutionRole:
+    Type: 'AWS::IAM::Role'
+    Properties:
+      RoleName: !Sub
+        - '{{ .Names.StateMachineExecutionRole }}'
+        - StackID: !Select [2, !Split ['/', !Ref 'AWS::StackId']]
+      Description: 'Execution role for the ktnh state machine'
+      AssumeRolePolicyDocument:
+        Version: '2012-10-17'
+        Statement:
+          - Effect: 'Allow'
+            Action: 'sts:AssumeRole'
+            Principal:
+              Service: 'states.amazonaws.com'
+      Policies:
+        - PolicyName: 'rds'
+          PolicyDocument:
+            Version: '2012-10-17'
+            Statement:
+              - Effect: 'Allow'
+                Action: !If
+                  - 'IsAurora'
+                  - - 'rds:DescribeDBClusters'
+                    - 'rds:StopDBCluster'
+                  - - 'rds:DescribeDBInstances'
+                    - 'rds:StopDBInstance'
+                Resource:
+                  - !If
+                    - 'IsAurora'
+                    - !Sub 'arn:${AWS::Partition}:rds:${AWS::Region}:${AWS::AccountId}:cluster:${DBIdentifier}'
+                    - !Sub 'arn:${AWS::Partition}:rds:${AWS::Region}:${AWS::AccountId}:db:${DBIdentifier}'
+        - PolicyName: 'logs'
+          PolicyDocument:
+            Version: '2012-10-17'
+            Statement:
+              - Effect: 'Allow'
+                Action:
+                  - 'logs:CreateLogDelivery'
+                  - 'logs:GetLogDelivery'
+                  - 'logs:UpdateLogDelivery'
+                  - 'logs:DeleteLogDelivery'
+                  - 'logs:ListLogDeliveries'
+                  - 'logs:PutLogEvents'
+                  - 'logs:PutResourcePolicy'
+                  - 'logs:DescribeResourcePolicies'
+                  - 'logs:DescribeLogGroups'
+                Resource: '*'
+
+  StateMachineLogGroup:
+    Type: 'AWS::Logs::LogGroup'
+    Properties:
+      LogGroupName: !Sub
+        - '{{ .Names.StateMachineLogGroup }}'
+        - StackID: !Select [2, !Split ['/', !Ref 'AWS::StackId']]
+      RetentionInDays: !Ref 'LogRetentionInDays'
+
+  StateMachine:
+    Type: 'AWS::StepFunctions::StateMachine'
+    Properties:
+      StateMachineName: !Sub
+        - '{{ .Names.StateMachine }}'
+        - StackID: !Select [2, !Split ['/', !Ref 'AWS::StackId']]
+      DefinitionString: !If
+        - 'IsAurora'
+        - |-
+          {{- include "stateMachineAurora" . | indent 10 | printf "\n%s" }}
+        - |-
+          {{- include "stateMachineRDS" . | indent 10 | printf "\n%s" }}
+      DefinitionSubstitutions:
+        DBIdentifier: !Ref 'DBIdentifier'
+        Partition: !Ref 'AWS::Partition'
+      RoleArn: !GetAtt 'StateMachineExecutionRole.Arn'
+      LoggingConfiguration:
+        Level: 'ALL'
+        IncludeExecutionData: true
+        Destinations:
+          - CloudWatchLogsLogGroup:
+              LogGroupArn: !GetAtt 'StateMachineLogGroup.Arn'
+
+  EventsRole:
+    Type: 'AWS::IAM::Role'
+    Properties:
+      RoleName: !Sub
+        - '{{ .Names.EventsRole }}'
+        - StackID: !Select [2, !Split ['/', !Ref 'AWS::StackId']]
+      Description: 'Role used by EventBridge rule and scheduler to trigger the ktnh state machine'
+      AssumeRolePolicyDocument:
+        Version: '2012-10-17'
+        Statement:
+          - Effect: 'Allow'
+            Action: 'sts:AssumeRole'
+            Principal:
+              Service:
+                - 'events.amazonaws.com'
+                - 'scheduler.amazonaws.com'
+      Policies:
+        - PolicyName: 'statemachine'
+          PolicyDocument:
+            Version: '2012-10-17'
+            Statement:
+              - Effect: 'Allow'
+                Action:
+                  - 'states:StartExecution'
+                Resource: !GetAtt 'StateMachine.Arn'
+
+  RDSAutoStartEventRule:
+    Type: 'AWS::Events::Rule'
+    Properties:
+      Name: !Sub
+        - '{{ .Names.AutoStartEventRule }}'
+        - StackID: !Select [2, !Split ['/', !Ref 'AWS::StackId']]
+      Description: 'Rule to capture Aurora cluster or RDS instance auto-start events and trigger Step Functions'
+      State: !Ref 'ProtectionState'
+      EventPattern:
+        source:
+          - 'aws.rds'
+        detail-type:
+          - !If ['IsAurora', 'RDS DB Cluster Event', 'RDS DB Instance Event']
+        detail:
+          EventID:
+            - !If ['IsAurora', 'RDS-EVENT-0153', 'RDS-EVENT-0154']
+          SourceIdentifier:
+            - !Ref 'DBIdentifier'
+      Targets:
+        - Id: 'stop'
+          Arn: !GetAtt 'StateMachine.Arn'
+          RoleArn: !GetAtt 'EventsRole.Arn'
+          RetryPolicy:
+            MaximumEventAgeInSeconds: 86400
+            MaximumRetryAttempts: 185
+
+  PeriodicStopSchedule:
+    Type: 'AWS::Scheduler::Schedule'
+    Properties:
+      Name: !Sub
+        - '{{ .Names.PeriodicStopSchedule }}'
+        - StackID: !Select [2, !Split ['/', !Ref 'AWS::StackId']]
+      Description: 'Schedule to stop Aurora cluster or RDS instance periodically as a backup mechanism'
+      State: !Ref 'ProtectionState'
+      ScheduleExpression: !Ref 'ScheduleExpression'
+      Target:
+        Arn: !GetAtt 'StateMachine.Arn'
+        RoleArn: !GetAtt 'EventsRole.Arn'
+        RetryPolicy:
+          MaximumEventAgeInSeconds: 1800
+          MaximumRetryAttempts: 3
+      FlexibleTimeWindow:
+        Mode: 'OFF'
+
+{{- end -}}
+
+{{- define "terraform" -}}
+# Generated by {{ .GeneratorName }} version {{ .GeneratorVersion }}
+# DBIdentifier: {{ .DBIdentifier }}
+# DBType: {{ .DBType }}
+
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.40"
+    }
+  }
+}
+
+variable "protection_state" {
+  type        = string
+  description = "State of the event rule and the schedule (DISABLED while protection is paused)"
+  default     = "ENABLED"
+
+  validation {
+    condition     = contains(["ENABLED", "DISABLED"], var.protection_state)
+    error_message = "The protection_state must be either ENABLED or DISABLED."
+  }
+}
+
+data "aws_partition" "current" {}
+
+data "aws_region" "current" {}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_iam_role" "state_machine_execution" {
+  name        = "{{ .Names.StateMachineExecutionRole }}"
+  description = "Execution role for the ktnh state machine"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = "sts:AssumeRole"
+        Principal = {
+          Service = "states.amazonaws.com"
+        }
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "state_machine_execution_rds" {
+  name = "rds"
+  role = aws_iam_role.state_machine_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "rds:DescribeDB{{ if eq .DBType "aurora" }}Cluster{{ else }}Instance{{ end }}s",
+          "rds:StopDB{{ if eq .DBType "aurora" }}Cluster{{ else }}Instance{{ end }}",
+        ]
+        Resource = [
+          "arn:${data.aws_partition.current.partition}:rds:${data.aws_region.current.id}:${data.aws_caller_identity.current.account_id}:{{ if eq .DBType "aurora" }}cluster{{ else }}db{{ end }}:{{ .DBIdentifier }}",
+        ]
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "state_machine_execution_logs" {
+  name = "logs"
+  role = aws_iam_role.state_machine_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "logs:CreateLogDelivery",
+          "logs:GetLogDelivery",
+          "logs:UpdateLogDelivery",
+          "logs:DeleteLogDelivery",
+          "logs:ListLogDeliveries",
+          "logs:PutLogEvents",
+          "logs:PutResourcePolicy",
+          "logs:DescribeResourcePolicies",
+          "logs:DescribeLogGroups",
+        ]
+        Resource = "*"
+      },
+    ]
+  })
+}
+
+resource "aws_cloudwatch_log_group" "state_machine" {
+  name              = "{{ .Names.StateMachineLogGroup }}"
+  retention_in_days = {{ .LogRetentionInDays }}
+}
+
+locals {
+  # The partition is substituted for "${Partition}" in the same way as DefinitionSubstitutions of CloudFormation.
+  state_machine_definition = <<-EOT
+    {{- if eq .DBType "aurora" }}
+    {{-   include "stateMachineAurora" . | escapeHCL | indent 4 | printf "\n%s" }}
+    {{- else }}
+    {{-   include "stateMachineRDS" . | escapeHCL | indent 4 | printf "\n%s" }}
+    {{- end }}
+  EOT
+}
+
+resource "aws_sfn_state_machine" "state_machine" {
+  name     = "{{ .Names.StateMachine }}"
+  role_arn = aws_iam_role.state_machine_execution.arn
+
+  definition = replace(local.state_machine_definition, "$${Partition}", data.aws_partition.current.partition)
+
+  logging_configuration {
+    level                  = "ALL"
+    include_execution_data = true
+    log_destination        = "${aws_cloudwatch_log_group.state_machine.arn}:*"
+  }
+
+  depends_on = [
+    aws_iam_role_policy.state_machine_execution_rds,
+    aws_iam_role_policy.state_machine_execution_logs,
+  ]
+}
+
+resource "aws_iam_role" "events" {
+  name        = "{{ .Names.EventsRole }}"
+  description = "Role used by EventBridge rule and scheduler to trigger the ktnh state machine"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = "sts:AssumeRole"
+        Principal = {
+          Service = [
+            "events.amazonaws.com",
+            "scheduler.amazonaws.com",
+          ]
+        }
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "events_statemachine" {
+  name = "statemachine"
+  role = aws_iam_role.events.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "states:StartExecution",
+        ]
+        Resource = aws_sfn_state_machine.state_machine.arn
+      },
+    ]
+  })
+}
+
+resource "aws_cloudwatch_event_rule" "rds_auto_start" {
+  name        = "{{ .Names.AutoStartEventRule }}"
+  description = "Rule to capture Aurora cluster or RDS instance auto-start events and trigger Step Functions"
+  state       = var.protection_state
+
+  event_pattern = jsonencode({
+    source = [
+      "aws.rds",
+    ]
+    detail-type = [
+      "RDS DB {{ if eq .DBType "aurora" }}Cluster{{ else }}Instance{{ end }} Event",
+    ]
+    detail = {
+      EventID = [
+        "RDS-EVENT-{{ if eq .DBType "aurora" }}0153{{ else }}0154{{ end }}",
+      ]
+      SourceIdentifier = [
+        "{{ .DBIdentifier }}",
+      ]
+    }
+  })
+}
+
+resource "aws_cloudwatch_event_target" "rds_auto_start" {
+  rule      = aws_cloudwatch_event_rule.rds_auto_start.name
+  target_id = "stop"
+  arn       = aws_sfn_state_machine.state_machine.arn
+  role_arn  = aws_iam_role.events.arn
+
+  retry_policy {
+    maximum_event_age_in_seconds = 86400
+    maximum_retry_attempts       = 185
+  }
+}
+
+resource "aws_scheduler_schedule" "periodic_stop" {
+  name                = "{{ .Names.PeriodicStopSchedule }}"
+  description         = "Schedule to stop Aurora cluster or RDS instance periodically as a backup mechanism"
+  state               = var.protection_state
+  schedule_expression = "{{ .ScheduleExpression }}"
+
+  target {
+    arn      = aws_sfn_state_machine.state_machine.arn
+    role_arn = aws_iam_role.events.arn
+
+    retry_policy {
+      maximum_event_age_in_seconds = 1800
+      maximum_retry_attempts       = 3
+    }
+  }
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+}
+
+output "state_machine_arn" {
+  description = "ARN of the ktnh state machine"
+  value       = aws_sfn_state_machine.state_machine.arn
+}
+
+{{- end -}}
+
 {{- template "cloudformation" . -}}
 `
\ No newline at end of file
@@ -0,0 +1,179 @@
+package cfn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+/*
+ConvertTemplateToJSON converts a CloudFormation template in YAML into JSON.
+The order of the keys is preserved, and the short form of intrinsic functions (e.g., `!Ref`)
+is converted into the full form (e.g., `{"Ref": ...}`), which is the only form available in JSON.
+*/
+func ConvertTemplateToJSON(templateBody string) (string, error) {
+	var document yaml.Node
+
+	if err := yaml.Unmarshal([]byte(templateBody), &document); err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if (document.Kind != yaml.DocumentNode) || (len(document.Content) == 0) {
+		return "", fmt.Errorf("template is empty")
+	}
+
+	var buf bytes.Buffer
+
+	if err := writeJSONNode(&buf, document.Content[0]); err != nil {
+		return "", err
+	}
+
+	var indented bytes.Buffer
+
+	if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+		return "", fmt.Errorf("failed to format JSON template: %w", err)
+	}
+
+	slog.Debug("CloudFormation template converted into JSON")
+
+	return indented.String(), nil
+}
+
+/*
+writeJSONNode writes a YAML node as compact JSON.
+*/
+func writeJSONNode(buf *bytes.Buffer, node *yaml.Node) error {
+	if isIntrinsicFunctionTag(node.Tag) {
+		return writeIntrinsicFunction(buf, node)
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+
+		for i := 0; i < len(node.Content); i += 2 {
+			if 0 < i {
+				buf.WriteByte(',')
+			}
+
+			key, err := json.Marshal(node.Content[i].Value)
+
+			if err != nil {
+				return fmt.Errorf("failed to convert key '%s': %w", node.Content[i].Value, err)
+			}
+
+			buf.Write(key)
+			buf.WriteByte(':')
+
+			if err := writeJSONNode(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+
+		for i, item := range node.Content {
+			if 0 < i {
+				buf.WriteByte(',')
+			}
+
+			if err := writeJSONNode(buf, item); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	case yaml.ScalarNode:
+		var value any = node.Value
+
+		// NOTE: timestamps are kept as they are written, since JSON has no such type.
+		if node.Tag != "!!timestamp" {
+			if err := node.Decode(&value); err != nil {
+				return fmt.Errorf("failed to decode value at line %d: %w", node.Line, err)
+			}
+		}
+
+		encoded, err := json.Marshal(value)
+
+		if err != nil {
+			return fmt.Errorf("failed to convert value at line %d: %w", node.Line, err)
+		}
+
+		buf.Write(encoded)
+	case yaml.AliasNode:
+		return writeJSONNode(buf, node.Alias)
+	default:
+		return fmt.Errorf("unsupported YAML node at line %d", node.Line)
+	}
+
+	return nil
+}
+
+/*
+isIntrinsicFunctionTag determines whether the tag is the short form of an intrinsic function.
+Standard YAML tags start with "!!", while the short forms start with a single "!".
+*/
+func isIntrinsicFunctionTag(tag string) bool {
+	return strings.HasPrefix(tag, "!") && !strings.HasPrefix(tag, "!!")
+}
+
+/*
+writeIntrinsicFunction writes the short form of an intrinsic function in the full form.
+`!GetAtt 'Resource.Attribute'` is split into a list, as required by `Fn::GetAtt` in JSON.
+*/
+func writeIntrinsicFunction(buf *bytes.Buffer, node *yaml.Node) error {
+	name := strings.TrimPrefix(node.Tag, "!")
+
+	key := "Fn::" + name
+
+	if (name == "Ref") || (name == "Condition") {
+		key = name
+	}
+
+	argument := *node
+
+	argument.Tag = ""
+
+	if (name == "GetAtt") && (node.Kind == yaml.ScalarNode) {
+		resource, attribute, found := strings.Cut(node.Value, ".")
+
+		if !found {
+			return fmt.Errorf("invalid !GetAtt '%s' at line %d", node.Value, node.Line)
+		}
+
+		argument = yaml.Node{
+			Kind: yaml.SequenceNode,
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: resource},
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: attribute},
+			},
+		}
+	} else if node.Kind == yaml.ScalarNode {
+		// NOTE: arguments of intrinsic functions given as scalars are always strings.
+		argument.Tag = "!!str"
+	}
+
+	encodedKey, err := json.Marshal(key)
+
+	if err != nil {
+		return fmt.Errorf("failed to convert intrinsic function '%s': %w", node.Tag, err)
+	}
+
+	buf.WriteByte('{')
+	buf.Write(encodedKey)
+	buf.WriteByte(':')
+
+	if err := writeJSONNode(buf, &argument); err != nil {
+		return err
+	}
+
+	buf.WriteByte('}')
+
+	return nil
+}
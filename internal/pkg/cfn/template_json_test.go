@@ -0,0 +1,119 @@
+package cfn
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConvertTemplateToJSON(t *testing.T) {
+	testCases := []struct {
+		name         string
+		templateBody string
+		expected     string
+		wantErr      bool
+	}{
+		{
+			name: "Intrinsic functions",
+			templateBody: `Version: '2010-09-09'
+Resources:
+  Rule:
+    Properties:
+      State: !Ref 'ProtectionState'
+      RoleArn: !GetAtt 'Role.Arn'
+      Resource: !Sub 'arn:aws:rds:${AWS::Region}:db'
+      Join: !Join ['', ['a', !Ref 'B']]
+      Count: 14
+      Enabled: true
+`,
+			expected: `{
+  "Version": "2010-09-09",
+  "Resources": {
+    "Rule": {
+      "Properties": {
+        "State": {
+          "Ref": "ProtectionState"
+        },
+        "RoleArn": {
+          "Fn::GetAtt": [
+            "Role",
+            "Arn"
+          ]
+        },
+        "Resource": {
+          "Fn::Sub": "arn:aws:rds:${AWS::Region}:db"
+        },
+        "Join": {
+          "Fn::Join": [
+            "",
+            [
+              "a",
+              {
+                "Ref": "B"
+              }
+            ]
+          ]
+        },
+        "Count": 14,
+        "Enabled": true
+      }
+    }
+  }
+}`,
+			wantErr: false,
+		},
+		{
+			name:         "Invalid GetAtt",
+			templateBody: "Arn: !GetAtt 'Role'\n",
+			wantErr:      true,
+		},
+		{
+			name:         "Invalid YAML",
+			templateBody: "a: [\n",
+			wantErr:      true,
+		},
+		{
+			name:         "Empty",
+			templateBody: "",
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ConvertTemplateToJSON(tc.templateBody)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Converted template does not match expected output")
+			}
+		})
+	}
+}
+
+func Test_ConvertTemplateToJSON_GeneratedTemplate(t *testing.T) {
+	for _, filename := range []string{"aurora.yml", "rds.yml", "generic.yml"} {
+		t.Run(filename, func(t *testing.T) {
+			got, err := ConvertTemplateToJSON(readTestFile(t, filename))
+
+			assert.NoError(t, err, "Unexpected error occurred")
+
+			var template map[string]any
+
+			assert.NoError(t, json.Unmarshal([]byte(got), &template), "Converted template is not valid JSON")
+
+			assert.True(t, strings.HasPrefix(got, "{\n  \"AWSTemplateFormatVersion\": \"2010-09-09\""), "Order of keys should be preserved")
+
+			metadata, err := parseTemplate(got)
+
+			assert.NoError(t, err, "Converted template should be parsed as a ktnh template")
+
+			assert.NotEmpty(t, metadata.Metadata.KTNH.DBIdentifier, "Metadata should be preserved")
+		})
+	}
+}
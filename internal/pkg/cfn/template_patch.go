@@ -0,0 +1,313 @@
+package cfn
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	patchOpAdd     = "add"     // adds a value, or replaces the value of an existing key
+	patchOpRemove  = "remove"  // removes an existing value
+	patchOpReplace = "replace" // replaces an existing value
+)
+
+/*
+templatePatch holds a patch applied to the rendered CloudFormation template.
+A patch is either a list of JSON Patch (RFC 6902) operations, or a mapping merged into the template
+in the same way as JSON Merge Patch (RFC 7386).
+*/
+type templatePatch struct {
+	source     string           // path of the patch file
+	operations []patchOperation // JSON Patch operations (nil for a merge patch)
+	merge      *yaml.Node       // mapping to be merged into the template (nil for JSON Patch)
+}
+
+/*
+patchOperation holds a JSON Patch operation.
+*/
+type patchOperation struct {
+	Op    string    `yaml:"op"`    // "add", "remove" or "replace"
+	Path  string    `yaml:"path"`  // JSON Pointer (RFC 6901) to the target value
+	Value yaml.Node `yaml:"value"` // value for "add" and "replace"
+}
+
+/*
+parseTemplatePatch parses a patch in YAML or JSON.
+Values may use the short form of intrinsic functions (e.g., `!Ref`), as in the template.
+*/
+func parseTemplatePatch(source string, data []byte) (*templatePatch, error) {
+	var document yaml.Node
+
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse patch '%s': %w", source, err)
+	}
+
+	if (document.Kind != yaml.DocumentNode) || (len(document.Content) == 0) {
+		return nil, fmt.Errorf("patch '%s' is empty", source)
+	}
+
+	root := document.Content[0]
+
+	switch root.Kind {
+	case yaml.MappingNode:
+		return &templatePatch{source: source, merge: root}, nil
+	case yaml.SequenceNode:
+		var operations []patchOperation
+
+		if err := root.Decode(&operations); err != nil {
+			return nil, fmt.Errorf("failed to parse patch '%s': %w", source, err)
+		}
+
+		for i, operation := range operations {
+			switch operation.Op {
+			case patchOpAdd, patchOpReplace:
+				if operation.Value.Kind == 0 {
+					return nil, fmt.Errorf("patch '%s': operation %d: value is required for '%s'", source, i, operation.Op)
+				}
+			case patchOpRemove:
+			default:
+				return nil, fmt.Errorf("patch '%s': operation %d: unsupported op '%s' (must be '%s', '%s' or '%s')", source, i, operation.Op, patchOpAdd, patchOpRemove, patchOpReplace)
+			}
+
+			if !strings.HasPrefix(operation.Path, "/") {
+				return nil, fmt.Errorf("patch '%s': operation %d: path must start with '/'", source, i)
+			}
+		}
+
+		return &templatePatch{source: source, operations: operations}, nil
+	default:
+		return nil, fmt.Errorf("patch '%s' must be a list of operations or a mapping", source)
+	}
+}
+
+/*
+applyTemplatePatches applies the patches to the template in order, and returns the patched template.
+The template is returned as is if no patches are given.
+*/
+func applyTemplatePatches(templateBody string, patches []*templatePatch) (string, error) {
+	if len(patches) == 0 {
+		return templateBody, nil
+	}
+
+	var document yaml.Node
+
+	if err := yaml.Unmarshal([]byte(templateBody), &document); err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if (document.Kind != yaml.DocumentNode) || (len(document.Content) == 0) {
+		return "", fmt.Errorf("template is empty")
+	}
+
+	for _, patch := range patches {
+		if err := patch.apply(document.Content[0]); err != nil {
+			return "", fmt.Errorf("failed to apply patch '%s': %w", patch.source, err)
+		}
+
+		slog.Debug("Applied template patch", "source", patch.source)
+	}
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&document); err != nil {
+		return "", fmt.Errorf("failed to encode patched template: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode patched template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+/*
+apply applies the patch to the root node of the template.
+*/
+func (p *templatePatch) apply(root *yaml.Node) error {
+	if p.merge != nil {
+		mergeNode(root, p.merge)
+
+		return nil
+	}
+
+	for i, operation := range p.operations {
+		if err := applyPatchOperation(root, operation); err != nil {
+			return fmt.Errorf("operation %d (%s %s): %w", i, operation.Op, operation.Path, err)
+		}
+	}
+
+	return nil
+}
+
+/*
+applyPatchOperation applies a JSON Patch operation to the root node.
+*/
+func applyPatchOperation(root *yaml.Node, operation patchOperation) error {
+	tokens := parsePointer(operation.Path)
+
+	parent, err := resolvePointer(root, tokens[:len(tokens)-1])
+
+	if err != nil {
+		return err
+	}
+
+	last := tokens[len(tokens)-1]
+
+	switch parent.Kind {
+	case yaml.MappingNode:
+		index := mappingKeyIndex(parent, last)
+
+		switch {
+		case operation.Op == patchOpAdd && index < 0:
+			parent.Content = append(parent.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: last},
+				cloneNode(&operation.Value),
+			)
+		case index < 0:
+			return fmt.Errorf("key '%s' not found", last)
+		case operation.Op == patchOpRemove:
+			parent.Content = append(parent.Content[:index], parent.Content[index+2:]...)
+		default:
+			parent.Content[index+1] = cloneNode(&operation.Value)
+		}
+	case yaml.SequenceNode:
+		if (operation.Op == patchOpAdd) && (last == "-") {
+			parent.Content = append(parent.Content, cloneNode(&operation.Value))
+
+			return nil
+		}
+
+		index, err := strconv.Atoi(last)
+
+		upper := len(parent.Content)
+
+		if operation.Op == patchOpAdd {
+			upper++
+		}
+
+		if (err != nil) || (index < 0) || (upper <= index) {
+			return fmt.Errorf("invalid index '%s'", last)
+		}
+
+		switch operation.Op {
+		case patchOpAdd:
+			parent.Content = append(parent.Content[:index], append([]*yaml.Node{cloneNode(&operation.Value)}, parent.Content[index:]...)...)
+		case patchOpRemove:
+			parent.Content = append(parent.Content[:index], parent.Content[index+1:]...)
+		default:
+			parent.Content[index] = cloneNode(&operation.Value)
+		}
+	default:
+		return fmt.Errorf("parent of '%s' is not a mapping or a list", operation.Path)
+	}
+
+	return nil
+}
+
+/*
+parsePointer splits a JSON Pointer into unescaped reference tokens.
+*/
+func parsePointer(pointer string) []string {
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+
+	unescaper := strings.NewReplacer("~1", "/", "~0", "~")
+
+	for i, token := range tokens {
+		tokens[i] = unescaper.Replace(token)
+	}
+
+	return tokens
+}
+
+/*
+resolvePointer returns the node referenced by the tokens.
+*/
+func resolvePointer(node *yaml.Node, tokens []string) (*yaml.Node, error) {
+	for _, token := range tokens {
+		switch node.Kind {
+		case yaml.MappingNode:
+			index := mappingKeyIndex(node, token)
+
+			if index < 0 {
+				return nil, fmt.Errorf("key '%s' not found", token)
+			}
+
+			node = node.Content[index+1]
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(token)
+
+			if (err != nil) || (index < 0) || (len(node.Content) <= index) {
+				return nil, fmt.Errorf("invalid index '%s'", token)
+			}
+
+			node = node.Content[index]
+		default:
+			return nil, fmt.Errorf("cannot refer to '%s' in a scalar value", token)
+		}
+	}
+
+	return node, nil
+}
+
+/*
+mergeNode merges the patch into the target in the same way as JSON Merge Patch:
+mappings are merged recursively, null removes the key, and any other value replaces the target.
+*/
+func mergeNode(target *yaml.Node, patch *yaml.Node) {
+	for i := 0; i < len(patch.Content); i += 2 {
+		key := patch.Content[i].Value
+		value := patch.Content[i+1]
+
+		index := mappingKeyIndex(target, key)
+
+		switch {
+		case value.Tag == "!!null":
+			if 0 <= index {
+				target.Content = append(target.Content[:index], target.Content[index+2:]...)
+			}
+		case index < 0:
+			target.Content = append(target.Content, cloneNode(patch.Content[i]), cloneNode(value))
+		case (value.Kind == yaml.MappingNode) && (target.Content[index+1].Kind == yaml.MappingNode) && !isIntrinsicFunctionTag(value.Tag):
+			mergeNode(target.Content[index+1], value)
+		default:
+			target.Content[index+1] = cloneNode(value)
+		}
+	}
+}
+
+/*
+mappingKeyIndex returns the index of the key node in the mapping node, or -1 if not found.
+*/
+func mappingKeyIndex(node *yaml.Node, key string) int {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+/*
+cloneNode returns a deep copy of the node, so that a patch can be applied to more than one template.
+*/
+func cloneNode(node *yaml.Node) *yaml.Node {
+	clone := *node
+
+	clone.Content = make([]*yaml.Node, len(node.Content))
+
+	for i, child := range node.Content {
+		clone.Content[i] = cloneNode(child)
+	}
+
+	return &clone
+}
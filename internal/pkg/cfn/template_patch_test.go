@@ -0,0 +1,220 @@
+package cfn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseTemplatePatch(t *testing.T) {
+	testCases := []struct {
+		name          string
+		data          string
+		expectedMerge bool
+		wantErr       bool
+	}{
+		{
+			name:          "Merge patch",
+			data:          "Resources:\n  StateMachineLogGroup:\n    Properties:\n      RetentionInDays: 90\n",
+			expectedMerge: true,
+			wantErr:       false,
+		},
+		{
+			name:          "JSON Patch",
+			data:          `[{"op": "replace", "path": "/Description", "value": "custom"}, {"op": "remove", "path": "/Outputs"}]`,
+			expectedMerge: false,
+			wantErr:       false,
+		},
+		{
+			name:    "Unsupported op",
+			data:    `[{"op": "move", "from": "/a", "path": "/b"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Missing value",
+			data:    `[{"op": "add", "path": "/a"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Relative path",
+			data:    `[{"op": "remove", "path": "a"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Scalar",
+			data:    "patch",
+			wantErr: true,
+		},
+		{
+			name:    "Empty",
+			data:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseTemplatePatch("patch.yaml", []byte(tc.data))
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expectedMerge, got.merge != nil, "Kind of patch does not match expected value")
+			}
+		})
+	}
+}
+
+func Test_applyTemplatePatches(t *testing.T) {
+	templateBody := strings.Join([]string{
+		"Description: 'original'",
+		"Resources:",
+		"  LogGroup:",
+		"    Properties:",
+		"      RetentionInDays: 14",
+		"      LogGroupName: !Sub 'ktnh-${AWS::StackName}'",
+		"  Role:",
+		"    Properties:",
+		"      Tags:",
+		"        - Key: 'a'",
+		"          Value: '1'",
+	}, "\n")
+
+	testCases := []struct {
+		name     string
+		patches  []string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name: "Merge patch",
+			patches: []string{
+				strings.Join([]string{
+					"Description: ~",
+					"Resources:",
+					"  LogGroup:",
+					"    Properties:",
+					"      RetentionInDays: 90",
+					"      KmsKeyId: !Ref 'KmsKeyArn'",
+				}, "\n"),
+			},
+			expected: []string{
+				"Resources:",
+				"  LogGroup:",
+				"    Properties:",
+				"      RetentionInDays: 90",
+				"      LogGroupName: !Sub 'ktnh-${AWS::StackName}'",
+				"      KmsKeyId: !Ref 'KmsKeyArn'",
+				"  Role:",
+				"    Properties:",
+				"      Tags:",
+				"        - Key: 'a'",
+				"          Value: '1'",
+			},
+			wantErr: false,
+		},
+		{
+			name: "JSON Patch",
+			patches: []string{
+				strings.Join([]string{
+					"- op: 'replace'",
+					"  path: '/Description'",
+					"  value: 'patched'",
+					"- op: 'add'",
+					"  path: '/Resources/Role/Properties/Tags/-'",
+					"  value: {Key: 'b', Value: '2'}",
+					"- op: 'add'",
+					"  path: '/Resources/Role/Properties/Tags/0'",
+					"  value: {Key: 'c', Value: '3'}",
+					"- op: 'remove'",
+					"  path: '/Resources/LogGroup/Properties/RetentionInDays'",
+				}, "\n"),
+			},
+			expected: []string{
+				"Description: 'patched'",
+				"Resources:",
+				"  LogGroup:",
+				"    Properties:",
+				"      LogGroupName: !Sub 'ktnh-${AWS::StackName}'",
+				"  Role:",
+				"    Properties:",
+				"      Tags:",
+				"        - {Key: 'c', Value: '3'}",
+				"        - Key: 'a'",
+				"          Value: '1'",
+				"        - {Key: 'b', Value: '2'}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Patches in order",
+			patches: []string{
+				"Description: 'first'",
+				`[{"op": "replace", "path": "/Description", "value": "second"}]`,
+			},
+			expected: []string{
+				"Description: \"second\"",
+				"Resources:",
+				"  LogGroup:",
+				"    Properties:",
+				"      RetentionInDays: 14",
+				"      LogGroupName: !Sub 'ktnh-${AWS::StackName}'",
+				"  Role:",
+				"    Properties:",
+				"      Tags:",
+				"        - Key: 'a'",
+				"          Value: '1'",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Replace missing key",
+			patches: []string{
+				`[{"op": "replace", "path": "/Resources/Missing/Type", "value": "x"}]`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Index out of range",
+			patches: []string{
+				`[{"op": "remove", "path": "/Resources/Role/Properties/Tags/1"}]`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var patches []*templatePatch
+
+			for _, data := range tc.patches {
+				patch, err := parseTemplatePatch("patch.yaml", []byte(data))
+
+				assert.NoError(t, err, "Unexpected error occurred while parsing patch")
+
+				patches = append(patches, patch)
+			}
+
+			got, err := applyTemplatePatches(templateBody, patches)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, strings.Join(tc.expected, "\n")+"\n", got, "Patched template does not match expected value")
+			}
+		})
+	}
+}
+
+func Test_applyTemplatePatches_NoPatches(t *testing.T) {
+	got, err := applyTemplatePatches("Description: 'original' # comment\n", nil)
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	assert.Equal(t, "Description: 'original' # comment\n", got, "Template should be returned as is")
+}
@@ -3,10 +3,12 @@ package cfn
 import (
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func Test_GenerateTemplateBody(t *testing.T) {
@@ -41,7 +43,7 @@ func Test_GenerateTemplateBody(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			got, err := GenerateTemplateBody(tc.dbIdentifier, tc.dbIdentifierShort, tc.dbType, tc.qualifier)
+			got, err := GenerateTemplateBody("ktnh", tc.dbIdentifier, tc.dbIdentifierShort, tc.dbType, tc.qualifier)
 
 			if tc.wantErr {
 				assert.Error(t, err, "Expected an error to be returned")
@@ -56,6 +58,137 @@ func Test_GenerateTemplateBody(t *testing.T) {
 	}
 }
 
+func Test_GenerateGenericTemplateBody(t *testing.T) {
+	got, err := GenerateGenericTemplateBody("ktnh")
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	expected := readTestFile(t, "generic.yml")
+
+	assert.Equal(t, expected, got, "Generated template does not match expected output")
+}
+
+func Test_GenerateGenericTemplateBody_DBIdentifierPattern(t *testing.T) {
+	body, err := GenerateGenericTemplateBody("ktnh")
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	var template struct {
+		Parameters map[string]struct {
+			AllowedPattern string `yaml:"AllowedPattern"`
+		} `yaml:"Parameters"`
+	}
+
+	err = yaml.Unmarshal([]byte(body), &template)
+
+	assert.NoError(t, err, "Failed to parse generated template")
+
+	pattern := regexp.MustCompile(template.Parameters[ParameterDBIdentifier].AllowedPattern)
+
+	testCases := []struct {
+		name         string
+		dbIdentifier string
+		want         bool
+	}{
+		{
+			name:         "Lowercase",
+			dbIdentifier: "my-db",
+			want:         true,
+		},
+		{
+			name:         "Uppercase",
+			dbIdentifier: "MyDb",
+			want:         false,
+		},
+		{
+			name:         "Leading digit",
+			dbIdentifier: "1-db",
+			want:         false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, pattern.MatchString(tc.dbIdentifier), "AllowedPattern should match only lowercase DB identifiers")
+		})
+	}
+}
+
+func Test_StateMachineDefinition(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filename string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Template for a DB",
+			filename: "rds.yml",
+			expected: `"StartAt": "Setup",`,
+			wantErr:  false,
+		},
+		{
+			name:     "Generic template",
+			filename: "generic.yml",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := StateMachineDefinition(readTestFile(t, tc.filename))
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Contains(t, got, tc.expected, "Definition should be extracted from the template")
+			}
+		})
+	}
+}
+
+func Test_GenerateTerraform(t *testing.T) {
+	testCases := []struct {
+		name              string
+		dbIdentifier      string
+		dbIdentifierShort string
+		dbType            string
+		qualifier         string
+		expectFile        string
+	}{
+		{
+			name:              "Aurora",
+			dbIdentifier:      "aurora-db-identifier",
+			dbIdentifierShort: "aurora-db-i",
+			dbType:            "aurora",
+			qualifier:         "abcdef",
+			expectFile:        "aurora.tf",
+		},
+		{
+			name:              "RDS",
+			dbIdentifier:      "rds-db-identifier",
+			dbIdentifierShort: "rds-db-ide",
+			dbType:            "rds",
+			qualifier:         "ghijklm",
+			expectFile:        "rds.tf",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := GenerateTerraform("ktnh", tc.dbIdentifier, tc.dbIdentifierShort, tc.dbType, tc.qualifier)
+
+			assert.NoError(t, err, "Unexpected error occurred")
+
+			expected := readTestFile(t, tc.expectFile)
+
+			assert.Equal(t, expected, got, "Generated configuration does not match expected output")
+		})
+	}
+}
+
 /*
 readTestFile reads a testdata file.
 */
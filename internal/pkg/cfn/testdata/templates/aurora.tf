@@ -0,0 +1,307 @@
+# Generated by koreru-toki-no-hiho version 1
+# DBIdentifier: aurora-db-identifier
+# DBType: aurora
+
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.40"
+    }
+  }
+}
+
+variable "protection_state" {
+  type        = string
+  description = "State of the event rule and the schedule (DISABLED while protection is paused)"
+  default     = "ENABLED"
+
+  validation {
+    condition     = contains(["ENABLED", "DISABLED"], var.protection_state)
+    error_message = "The protection_state must be either ENABLED or DISABLED."
+  }
+}
+
+data "aws_partition" "current" {}
+
+data "aws_region" "current" {}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_iam_role" "state_machine_execution" {
+  name        = "ktnh-sfn-aurora-db-i-abcdef"
+  description = "Execution role for the ktnh state machine"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = "sts:AssumeRole"
+        Principal = {
+          Service = "states.amazonaws.com"
+        }
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "state_machine_execution_rds" {
+  name = "rds"
+  role = aws_iam_role.state_machine_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "rds:DescribeDBClusters",
+          "rds:StopDBCluster",
+        ]
+        Resource = [
+          "arn:${data.aws_partition.current.partition}:rds:${data.aws_region.current.id}:${data.aws_caller_identity.current.account_id}:cluster:aurora-db-identifier",
+        ]
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "state_machine_execution_logs" {
+  name = "logs"
+  role = aws_iam_role.state_machine_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "logs:CreateLogDelivery",
+          "logs:GetLogDelivery",
+          "logs:UpdateLogDelivery",
+          "logs:DeleteLogDelivery",
+          "logs:ListLogDeliveries",
+          "logs:PutLogEvents",
+          "logs:PutResourcePolicy",
+          "logs:DescribeResourcePolicies",
+          "logs:DescribeLogGroups",
+        ]
+        Resource = "*"
+      },
+    ]
+  })
+}
+
+resource "aws_cloudwatch_log_group" "state_machine" {
+  name              = "ktnh-sfn-aurora-db-i-abcdef"
+  retention_in_days = 14
+}
+
+locals {
+  # The partition is substituted for "${Partition}" in the same way as DefinitionSubstitutions of CloudFormation.
+  state_machine_definition = <<-EOT
+    {
+      "Comment": "State machine to automatically stop Aurora cluster",
+      "QueryLanguage": "JSONata",
+      "TimeoutSeconds": 3600,
+      "StartAt": "Setup",
+      "States": {
+        "Setup": {
+          "Type": "Pass",
+          "Assign": {
+            "dbStatus": {
+              "wait": [
+                "backing-up",
+                "backtracking",
+                "creating",
+                "failing-over",
+                "maintenance",
+                "migrating",
+                "modifying",
+                "promoting",
+                "preparing-data-migration",
+                "renaming",
+                "resetting-master-credentials",
+                "starting",
+                "storage-optimization",
+                "update-iam-db-auth",
+                "upgrading"
+              ],
+              "available": ["available"]
+            },
+            "stoppedCount": 0
+          },
+          "Next": "DescribeDBStatus"
+        },
+        "DescribeDBStatus": {
+          "Type": "Task",
+          "Resource": "arn:$${Partition}:states:::aws-sdk:rds:describeDBClusters",
+          "Arguments": {
+            "DbClusterIdentifier": "aurora-db-identifier"
+          },
+          "Next": "CheckDBStatus"
+        },
+        "CheckDBStatus": {
+          "Type": "Choice",
+          "Choices": [
+            {
+              "Condition": "{% $states.input.DbClusters[0].Status in $dbStatus.wait %}",
+              "Next": "WaitForDBAvailable"
+            },
+            {
+              "Condition": "{% $states.input.DbClusters[0].Status in $dbStatus.available %}",
+              "Next": "StopDB"
+            },
+            {
+              "Condition": "{% 1 <= $stoppedCount %}",
+              "Next": "DBNotAvailable"
+            }
+          ],
+          "Default": "IncrementStoppedCount"
+        },
+        "IncrementStoppedCount": {
+          "Type": "Pass",
+          "Assign": {
+            "stoppedCount": "{% $stoppedCount + 1 %}"
+          },
+          "Next": "WaitForDBAvailable"
+        },
+        "DBNotAvailable": {
+          "Type": "Succeed"
+        },
+        "WaitForDBAvailable": {
+          "Type": "Wait",
+          "Seconds": 120,
+          "Next": "DescribeDBStatus"
+        },
+        "StopDB": {
+          "Type": "Task",
+          "Resource": "arn:$${Partition}:states:::aws-sdk:rds:stopDBCluster",
+          "Arguments": {
+            "DbClusterIdentifier": "aurora-db-identifier"
+          },
+          "End": true
+        }
+      }
+    }
+  EOT
+}
+
+resource "aws_sfn_state_machine" "state_machine" {
+  name     = "ktnh-aurora-db-i-abcdef"
+  role_arn = aws_iam_role.state_machine_execution.arn
+
+  definition = replace(local.state_machine_definition, "$${Partition}", data.aws_partition.current.partition)
+
+  logging_configuration {
+    level                  = "ALL"
+    include_execution_data = true
+    log_destination        = "${aws_cloudwatch_log_group.state_machine.arn}:*"
+  }
+
+  depends_on = [
+    aws_iam_role_policy.state_machine_execution_rds,
+    aws_iam_role_policy.state_machine_execution_logs,
+  ]
+}
+
+resource "aws_iam_role" "events" {
+  name        = "ktnh-events-aurora-db-i-abcdef"
+  description = "Role used by EventBridge rule and scheduler to trigger the ktnh state machine"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = "sts:AssumeRole"
+        Principal = {
+          Service = [
+            "events.amazonaws.com",
+            "scheduler.amazonaws.com",
+          ]
+        }
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "events_statemachine" {
+  name = "statemachine"
+  role = aws_iam_role.events.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "states:StartExecution",
+        ]
+        Resource = aws_sfn_state_machine.state_machine.arn
+      },
+    ]
+  })
+}
+
+resource "aws_cloudwatch_event_rule" "rds_auto_start" {
+  name        = "ktnh-autostart-aurora-db-i-abcdef"
+  description = "Rule to capture Aurora cluster or RDS instance auto-start events and trigger Step Functions"
+  state       = var.protection_state
+
+  event_pattern = jsonencode({
+    source = [
+      "aws.rds",
+    ]
+    detail-type = [
+      "RDS DB Cluster Event",
+    ]
+    detail = {
+      EventID = [
+        "RDS-EVENT-0153",
+      ]
+      SourceIdentifier = [
+        "aurora-db-identifier",
+      ]
+    }
+  })
+}
+
+resource "aws_cloudwatch_event_target" "rds_auto_start" {
+  rule      = aws_cloudwatch_event_rule.rds_auto_start.name
+  target_id = "stop"
+  arn       = aws_sfn_state_machine.state_machine.arn
+  role_arn  = aws_iam_role.events.arn
+
+  retry_policy {
+    maximum_event_age_in_seconds = 86400
+    maximum_retry_attempts       = 185
+  }
+}
+
+resource "aws_scheduler_schedule" "periodic_stop" {
+  name                = "ktnh-periodicstop-aurora-db-i-abcdef"
+  description         = "Schedule to stop Aurora cluster or RDS instance periodically as a backup mechanism"
+  state               = var.protection_state
+  schedule_expression = "rate(6 hours)"
+
+  target {
+    arn      = aws_sfn_state_machine.state_machine.arn
+    role_arn = aws_iam_role.events.arn
+
+    retry_policy {
+      maximum_event_age_in_seconds = 1800
+      maximum_retry_attempts       = 3
+    }
+  }
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+}
+
+output "state_machine_arn" {
+  description = "ARN of the ktnh state machine"
+  value       = aws_sfn_state_machine.state_machine.arn
+}
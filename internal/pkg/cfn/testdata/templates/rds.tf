@@ -0,0 +1,315 @@
+# Generated by koreru-toki-no-hiho version 1
+# DBIdentifier: rds-db-identifier
+# DBType: rds
+
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.40"
+    }
+  }
+}
+
+variable "protection_state" {
+  type        = string
+  description = "State of the event rule and the schedule (DISABLED while protection is paused)"
+  default     = "ENABLED"
+
+  validation {
+    condition     = contains(["ENABLED", "DISABLED"], var.protection_state)
+    error_message = "The protection_state must be either ENABLED or DISABLED."
+  }
+}
+
+data "aws_partition" "current" {}
+
+data "aws_region" "current" {}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_iam_role" "state_machine_execution" {
+  name        = "ktnh-sfn-rds-db-ide-ghijklm"
+  description = "Execution role for the ktnh state machine"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = "sts:AssumeRole"
+        Principal = {
+          Service = "states.amazonaws.com"
+        }
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "state_machine_execution_rds" {
+  name = "rds"
+  role = aws_iam_role.state_machine_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "rds:DescribeDBInstances",
+          "rds:StopDBInstance",
+        ]
+        Resource = [
+          "arn:${data.aws_partition.current.partition}:rds:${data.aws_region.current.id}:${data.aws_caller_identity.current.account_id}:db:rds-db-identifier",
+        ]
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "state_machine_execution_logs" {
+  name = "logs"
+  role = aws_iam_role.state_machine_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "logs:CreateLogDelivery",
+          "logs:GetLogDelivery",
+          "logs:UpdateLogDelivery",
+          "logs:DeleteLogDelivery",
+          "logs:ListLogDeliveries",
+          "logs:PutLogEvents",
+          "logs:PutResourcePolicy",
+          "logs:DescribeResourcePolicies",
+          "logs:DescribeLogGroups",
+        ]
+        Resource = "*"
+      },
+    ]
+  })
+}
+
+resource "aws_cloudwatch_log_group" "state_machine" {
+  name              = "ktnh-sfn-rds-db-ide-ghijklm"
+  retention_in_days = 14
+}
+
+locals {
+  # The partition is substituted for "${Partition}" in the same way as DefinitionSubstitutions of CloudFormation.
+  state_machine_definition = <<-EOT
+    {
+      "Comment": "State machine to automatically stop RDS instance",
+      "QueryLanguage": "JSONata",
+      "TimeoutSeconds": 3600,
+      "StartAt": "Setup",
+      "States": {
+        "Setup": {
+          "Type": "Pass",
+          "Assign": {
+            "dbStatus": {
+              "wait": [
+                "backing-up",
+                "configuring-enhanced-monitoring",
+                "configuring-iam-database-auth",
+                "configuring-log-exports",
+                "converting-to-vpc",
+                "creating",
+                "maintenance",
+                "modifying",
+                "moving-to-vpc",
+                "rebooting",
+                "resetting-master-credentials",
+                "renaming",
+                "starting",
+                "storage-config-upgrade",
+                "storage-initialization",
+                "storage-optimization",
+                "upgrading"
+              ],
+              "available": [
+                "available",
+                "incompatible-option-group",
+                "incompatible-parameters",
+                "restore-error",
+                "storage-full"
+              ]
+            },
+            "stoppedCount": 0
+          },
+          "Next": "DescribeDBStatus"
+        },
+        "DescribeDBStatus": {
+          "Type": "Task",
+          "Resource": "arn:$${Partition}:states:::aws-sdk:rds:describeDBInstances",
+          "Arguments": {
+            "DbInstanceIdentifier": "rds-db-identifier"
+          },
+          "Next": "CheckDBStatus"
+        },
+        "CheckDBStatus": {
+          "Type": "Choice",
+          "Choices": [
+            {
+              "Condition": "{% $states.input.DbInstances[0].DbInstanceStatus in $dbStatus.wait %}",
+              "Next": "WaitForDBAvailable"
+            },
+            {
+              "Condition": "{% $states.input.DbInstances[0].DbInstanceStatus in $dbStatus.available %}",
+              "Next": "StopDB"
+            },
+            {
+              "Condition": "{% 1 <= $stoppedCount %}",
+              "Next": "DBNotAvailable"
+            }
+          ],
+          "Default": "IncrementStoppedCount"
+        },
+        "IncrementStoppedCount": {
+          "Type": "Pass",
+          "Assign": {
+            "stoppedCount": "{% $stoppedCount + 1 %}"
+          },
+          "Next": "WaitForDBAvailable"
+        },
+        "DBNotAvailable": {
+          "Type": "Succeed"
+        },
+        "WaitForDBAvailable": {
+          "Type": "Wait",
+          "Seconds": 120,
+          "Next": "DescribeDBStatus"
+        },
+        "StopDB": {
+          "Type": "Task",
+          "Resource": "arn:$${Partition}:states:::aws-sdk:rds:stopDBInstance",
+          "Arguments": {
+            "DbInstanceIdentifier": "rds-db-identifier"
+          },
+          "End": true
+        }
+      }
+    }
+  EOT
+}
+
+resource "aws_sfn_state_machine" "state_machine" {
+  name     = "ktnh-rds-db-ide-ghijklm"
+  role_arn = aws_iam_role.state_machine_execution.arn
+
+  definition = replace(local.state_machine_definition, "$${Partition}", data.aws_partition.current.partition)
+
+  logging_configuration {
+    level                  = "ALL"
+    include_execution_data = true
+    log_destination        = "${aws_cloudwatch_log_group.state_machine.arn}:*"
+  }
+
+  depends_on = [
+    aws_iam_role_policy.state_machine_execution_rds,
+    aws_iam_role_policy.state_machine_execution_logs,
+  ]
+}
+
+resource "aws_iam_role" "events" {
+  name        = "ktnh-events-rds-db-ide-ghijklm"
+  description = "Role used by EventBridge rule and scheduler to trigger the ktnh state machine"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = "sts:AssumeRole"
+        Principal = {
+          Service = [
+            "events.amazonaws.com",
+            "scheduler.amazonaws.com",
+          ]
+        }
+      },
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "events_statemachine" {
+  name = "statemachine"
+  role = aws_iam_role.events.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "states:StartExecution",
+        ]
+        Resource = aws_sfn_state_machine.state_machine.arn
+      },
+    ]
+  })
+}
+
+resource "aws_cloudwatch_event_rule" "rds_auto_start" {
+  name        = "ktnh-autostart-rds-db-ide-ghijklm"
+  description = "Rule to capture Aurora cluster or RDS instance auto-start events and trigger Step Functions"
+  state       = var.protection_state
+
+  event_pattern = jsonencode({
+    source = [
+      "aws.rds",
+    ]
+    detail-type = [
+      "RDS DB Instance Event",
+    ]
+    detail = {
+      EventID = [
+        "RDS-EVENT-0154",
+      ]
+      SourceIdentifier = [
+        "rds-db-identifier",
+      ]
+    }
+  })
+}
+
+resource "aws_cloudwatch_event_target" "rds_auto_start" {
+  rule      = aws_cloudwatch_event_rule.rds_auto_start.name
+  target_id = "stop"
+  arn       = aws_sfn_state_machine.state_machine.arn
+  role_arn  = aws_iam_role.events.arn
+
+  retry_policy {
+    maximum_event_age_in_seconds = 86400
+    maximum_retry_attempts       = 185
+  }
+}
+
+resource "aws_scheduler_schedule" "periodic_stop" {
+  name                = "ktnh-periodicstop-rds-db-ide-ghijklm"
+  description         = "Schedule to stop Aurora cluster or RDS instance periodically as a backup mechanism"
+  state               = var.protection_state
+  schedule_expression = "rate(6 hours)"
+
+  target {
+    arn      = aws_sfn_state_machine.state_machine.arn
+    role_arn = aws_iam_role.events.arn
+
+    retry_policy {
+      maximum_event_age_in_seconds = 1800
+      maximum_retry_attempts       = 3
+    }
+  }
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+}
+
+output "state_machine_arn" {
+  description = "ARN of the ktnh state machine"
+  value       = aws_sfn_state_machine.state_machine.arn
+}
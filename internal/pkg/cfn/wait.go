@@ -14,25 +14,27 @@ import (
 
 /*
 operation represents the type of CloudFormation stack operation being performed.
-It is used to distinguish between different stack operations such as creation and deletion.
+It is used to distinguish between different stack operations such as creation, deletion and update.
 */
 type operation string
 
 /*
 completeWaiter encapsulates the waiters for different CloudFormation stack operations.
 It includes a reference to the operation type and the appropriate waiter objects
-from the CloudFormation SDK for creation and deletion operations.
+from the CloudFormation SDK for creation, deletion and update operations.
 */
 type completeWaiter struct {
-	operation operation // type of operation ("creation" or "deletion")
+	operation operation // type of operation ("creation", "deletion" or "update")
 
 	create awsfactory.StackCreateCompleteWaiter // waiter for stack creation completion
 	delete awsfactory.StackDeleteCompleteWaiter // waiter for stack deletion completion
+	update awsfactory.StackUpdateCompleteWaiter // waiter for stack update completion
 }
 
 const (
 	operationCreate = operation("creation") // stack creation operation
 	operationDelete = operation("deletion") // stack deletion operation
+	operationUpdate = operation("update")   // stack update operation
 )
 
 /*
@@ -72,7 +74,25 @@ func (c *CloudFormation) WaitForStackDeletion(stackName string, timeout time.Dur
 }
 
 /*
-waitForStackOperation waits for a CloudFormation stack operation (create or delete) to complete.
+WaitForStackUpdate waits for a CloudFormation stack update to complete.
+*/
+func (c *CloudFormation) WaitForStackUpdate(stackName string, timeout time.Duration) error {
+	updateWaiter, err := c.factory.NewStackUpdateCompleteWaiter()
+
+	if err != nil {
+		return fmt.Errorf("failed to create StackUpdateComplete waiter: %w", err)
+	}
+
+	waiter := completeWaiter{
+		operation: operationUpdate,
+		update:    updateWaiter,
+	}
+
+	return c.waitForStackOperation(stackName, timeout, waiter)
+}
+
+/*
+waitForStackOperation waits for a CloudFormation stack operation (create, delete or update) to complete.
 */
 func (c *CloudFormation) waitForStackOperation(stackName string, timeout time.Duration, waiter completeWaiter) error {
 	slog.Debug("Waiting for stack operation to complete",
@@ -129,6 +149,13 @@ func (c *CloudFormation) waitForStackOperation(stackName string, timeout time.Du
 		}
 
 		err = waiter.delete.Wait(ctx, input, timeout, optFunc)
+	case operationUpdate:
+		optFunc := func(opt *cloudformation.StackUpdateCompleteWaiterOptions) {
+			opt.MinDelay = 10 * time.Second
+			opt.MaxDelay = 15 * time.Second
+		}
+
+		err = waiter.update.Wait(ctx, input, timeout, optFunc)
 	default:
 		return fmt.Errorf("unknown operation '%s'", waiter.operation)
 	}
@@ -165,3 +165,80 @@ func Test_WaitForStackDeletion(t *testing.T) {
 		})
 	}
 }
+
+func Test_WaitForStackUpdate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		stackName string
+		timeout   time.Duration
+		mockSetup func(*appmock.MockCloudFormationFactory, *appmock.MockStackUpdateCompleteWaiter)
+		wantErr   bool
+	}{
+		{
+			name:      "success",
+			stackName: "success-stack",
+			timeout:   time.Minute * 5,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, w *appmock.MockStackUpdateCompleteWaiter) {
+				f.On("NewStackUpdateCompleteWaiter").
+					Return(w, nil)
+
+				params := &cloudformation.DescribeStacksInput{
+					StackName: aws.String("success-stack"),
+				}
+
+				w.On("Wait", mock.Anything, params, time.Minute*5, mock.Anything).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Timeout",
+			stackName: "timeout-stack",
+			timeout:   time.Second * 30,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, w *appmock.MockStackUpdateCompleteWaiter) {
+				f.On("NewStackUpdateCompleteWaiter").
+					Return(w, nil)
+
+				params := &cloudformation.DescribeStacksInput{
+					StackName: aws.String("timeout-stack"),
+				}
+
+				w.On("Wait", mock.Anything, params, time.Second*30, mock.Anything).
+					Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "Factory error",
+			stackName: "factory-error-stack",
+			timeout:   time.Minute * 5,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, w *appmock.MockStackUpdateCompleteWaiter) {
+				f.On("NewStackUpdateCompleteWaiter").
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudFormationFactory)
+			mockWaiter := new(appmock.MockStackUpdateCompleteWaiter)
+
+			tc.mockSetup(mockFactory, mockWaiter)
+
+			c := NewCloudFormation(mockFactory)
+
+			err := c.WaitForStackUpdate(tc.stackName, tc.timeout)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockWaiter.AssertExpectations(t)
+		})
+	}
+}
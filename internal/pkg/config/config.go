@@ -0,0 +1,346 @@
+/*
+Package config loads the settings of ktnh from configuration files and environment variables.
+
+Settings are layered in the following order, where later layers take precedence:
+
+ 1. the user configuration file (`~/.config/ktnh/config.yaml`)
+ 2. the project configuration file (`./.ktnh.yaml`)
+ 3. the `KTNH_*` environment variables
+ 4. the command-line flags (applied by the caller)
+
+Each configuration file can define named profiles, whose values take precedence over
+the top-level values of the same file.
+*/
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+/*
+envPrefix is the prefix of the environment variables that hold settings.
+*/
+const envPrefix = "KTNH_"
+
+/*
+ProfileKey is the name of the setting that selects a profile.
+*/
+const ProfileKey = "config-profile"
+
+const (
+	SourceDefault = "default" // the default value of the flag
+	SourceFlag    = "flag"    // the command-line flag
+)
+
+/*
+Value holds a setting value and where it came from.
+*/
+type Value struct {
+	Value  string // setting value in the same format as the command-line flag
+	Source string // where the value came from (e.g., "env KTNH_PREFIX")
+}
+
+/*
+Config holds the settings loaded from the configuration files and the environment variables.
+*/
+type Config struct {
+	Profile Value            // selected profile (empty value if no profile is selected)
+	Values  map[string]Value // setting values keyed by setting name
+}
+
+/*
+file holds the settings of a configuration file, whose values are converted into the format of the command-line flags.
+*/
+type file struct {
+	Profile  string                       // profile selected by default
+	Settings map[string]string            // top-level setting values
+	Profiles map[string]map[string]string // setting values keyed by profile name
+}
+
+/*
+fileContent represents the structure of a configuration file.
+The setting values are kept as YAML nodes, since they can be written as a mapping or a sequence
+as well as a scalar (see `flagValue`).
+*/
+type fileContent struct {
+	Profile  string                          `yaml:"config-profile"` // profile selected by default
+	Settings map[string]yaml.Node            `yaml:",inline"`        // top-level setting values
+	Profiles map[string]map[string]yaml.Node `yaml:"profiles"`       // setting values keyed by profile name
+}
+
+/*
+Loader loads settings from the configuration files and the environment variables.
+*/
+type Loader struct {
+	Keys      []string                        // names of the settings that can be configured
+	Files     []string                        // paths of the configuration files, in ascending order of precedence
+	LookupEnv func(key string) (string, bool) // function to look up environment variables
+}
+
+/*
+NewLoader creates a Loader for the given setting names, reading the default configuration files
+and the environment variables of the process.
+*/
+func NewLoader(keys []string) *Loader {
+	return &Loader{
+		Keys:      keys,
+		Files:     DefaultFiles(),
+		LookupEnv: os.LookupEnv,
+	}
+}
+
+/*
+DefaultFiles returns the paths of the user and the project configuration files.
+The user configuration file is placed under `$XDG_CONFIG_HOME` if set, and `~/.config` otherwise.
+*/
+func DefaultFiles() []string {
+	var files []string
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+
+	if configHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+
+	if configHome != "" {
+		files = append(files, filepath.Join(configHome, "ktnh", "config.yaml"))
+	}
+
+	return append(files, ".ktnh.yaml")
+}
+
+/*
+EnvName returns the name of the environment variable for the setting (e.g., "wait-timeout" -> "KTNH_WAIT_TIMEOUT").
+*/
+func EnvName(key string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+/*
+Load loads the settings and returns them together with their sources.
+The profile is selected by the argument (i.e. the command-line flag), `KTNH_CONFIG_PROFILE`,
+or the configuration files, in this order of precedence.
+Missing configuration files are ignored, but unknown settings and undefined profiles are rejected.
+*/
+func (l *Loader) Load(profile string) (*Config, error) {
+	files := make([]*file, len(l.Files))
+
+	for i, path := range l.Files {
+		f, err := l.parseFile(path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		files[i] = f
+	}
+
+	cfg := &Config{
+		Values: map[string]Value{},
+	}
+
+	cfg.Profile = l.selectProfile(profile, files)
+
+	if (cfg.Profile.Value != "") && !hasProfile(files, cfg.Profile.Value) {
+		return nil, fmt.Errorf("profile '%s' (from %s) is not defined in any configuration file", cfg.Profile.Value, cfg.Profile.Source)
+	}
+
+	for i, f := range files {
+		if f == nil {
+			continue
+		}
+
+		for key, value := range f.Settings {
+			cfg.Values[key] = Value{Value: value, Source: l.Files[i]}
+		}
+
+		for key, value := range f.Profiles[cfg.Profile.Value] {
+			cfg.Values[key] = Value{
+				Value:  value,
+				Source: fmt.Sprintf("%s (profile %s)", l.Files[i], cfg.Profile.Value),
+			}
+		}
+	}
+
+	for _, key := range l.Keys {
+		name := EnvName(key)
+
+		if value, ok := l.LookupEnv(name); ok {
+			cfg.Values[key] = Value{Value: value, Source: "env " + name}
+		}
+	}
+
+	slog.Debug("Loaded configuration",
+		"profile", cfg.Profile.Value,
+		"settings", slices.Sorted(maps.Keys(cfg.Values)),
+	)
+
+	return cfg, nil
+}
+
+/*
+parseFile reads and parses a configuration file. Returns nil if the file does not exist.
+*/
+func (l *Loader) parseFile(path string) (*file, error) {
+	data, err := os.ReadFile(path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		slog.Debug("Configuration file not found", "path", path)
+
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file '%s': %w", path, err)
+	}
+
+	content := &fileContent{}
+
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(content); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse configuration file '%s': %w", path, err)
+	}
+
+	f := &file{
+		Profile:  content.Profile,
+		Profiles: map[string]map[string]string{},
+	}
+
+	if f.Settings, err = l.parseSettings(content.Settings); err != nil {
+		return nil, fmt.Errorf("invalid configuration file '%s': %w", path, err)
+	}
+
+	for name, settings := range content.Profiles {
+		if _, ok := settings[ProfileKey]; ok {
+			return nil, fmt.Errorf("invalid configuration file '%s': profile '%s' cannot select another profile", path, name)
+		}
+
+		if f.Profiles[name], err = l.parseSettings(settings); err != nil {
+			return nil, fmt.Errorf("invalid configuration file '%s': profile '%s': %w", path, name, err)
+		}
+	}
+
+	slog.Debug("Parsed configuration file", "path", path)
+
+	return f, nil
+}
+
+/*
+parseSettings validates whether all the settings are known, and converts their values into the format of the command-line flags.
+*/
+func (l *Loader) parseSettings(settings map[string]yaml.Node) (map[string]string, error) {
+	values := make(map[string]string, len(settings))
+
+	for _, key := range slices.Sorted(maps.Keys(settings)) {
+		if !slices.Contains(l.Keys, key) {
+			return nil, fmt.Errorf("unknown setting '%s'", key)
+		}
+
+		node := settings[key]
+
+		value, err := flagValue(&node)
+
+		if err != nil {
+			return nil, fmt.Errorf("setting '%s': %w", key, err)
+		}
+
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+/*
+flagValue converts a setting value into the format of the command-line flag.
+A mapping is converted into comma-separated `key=value` pairs (e.g., `{env: dev}` -> "env=dev"),
+and a sequence into comma-separated values, both in the order written.
+*/
+func flagValue(node *yaml.Node) (string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value, nil
+	case yaml.SequenceNode:
+		values := make([]string, len(node.Content))
+
+		for i, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return "", fmt.Errorf("items of a sequence must be scalars (line %d)", item.Line)
+			}
+
+			values[i] = item.Value
+		}
+
+		return strings.Join(values, ","), nil
+	case yaml.MappingNode:
+		pairs := make([]string, 0, len(node.Content)/2)
+
+		for i := 0; i < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			if (key.Kind != yaml.ScalarNode) || (value.Kind != yaml.ScalarNode) {
+				return "", fmt.Errorf("keys and values of a mapping must be scalars (line %d)", key.Line)
+			}
+
+			pairs = append(pairs, key.Value+"="+value.Value)
+		}
+
+		return strings.Join(pairs, ","), nil
+	case yaml.AliasNode:
+		return flagValue(node.Alias)
+	default:
+		return "", fmt.Errorf("unsupported value (line %d)", node.Line)
+	}
+}
+
+/*
+selectProfile selects the profile from the argument, the environment variable or the configuration files.
+*/
+func (l *Loader) selectProfile(profile string, files []*file) Value {
+	if profile != "" {
+		return Value{Value: profile, Source: SourceFlag}
+	}
+
+	name := EnvName(ProfileKey)
+
+	if value, ok := l.LookupEnv(name); ok && (value != "") {
+		return Value{Value: value, Source: "env " + name}
+	}
+
+	for i := len(files) - 1; 0 <= i; i-- {
+		if (files[i] != nil) && (files[i].Profile != "") {
+			return Value{Value: files[i].Profile, Source: l.Files[i]}
+		}
+	}
+
+	return Value{Source: SourceDefault}
+}
+
+/*
+hasProfile determines whether any of the configuration files defines the profile.
+*/
+func hasProfile(files []*file, profile string) bool {
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+
+		if _, ok := f.Profiles[profile]; ok {
+			return true
+		}
+	}
+
+	return false
+}
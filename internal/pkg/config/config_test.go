@@ -0,0 +1,183 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EnvName(t *testing.T) {
+	assert.Equal(t, "KTNH_WAIT_TIMEOUT", EnvName("wait-timeout"), "Environment variable name does not match expected value")
+	assert.Equal(t, "KTNH_PREFIX", EnvName("prefix"), "Environment variable name does not match expected value")
+}
+
+func Test_Load(t *testing.T) {
+	userConfig := `prefix: user
+wait-timeout: 10m
+config-profile: dev
+profiles:
+  dev:
+    prefix: userdev
+  prod:
+    region: us-east-1
+`
+
+	projectConfig := `no-wait: true
+profiles:
+  dev:
+    region: ap-northeast-1
+`
+
+	testCases := []struct {
+		name            string
+		userConfig      string
+		projectConfig   string
+		env             map[string]string
+		profile         string
+		expectedProfile Value
+		expectedValues  map[string]Value
+		wantErr         bool
+	}{
+		{
+			name:            "No files",
+			expectedProfile: Value{Source: "default"},
+			expectedValues:  map[string]Value{},
+			wantErr:         false,
+		},
+		{
+			name:            "Layered files with default profile",
+			userConfig:      userConfig,
+			projectConfig:   projectConfig,
+			expectedProfile: Value{Value: "dev", Source: "user.yaml"},
+			expectedValues: map[string]Value{
+				"prefix":       {Value: "userdev", Source: "user.yaml (profile dev)"},
+				"wait-timeout": {Value: "10m", Source: "user.yaml"},
+				"no-wait":      {Value: "true", Source: "project.yaml"},
+				"region":       {Value: "ap-northeast-1", Source: "project.yaml (profile dev)"},
+			},
+			wantErr: false,
+		},
+		{
+			name:            "Profile from flag and environment variables",
+			userConfig:      userConfig,
+			projectConfig:   projectConfig,
+			env:             map[string]string{"KTNH_CONFIG_PROFILE": "dev", "KTNH_PREFIX": "env"},
+			profile:         "prod",
+			expectedProfile: Value{Value: "prod", Source: "flag"},
+			expectedValues: map[string]Value{
+				"prefix":       {Value: "env", Source: "env KTNH_PREFIX"},
+				"wait-timeout": {Value: "10m", Source: "user.yaml"},
+				"no-wait":      {Value: "true", Source: "project.yaml"},
+				"region":       {Value: "us-east-1", Source: "user.yaml (profile prod)"},
+			},
+			wantErr: false,
+		},
+		{
+			name:            "Profile from environment variable",
+			userConfig:      userConfig,
+			env:             map[string]string{"KTNH_CONFIG_PROFILE": "prod"},
+			expectedProfile: Value{Value: "prod", Source: "env KTNH_CONFIG_PROFILE"},
+			expectedValues: map[string]Value{
+				"prefix":       {Value: "user", Source: "user.yaml"},
+				"wait-timeout": {Value: "10m", Source: "user.yaml"},
+				"region":       {Value: "us-east-1", Source: "user.yaml (profile prod)"},
+			},
+			wantErr: false,
+		},
+		{
+			name:            "Mapping and sequence values",
+			userConfig:      "stack-tag: {env: dev, team: db}\ntemplate-patch:\n  - a.yaml\n  - b.json\nprofiles:\n  dev:\n    stack-tag:\n      env: dev\n",
+			profile:         "dev",
+			expectedProfile: Value{Value: "dev", Source: "flag"},
+			expectedValues: map[string]Value{
+				"stack-tag":      {Value: "env=dev", Source: "user.yaml (profile dev)"},
+				"template-patch": {Value: "a.yaml,b.json", Source: "user.yaml"},
+			},
+			wantErr: false,
+		},
+		{
+			name:            "Mapping value without profile",
+			userConfig:      "stack-tag:\n  env: dev\n  team: db\n",
+			expectedProfile: Value{Source: "default"},
+			expectedValues: map[string]Value{
+				"stack-tag": {Value: "env=dev,team=db", Source: "user.yaml"},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Nested mapping value",
+			userConfig: "stack-tag:\n  env:\n    name: dev\n",
+			wantErr:    true,
+		},
+		{
+			name:       "Nested sequence value",
+			userConfig: "template-patch:\n  - [a.yaml]\n",
+			wantErr:    true,
+		},
+		{
+			name:       "Undefined profile",
+			userConfig: userConfig,
+			profile:    "staging",
+			wantErr:    true,
+		},
+		{
+			name:       "Unknown setting",
+			userConfig: "tags: foo\n",
+			wantErr:    true,
+		},
+		{
+			name:       "Unknown setting in profile",
+			userConfig: "profiles:\n  dev:\n    tags: foo\n",
+			wantErr:    true,
+		},
+		{
+			name:       "Profile selecting another profile",
+			userConfig: "profiles:\n  dev:\n    config-profile: prod\n",
+			wantErr:    true,
+		},
+		{
+			name:       "Invalid YAML",
+			userConfig: "prefix: [\n",
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			t.Chdir(dir)
+
+			if tc.userConfig != "" {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "user.yaml"), []byte(tc.userConfig), 0o644))
+			}
+
+			if tc.projectConfig != "" {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "project.yaml"), []byte(tc.projectConfig), 0o644))
+			}
+
+			loader := &Loader{
+				Keys:  []string{"no-wait", "prefix", "region", "stack-tag", "template-patch", "wait-timeout"},
+				Files: []string{"user.yaml", "project.yaml"},
+				LookupEnv: func(key string) (string, bool) {
+					value, ok := tc.env[key]
+
+					return value, ok
+				},
+			}
+
+			got, err := loader.Load(tc.profile)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expectedProfile, got.Profile, "Profile does not match expected value")
+				assert.Equal(t, tc.expectedValues, got.Values, "Values do not match expected values")
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+)
+
+/*
+Report holds the effective settings and where each of them came from.
+*/
+type Report struct {
+	Settings []Setting `json:"settings"` // effective settings, in the order of the setting name
+}
+
+/*
+Setting holds an effective setting value and its source.
+*/
+type Setting struct {
+	Key    string `json:"key"`    // setting name, which is the same as the command-line flag
+	Value  string `json:"value"`  // effective value
+	Source string `json:"source"` // where the value came from ("default", "flag", a file or an environment variable)
+}
+
+/*
+Tables converts the report into a table for display.
+*/
+func (r *Report) Tables() []output.Table {
+	table := output.Table{
+		Headers: []string{"key", "value", "source"},
+	}
+
+	for _, setting := range r.Settings {
+		var value any
+
+		if setting.Value != "" {
+			value = setting.Value
+		}
+
+		table.Rows = append(table.Rows, []any{setting.Key, value, setting.Source})
+	}
+
+	return []output.Table{table}
+}
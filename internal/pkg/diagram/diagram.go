@@ -0,0 +1,393 @@
+/*
+Package diagram renders Step Functions state machine definitions as state diagrams.
+
+The definition is written in Amazon States Language (ASL). States are drawn in the order
+they are defined, with choices, their JSONata conditions, waits and terminal states.
+States of Parallel and Map are drawn as single states without their branches.
+*/
+package diagram
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatMermaid = "mermaid" // Mermaid state diagram (`stateDiagram-v2`)
+	FormatDOT     = "dot"     // Graphviz DOT
+)
+
+/*
+Formats returns the names of the available diagram formats.
+*/
+func Formats() []string {
+	return []string{FormatDOT, FormatMermaid}
+}
+
+/*
+identifierPattern matches state names that can be used as node identifiers as is.
+*/
+var identifierPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+/*
+integrationPrefixPattern matches the prefix of the service integration ARNs in any partition
+(e.g., "arn:aws:states:::aws-sdk:", "arn:${Partition}:states:::").
+*/
+var integrationPrefixPattern = regexp.MustCompile(`^arn:[^:]+:states:::(aws-sdk:)?`)
+
+/*
+state holds the attributes of a state that are drawn in the diagram.
+*/
+type state struct {
+	Name     string         `yaml:"-"`        // state name
+	Type     string         `yaml:"Type"`     // state type (e.g., "Task")
+	Next     string         `yaml:"Next"`     // next state
+	End      bool           `yaml:"End"`      // whether the state ends the execution
+	Default  string         `yaml:"Default"`  // default state of Choice
+	Choices  []choice       `yaml:"Choices"`  // rules of Choice
+	Catch    []catcher      `yaml:"Catch"`    // error handlers
+	Seconds  any            `yaml:"Seconds"`  // seconds to wait (number or JSONata)
+	Resource string         `yaml:"Resource"` // resource invoked by Task
+	Assign   map[string]any `yaml:"Assign"`   // variables assigned by the state
+	Branches []yaml.Node    `yaml:"Branches"` // branches of Parallel
+}
+
+/*
+choice holds a rule of a Choice state.
+*/
+type choice struct {
+	Condition string `yaml:"Condition"` // JSONata condition
+	Variable  string `yaml:"Variable"`  // variable compared by the rule (JSONPath)
+	Next      string `yaml:"Next"`      // state to transition to
+}
+
+/*
+catcher holds an error handler of a state.
+*/
+type catcher struct {
+	ErrorEquals []string `yaml:"ErrorEquals"` // errors handled
+	Next        string   `yaml:"Next"`        // state to transition to
+}
+
+/*
+transition holds a transition between states. An empty target means the end of the execution.
+*/
+type transition struct {
+	from  string // source state
+	to    string // target state
+	label string // condition of the transition
+}
+
+/*
+machine holds the states and the transitions of a state machine.
+*/
+type machine struct {
+	startAt     string
+	states      []*state
+	transitions []transition
+}
+
+/*
+Render renders the state machine definition as a state diagram in the given format.
+*/
+func Render(definition string, format string) (string, error) {
+	m, err := parse(definition)
+
+	if err != nil {
+		return "", err
+	}
+
+	slog.Debug("Rendering state diagram", "format", format, "states", len(m.states))
+
+	switch format {
+	case FormatMermaid:
+		return m.mermaid(), nil
+	case FormatDOT:
+		return m.dot(), nil
+	default:
+		return "", fmt.Errorf("unknown diagram format '%s' (must be one of %s)", format, strings.Join(Formats(), ", "))
+	}
+}
+
+/*
+parse parses the state machine definition, keeping the order of the states.
+*/
+func parse(definition string) (*machine, error) {
+	var document struct {
+		StartAt string    `yaml:"StartAt"`
+		States  yaml.Node `yaml:"States"`
+	}
+
+	// NOTE: JSON is parsed as YAML, which keeps the order of the keys.
+	if err := yaml.Unmarshal([]byte(definition), &document); err != nil {
+		return nil, fmt.Errorf("failed to parse state machine definition: %w", err)
+	}
+
+	if document.States.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("state machine definition has no States")
+	}
+
+	m := &machine{
+		startAt: document.StartAt,
+	}
+
+	for i := 0; i < len(document.States.Content); i += 2 {
+		s := &state{
+			Name: document.States.Content[i].Value,
+		}
+
+		if err := document.States.Content[i+1].Decode(s); err != nil {
+			return nil, fmt.Errorf("failed to parse state '%s': %w", s.Name, err)
+		}
+
+		m.states = append(m.states, s)
+		m.transitions = append(m.transitions, s.transitions()...)
+	}
+
+	return m, nil
+}
+
+/*
+transitions returns the transitions from the state.
+*/
+func (s *state) transitions() []transition {
+	var transitions []transition
+
+	for _, c := range s.Choices {
+		transitions = append(transitions, transition{from: s.Name, to: c.Next, label: c.label()})
+	}
+
+	if s.Default != "" {
+		transitions = append(transitions, transition{from: s.Name, to: s.Default, label: "default"})
+	}
+
+	if s.Next != "" {
+		transitions = append(transitions, transition{from: s.Name, to: s.Next})
+	}
+
+	for _, c := range s.Catch {
+		transitions = append(transitions, transition{from: s.Name, to: c.Next, label: "catch " + strings.Join(c.ErrorEquals, ", ")})
+	}
+
+	if s.End || (s.Type == "Succeed") || (s.Type == "Fail") {
+		transitions = append(transitions, transition{from: s.Name})
+	}
+
+	return transitions
+}
+
+/*
+label returns the condition of the rule, without the JSONata delimiters.
+*/
+func (c *choice) label() string {
+	if c.Condition != "" {
+		return stripJSONata(c.Condition)
+	}
+
+	return c.Variable
+}
+
+/*
+description returns what the state does, or an empty string if there is nothing to describe.
+*/
+func (s *state) description() string {
+	switch s.Type {
+	case "Task":
+		return integrationPrefixPattern.ReplaceAllString(s.Resource, "")
+	case "Wait":
+		if s.Seconds == nil {
+			return "Wait"
+		}
+
+		if seconds, ok := s.Seconds.(string); ok {
+			return fmt.Sprintf("Wait %s seconds", stripJSONata(seconds))
+		}
+
+		return fmt.Sprintf("Wait %v seconds", s.Seconds)
+	case "Pass":
+		return assignDescription(s.Assign)
+	case "Parallel":
+		return fmt.Sprintf("Parallel (%d branches)", len(s.Branches))
+	case "Succeed", "Fail", "Map":
+		return s.Type
+	default:
+		return ""
+	}
+}
+
+/*
+assignDescription describes the variables assigned by a state.
+Values given by JSONata are shown as expressions, and the others by the names only.
+*/
+func assignDescription(assign map[string]any) string {
+	if len(assign) == 0 {
+		return ""
+	}
+
+	var items []string
+
+	for _, name := range slices.Sorted(maps.Keys(assign)) {
+		if value, ok := assign[name].(string); ok && isJSONata(value) {
+			items = append(items, fmt.Sprintf("%s = %s", name, stripJSONata(value)))
+		} else {
+			items = append(items, name)
+		}
+	}
+
+	return "Assign " + strings.Join(items, ", ")
+}
+
+/*
+isJSONata determines whether the value is a JSONata expression (e.g., "{% $a + 1 %}").
+*/
+func isJSONata(value string) bool {
+	return strings.HasPrefix(value, "{%") && strings.HasSuffix(value, "%}")
+}
+
+/*
+stripJSONata removes the JSONata delimiters from the expression.
+*/
+func stripJSONata(value string) string {
+	if !isJSONata(value) {
+		return value
+	}
+
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(value, "{%"), "%}"))
+}
+
+/*
+ids returns the node identifiers keyed by state name.
+States whose names cannot be used as identifiers are numbered instead.
+*/
+func (m *machine) ids() map[string]string {
+	ids := map[string]string{}
+
+	for i, s := range m.states {
+		if identifierPattern.MatchString(s.Name) {
+			ids[s.Name] = s.Name
+		} else {
+			ids[s.Name] = fmt.Sprintf("state%d", i+1)
+		}
+	}
+
+	return ids
+}
+
+/*
+mermaid renders the state machine as a Mermaid state diagram.
+*/
+func (m *machine) mermaid() string {
+	ids := m.ids()
+
+	id := func(name string) string {
+		if name == "" {
+			return "[*]"
+		}
+
+		if value, ok := ids[name]; ok {
+			return value
+		}
+
+		return name
+	}
+
+	lines := []string{
+		"stateDiagram-v2",
+		"  [*] --> " + id(m.startAt),
+	}
+
+	for _, s := range m.states {
+		if s.Type == "Choice" {
+			lines = append(lines, fmt.Sprintf("  state %s <<choice>>", id(s.Name)))
+
+			continue
+		}
+
+		if id(s.Name) != s.Name {
+			lines = append(lines, fmt.Sprintf("  %s: %s", id(s.Name), mermaidText(s.Name)))
+		}
+
+		if description := s.description(); description != "" {
+			lines = append(lines, fmt.Sprintf("  %s: %s", id(s.Name), mermaidText(description)))
+		}
+	}
+
+	for _, t := range m.transitions {
+		line := fmt.Sprintf("  %s --> %s", id(t.from), id(t.to))
+
+		if t.label != "" {
+			line += ": " + mermaidText(t.label)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+/*
+mermaidText makes the text safe to be written in a single line of a Mermaid diagram.
+*/
+func mermaidText(text string) string {
+	return strings.NewReplacer("\n", " ", ";", ",").Replace(text)
+}
+
+/*
+dot renders the state machine as a Graphviz DOT graph.
+*/
+func (m *machine) dot() string {
+	id := func(name string) string {
+		if name == "" {
+			return dotString("__end")
+		}
+
+		return dotString(name)
+	}
+
+	lines := []string{
+		"digraph StateMachine {",
+		`  node [shape=box, style=rounded];`,
+		`  "__start" [shape=circle, label="", width=0.2, style=filled, fillcolor=black];`,
+		`  "__end" [shape=doublecircle, label="", width=0.2, style=filled, fillcolor=black];`,
+		fmt.Sprintf("  %s -> %s;", dotString("__start"), id(m.startAt)),
+	}
+
+	for _, s := range m.states {
+		attributes := []string{"label=" + dotString(s.Name)}
+
+		if s.Type == "Choice" {
+			attributes = []string{"label=" + dotString(s.Name), "shape=diamond", "style=solid"}
+		} else if description := s.description(); description != "" {
+			attributes = []string{"label=" + dotString(s.Name+"\n"+description)}
+		}
+
+		lines = append(lines, fmt.Sprintf("  %s [%s];", id(s.Name), strings.Join(attributes, ", ")))
+	}
+
+	for _, t := range m.transitions {
+		line := fmt.Sprintf("  %s -> %s", id(t.from), id(t.to))
+
+		if t.label != "" {
+			line += fmt.Sprintf(" [label=%s]", dotString(t.label))
+		}
+
+		lines = append(lines, line+";")
+	}
+
+	lines = append(lines, "}")
+
+	return strings.Join(lines, "\n")
+}
+
+/*
+dotString quotes the text as a DOT string.
+*/
+func dotString(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text) + `"`
+}
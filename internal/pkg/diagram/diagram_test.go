@@ -0,0 +1,132 @@
+package diagram
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDefinition = `{
+  "QueryLanguage": "JSONata",
+  "StartAt": "Setup",
+  "States": {
+    "Setup": {
+      "Type": "Pass",
+      "Assign": {"count": 0, "next": "{% $count + 1 %}"},
+      "Next": "Check status"
+    },
+    "Check status": {
+      "Type": "Choice",
+      "Choices": [
+        {"Condition": "{% $states.input.Status = 'available' %}", "Next": "Stop"}
+      ],
+      "Default": "Wait"
+    },
+    "Wait": {
+      "Type": "Wait",
+      "Seconds": 60,
+      "Next": "Check status"
+    },
+    "Stop": {
+      "Type": "Task",
+      "Resource": "arn:${Partition}:states:::aws-sdk:rds:stopDBInstance",
+      "Catch": [{"ErrorEquals": ["States.ALL"], "Next": "Failed"}],
+      "End": true
+    },
+    "Failed": {
+      "Type": "Fail"
+    }
+  }
+}`
+
+func Test_Render(t *testing.T) {
+	testCases := []struct {
+		name       string
+		definition string
+		format     string
+		expected   []string
+		wantErr    bool
+	}{
+		{
+			name:       "Mermaid",
+			definition: testDefinition,
+			format:     FormatMermaid,
+			expected: []string{
+				"stateDiagram-v2",
+				"  [*] --> Setup",
+				"  Setup: Assign count, next = $count + 1",
+				"  state state2 <<choice>>",
+				"  Wait: Wait 60 seconds",
+				"  Stop: rds:stopDBInstance",
+				"  Failed: Fail",
+				"  Setup --> state2",
+				"  state2 --> Stop: $states.input.Status = 'available'",
+				"  state2 --> Wait: default",
+				"  Wait --> state2",
+				"  Stop --> Failed: catch States.ALL",
+				"  Stop --> [*]",
+				"  Failed --> [*]",
+			},
+			wantErr: false,
+		},
+		{
+			name:       "DOT",
+			definition: testDefinition,
+			format:     FormatDOT,
+			expected: []string{
+				"digraph StateMachine {",
+				`  node [shape=box, style=rounded];`,
+				`  "__start" [shape=circle, label="", width=0.2, style=filled, fillcolor=black];`,
+				`  "__end" [shape=doublecircle, label="", width=0.2, style=filled, fillcolor=black];`,
+				`  "__start" -> "Setup";`,
+				`  "Setup" [label="Setup\nAssign count, next = $count + 1"];`,
+				`  "Check status" [label="Check status", shape=diamond, style=solid];`,
+				`  "Wait" [label="Wait\nWait 60 seconds"];`,
+				`  "Stop" [label="Stop\nrds:stopDBInstance"];`,
+				`  "Failed" [label="Failed\nFail"];`,
+				`  "Setup" -> "Check status";`,
+				`  "Check status" -> "Stop" [label="$states.input.Status = 'available'"];`,
+				`  "Check status" -> "Wait" [label="default"];`,
+				`  "Wait" -> "Check status";`,
+				`  "Stop" -> "Failed" [label="catch States.ALL"];`,
+				`  "Stop" -> "__end";`,
+				`  "Failed" -> "__end";`,
+				"}",
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Unknown format",
+			definition: testDefinition,
+			format:     "svg",
+			wantErr:    true,
+		},
+		{
+			name:       "Invalid definition",
+			definition: `{"StartAt": "Setup"`,
+			format:     FormatMermaid,
+			wantErr:    true,
+		},
+		{
+			name:       "No States",
+			definition: `{"StartAt": "Setup"}`,
+			format:     FormatMermaid,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Render(tc.definition, tc.format)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, strings.Join(tc.expected, "\n"), got, "Diagram does not match expected output")
+			}
+		})
+	}
+}
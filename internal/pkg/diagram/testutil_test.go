@@ -0,0 +1,15 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/testhelper"
+)
+
+func TestMain(m *testing.M) {
+	restoreLogger := testhelper.DisableLogging()
+
+	defer restoreLogger()
+
+	m.Run()
+}
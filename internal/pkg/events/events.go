@@ -0,0 +1,57 @@
+/*
+Package events provides functionality for interacting with Amazon EventBridge.
+
+It offers utilities to inspect the EventBridge rule that ktnh deploys to catch
+auto-start events of Aurora clusters and RDS instances.
+*/
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/awsfactory"
+)
+
+/*
+EventBridge handles interactions with the Amazon EventBridge service.
+*/
+type EventBridge struct {
+	factory awsfactory.EventBridgeFactory // Interface instead of concrete client
+}
+
+/*
+NewEventBridge creates and returns a new instance of EventBridge.
+*/
+func NewEventBridge(factory awsfactory.EventBridgeFactory) *EventBridge {
+	return &EventBridge{
+		factory: factory,
+	}
+}
+
+/*
+GetRuleState returns the state ("ENABLED" or "DISABLED") of an EventBridge rule.
+*/
+func (e *EventBridge) GetRuleState(ruleName string) (string, error) {
+	slog.Debug("Retrieving EventBridge rule state", "ruleName", ruleName)
+
+	ctx := context.Background()
+
+	output, err := e.factory.GetClient().DescribeRule(ctx, &eventbridge.DescribeRuleInput{
+		Name: aws.String(ruleName),
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failed to execute DescribeRule API for rule '%s': %w", ruleName, err)
+	}
+
+	state := string(output.State)
+
+	slog.Debug("EventBridge rule state retrieved", "state", state)
+
+	return state, nil
+}
@@ -0,0 +1,82 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_GetRuleState(t *testing.T) {
+	testCases := []struct {
+		name      string
+		ruleName  string
+		mockSetup func(*appmock.MockEventBridgeFactory, *appmock.MockEventBridgeClient)
+		expected  string
+		wantErr   bool
+	}{
+		{
+			name:     "Enabled",
+			ruleName: "rule-1",
+			mockSetup: func(f *appmock.MockEventBridgeFactory, c *appmock.MockEventBridgeClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &eventbridge.DescribeRuleInput{
+					Name: aws.String("rule-1"),
+				}
+
+				result := &eventbridge.DescribeRuleOutput{
+					State: types.RuleStateEnabled,
+				}
+
+				c.On("DescribeRule", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			expected: "ENABLED",
+			wantErr:  false,
+		},
+		{
+			name:     "API error",
+			ruleName: "rule-2",
+			mockSetup: func(f *appmock.MockEventBridgeFactory, c *appmock.MockEventBridgeClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeRule", mock.Anything, mock.Anything, mock.Anything).
+					Return(&eventbridge.DescribeRuleOutput{}, assert.AnError)
+			},
+			expected: "",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockEventBridgeFactory)
+			mockClient := new(appmock.MockEventBridgeClient)
+
+			tc.mockSetup(mockFactory, mockClient)
+
+			e := NewEventBridge(mockFactory)
+
+			got, err := e.GetRuleState(tc.ruleName)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Rule state does not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
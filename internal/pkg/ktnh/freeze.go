@@ -4,48 +4,127 @@ import (
 	"fmt"
 	"log/slog"
 	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
 )
 
 /*
 Freeze creates a CloudFormation stack to keep the Aurora cluster or RDS instance stopped.
 */
 func (k *ktnh) Freeze(templateBody string, qualifier string, timeout time.Duration) error {
-	existingStackName, found, err := k.findMatchingStack()
+	newStackName, err := k.startFreeze(templateBody, qualifier, nil)
 
 	if err != nil {
-		return fmt.Errorf("error while checking for existing stacks: %w", err)
+		return err
 	}
 
-	if found {
-		return fmt.Errorf("stack '%s' for DB identifier '%s' already exists", existingStackName, k.dbIdentifier)
+	if timeout == 0 {
+		slog.Info("Skipped wait for stack creation")
+
+		return nil
 	}
 
-	newStackName := k.generateStackName(&stackNameOption{
-		dbIdentifierShort: k.dbIdentifierShort,
-		qualifier:         qualifier,
-	})
+	slog.Info("Waiting for CloudFormation stack creation to complete", "timeout", timeout.Seconds())
 
-	slog.Info("Creating CloudFormation stack", "stackName", newStackName)
+	err = k.cfn.WaitForStackCreation(newStackName, timeout)
+
+	if err != nil {
+		return fmt.Errorf("failed while waiting for stack creation: %w", err)
+	}
 
-	err = k.cfn.CreateStack(newStackName, templateBody)
+	return nil
+}
+
+/*
+StopNow starts an execution of the state machine without waiting for it, so that the DB is stopped
+right away instead of at the next event or scheduled run.
+The state machine is used rather than stopping the DB directly, since it also handles DBs in transitional states.
+*/
+func (k *ktnh) StopNow() error {
+	report, err := k.Trigger(0)
 
 	if err != nil {
-		return fmt.Errorf("failed to create CloudFormation stack: %w", err)
+		return fmt.Errorf("failed to start state machine execution: %w", err)
 	}
 
-	if timeout == 0 {
-		slog.Info("Skipped wait for stack creation")
+	slog.Info("Started state machine execution to stop DB", "executionName", report.Name)
 
-		return nil
+	return nil
+}
+
+/*
+WaitStopped waits for the DB to be stopped, logging each change of its status.
+*/
+func (k *ktnh) WaitStopped(timeout time.Duration) error {
+	dbType, err := k.rds.DetermineDBType(k.dbIdentifier)
+
+	if err != nil {
+		return fmt.Errorf("failed to determine DB type: %w", err)
 	}
 
-	slog.Info("Waiting for CloudFormation stack creation to complete", "timeout", timeout.Seconds())
+	slog.Info("Waiting for DB to be stopped", "timeout", timeout.Seconds())
 
-	err = k.cfn.WaitForStackCreation(newStackName, timeout)
+	err = k.rds.WaitForDBStatus(k.dbIdentifier, string(dbType), rds.StatusStopped, timeout)
 
 	if err != nil {
-		return fmt.Errorf("failed while waiting for stack creation: %w", err)
+		return fmt.Errorf("failed while waiting for DB to be stopped: %w", err)
 	}
 
 	return nil
 }
+
+/*
+startFreeze starts the creation of the CloudFormation stack without waiting for it to complete.
+Parameters not given keep the defaults defined in the template, and the default tags and notification targets are given to the stack.
+Returns the name of the stack being created.
+*/
+func (k *ktnh) startFreeze(templateBody string, qualifier string, parameters map[string]string) (string, error) {
+	dbType, err := k.rds.DetermineDBType(k.dbIdentifier)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to determine DB type: %w", err)
+	}
+
+	return k.startFreezeByType(string(dbType), templateBody, qualifier, parameters, k.defaultStackOptions())
+}
+
+/*
+startFreezeByType starts the creation of the CloudFormation stack for the DB of the given type,
+in the same way as startFreeze, with the tags and notification targets given by options.
+*/
+func (k *ktnh) startFreezeByType(dbType string, templateBody string, qualifier string, parameters map[string]string, options *cfn.StackOptions) (string, error) {
+	existingStackName, found, err := k.findMatchingStackByType(dbType)
+
+	if err != nil {
+		return "", fmt.Errorf("error while checking for existing stacks: %w", err)
+	}
+
+	if found {
+		return "", fmt.Errorf("stack '%s' for DB identifier '%s' already exists", existingStackName, k.dbIdentifier)
+	}
+
+	newStackName := k.stackName
+
+	if newStackName == "" {
+		newStackName = k.generateStackName(&stackNameOption{
+			dbIdentifierShort: k.dbIdentifierShort,
+			qualifier:         qualifier,
+		})
+	}
+
+	// NOTE: the template has been checked locally when rendered, but CloudFormation may still reject it.
+	if err := k.cfn.ValidateTemplate(templateBody); err != nil {
+		return "", fmt.Errorf("CloudFormation template is invalid: %w", err)
+	}
+
+	slog.Info("Creating CloudFormation stack", "stackName", newStackName)
+
+	err = k.cfn.CreateStack(newStackName, templateBody, parameters, options)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to create CloudFormation stack: %w", err)
+	}
+
+	return newStackName, nil
+}
@@ -24,6 +24,7 @@ func Test_Freeze(t *testing.T) {
 		dbIdentifier             string
 		dbIdentifierShort        string
 		stackNamePrefix          string
+		stackName                string
 		qualifier                string
 		templateBody             string
 		timeout                  time.Duration
@@ -114,6 +115,11 @@ func Test_Freeze(t *testing.T) {
 					Once()
 			},
 			mockCreateStackSetup: func(c *appmock.MockCloudFormationClient) {
+				c.On("ValidateTemplate", mock.Anything, &cloudformation.ValidateTemplateInput{
+					TemplateBody: aws.String("{a: 1}"),
+				}, mock.Anything).
+					Return(&cloudformation.ValidateTemplateOutput{}, nil)
+
 				params := &cloudformation.CreateStackInput{
 					StackName:    aws.String("A-db-1-12345-abcdef"),
 					TemplateBody: aws.String("{a: 1}"),
@@ -178,6 +184,11 @@ func Test_Freeze(t *testing.T) {
 					Return(c)
 			},
 			mockCreateStackSetup: func(c *appmock.MockCloudFormationClient) {
+				c.On("ValidateTemplate", mock.Anything, &cloudformation.ValidateTemplateInput{
+					TemplateBody: aws.String("{b: 2}"),
+				}, mock.Anything).
+					Return(&cloudformation.ValidateTemplateOutput{}, nil)
+
 				params := &cloudformation.CreateStackInput{
 					StackName:    aws.String("B-db-2-12345-ghijkl"),
 					TemplateBody: aws.String("{b: 2}"),
@@ -192,6 +203,66 @@ func Test_Freeze(t *testing.T) {
 			mockWaitSetup: func(f *appmock.MockCloudFormationFactory, w *appmock.MockStackCreateCompleteWaiter) {},
 			wantErr:       false,
 		},
+		{
+			name:              "With stack name",
+			dbIdentifier:      "db-9-1234567890",
+			dbIdentifierShort: "db-9-12345",
+			stackNamePrefix:   "H",
+			stackName:         "my-stack",
+			qualifier:         "stuvwx",
+			templateBody:      "{h: 8}",
+			timeout:           0,
+			mockDetermineDBTypeSetup: func(f *appmock.MockRDSFactory, c *appmock.MockRDSClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &rds.DescribeDBClustersInput{
+					DBClusterIdentifier: aws.String("db-9-1234567890"),
+				}
+
+				result := &rds.DescribeDBClustersOutput{
+					DBClusters: []rdstypes.DBCluster{
+						{
+							Engine: aws.String("aurora-mysql"),
+						},
+					},
+				}
+
+				c.On("DescribeDBClusters", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			mockListStacksSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockListStacksPaginator) {
+				f.On("NewListStacksPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			mockGetTemplateSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+			},
+			mockCreateStackSetup: func(c *appmock.MockCloudFormationClient) {
+				c.On("ValidateTemplate", mock.Anything, &cloudformation.ValidateTemplateInput{
+					TemplateBody: aws.String("{h: 8}"),
+				}, mock.Anything).
+					Return(&cloudformation.ValidateTemplateOutput{}, nil)
+
+				params := &cloudformation.CreateStackInput{
+					StackName:    aws.String("my-stack"),
+					TemplateBody: aws.String("{h: 8}"),
+					Capabilities: []cfntypes.Capability{cfntypes.CapabilityCapabilityNamedIam},
+				}
+
+				result := &cloudformation.CreateStackOutput{}
+
+				c.On("CreateStack", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			mockWaitSetup: func(f *appmock.MockCloudFormationFactory, w *appmock.MockStackCreateCompleteWaiter) {},
+			wantErr:       false,
+		},
 		{
 			name:              "Error during finding stack",
 			dbIdentifier:      "db-3-1234567890",
@@ -339,6 +410,11 @@ func Test_Freeze(t *testing.T) {
 					Return(c)
 			},
 			mockCreateStackSetup: func(c *appmock.MockCloudFormationClient) {
+				c.On("ValidateTemplate", mock.Anything, &cloudformation.ValidateTemplateInput{
+					TemplateBody: aws.String("{e: 5}"),
+				}, mock.Anything).
+					Return(&cloudformation.ValidateTemplateOutput{}, nil)
+
 				params := &cloudformation.CreateStackInput{
 					StackName:    aws.String("E-db-5-12345-zyxwvu"),
 					TemplateBody: aws.String("{e: 5}"),
@@ -353,6 +429,54 @@ func Test_Freeze(t *testing.T) {
 			mockWaitSetup: func(f *appmock.MockCloudFormationFactory, w *appmock.MockStackCreateCompleteWaiter) {},
 			wantErr:       true,
 		},
+		{
+			name:              "Error during template validation",
+			dbIdentifier:      "db-5-1234567890",
+			dbIdentifierShort: "db-5-12345",
+			stackNamePrefix:   "E",
+			qualifier:         "zyxwvu",
+			templateBody:      "{e: 5}",
+			timeout:           time.Minute * 5,
+			mockDetermineDBTypeSetup: func(f *appmock.MockRDSFactory, c *appmock.MockRDSClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &rds.DescribeDBClustersInput{
+					DBClusterIdentifier: aws.String("db-5-1234567890"),
+				}
+
+				result := &rds.DescribeDBClustersOutput{
+					DBClusters: []rdstypes.DBCluster{
+						{
+							Engine: aws.String("aurora-mysql"),
+						},
+					},
+				}
+
+				c.On("DescribeDBClusters", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			mockListStacksSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockListStacksPaginator) {
+				f.On("NewListStacksPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			mockGetTemplateSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+			},
+			mockCreateStackSetup: func(c *appmock.MockCloudFormationClient) {
+				c.On("ValidateTemplate", mock.Anything, &cloudformation.ValidateTemplateInput{
+					TemplateBody: aws.String("{e: 5}"),
+				}, mock.Anything).
+					Return(&cloudformation.ValidateTemplateOutput{}, assert.AnError)
+			},
+			mockWaitSetup: func(f *appmock.MockCloudFormationFactory, w *appmock.MockStackCreateCompleteWaiter) {},
+			wantErr:       true,
+		},
 		{
 			name:              "Error during waiter",
 			dbIdentifier:      "db-6-1234567890",
@@ -393,6 +517,11 @@ func Test_Freeze(t *testing.T) {
 					Return(c)
 			},
 			mockCreateStackSetup: func(c *appmock.MockCloudFormationClient) {
+				c.On("ValidateTemplate", mock.Anything, &cloudformation.ValidateTemplateInput{
+					TemplateBody: aws.String("{f: 6}"),
+				}, mock.Anything).
+					Return(&cloudformation.ValidateTemplateOutput{}, nil)
+
 				params := &cloudformation.CreateStackInput{
 					StackName:    aws.String("F-db-6-12345-tsrqpo"),
 					TemplateBody: aws.String("{f: 6}"),
@@ -431,6 +560,7 @@ func Test_Freeze(t *testing.T) {
 				dbIdentifier:      tc.dbIdentifier,
 				dbIdentifierShort: tc.dbIdentifierShort,
 				stackNamePrefix:   tc.stackNamePrefix,
+				stackName:         tc.stackName,
 				rds:               apprds.NewRDS(mockFactoryRDS),
 				cfn:               appcfn.NewCloudFormation(mockFactoryCloudFormation),
 			}
@@ -0,0 +1,152 @@
+package ktnh
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+)
+
+/*
+HistoryReport holds the execution history of the state machine of a database.
+*/
+type HistoryReport struct {
+	Executions  []HistoryEntry `json:"executions"`  // executions ordered from newest to oldest
+	ForcedStops int            `json:"forcedStops"` // number of executions that stopped the DB
+}
+
+/*
+HistoryEntry holds information about a single state machine execution.
+*/
+type HistoryEntry struct {
+	Name        string     `json:"name"`        // execution name
+	Trigger     string     `json:"trigger"`     // what started the execution ("event", "schedule" or "manual")
+	StartDate   time.Time  `json:"startDate"`   // time when the execution started
+	StopDate    *time.Time `json:"stopDate"`    // time when the execution stopped (nil if still running)
+	Status      string     `json:"status"`      // execution status
+	StopInvoked bool       `json:"stopInvoked"` // whether the `StopDB` state was entered
+}
+
+const (
+	triggerEvent    = "event"    // execution started by the auto-start event rule
+	triggerSchedule = "schedule" // execution started by the periodic stop schedule
+	triggerManual   = "manual"   // execution started by the `trigger` command
+
+	stopDBStateName = "StopDB" // name of the state that stops the DB
+)
+
+/*
+History returns the executions of the state machine started at or after the given time.
+*/
+func (k *ktnh) History(since time.Time) (*HistoryReport, error) {
+	stateMachineArn, err := k.findStackResource(cfn.LogicalIDStateMachine)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find state machine: %w", err)
+	}
+
+	executions, err := k.sfn.ListExecutionsSince(stateMachineArn, since)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list state machine executions: %w", err)
+	}
+
+	report := &HistoryReport{
+		Executions: make([]HistoryEntry, len(executions)),
+	}
+
+	for i, execution := range executions {
+		history, err := k.sfn.GetExecutionHistory(execution.ARN)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve history of execution '%s': %w", execution.Name, err)
+		}
+
+		entry := HistoryEntry{
+			Name:        execution.Name,
+			Trigger:     classifyTrigger(history.Input),
+			StartDate:   execution.StartDate,
+			Status:      execution.Status,
+			StopInvoked: slices.Contains(history.EnteredStates, stopDBStateName),
+		}
+
+		if !execution.StopDate.IsZero() {
+			entry.StopDate = &execution.StopDate
+		}
+
+		if entry.StopInvoked {
+			report.ForcedStops++
+		}
+
+		report.Executions[i] = entry
+	}
+
+	slog.Debug("Collected execution history",
+		"executions", len(report.Executions),
+		"forcedStops", report.ForcedStops,
+	)
+
+	return report, nil
+}
+
+/*
+classifyTrigger determines what started an execution from its input.
+The event rule passes the RDS event as is, the `trigger` command passes a marker,
+and the schedule passes no specific input.
+*/
+func classifyTrigger(input string) string {
+	var event struct {
+		Source string `json:"source"`
+	}
+
+	if err := json.Unmarshal([]byte(input), &event); err != nil {
+		slog.Debug("Failed to parse execution input", "error", err)
+
+		return triggerSchedule
+	}
+
+	switch event.Source {
+	case "aws.rds":
+		return triggerEvent
+	case manualTriggerSource:
+		return triggerManual
+	default:
+		return triggerSchedule
+	}
+}
+
+/*
+Tables converts the report into a table of executions and a summary table for display.
+*/
+func (r *HistoryReport) Tables() []output.Table {
+	executions := output.Table{
+		Title:   "Executions",
+		Headers: []string{"name", "trigger", "start", "stop", "status", "stopped-db"},
+		Rows:    make([][]any, len(r.Executions)),
+	}
+
+	for i, entry := range r.Executions {
+		executions.Rows[i] = []any{
+			entry.Name,
+			entry.Trigger,
+			entry.StartDate,
+			entry.StopDate,
+			entry.Status,
+			entry.StopInvoked,
+		}
+	}
+
+	summary := output.Table{
+		Title:   "Summary",
+		Headers: []string{"executions", "forced-stops"},
+		Rows: [][]any{
+			{len(r.Executions), r.ForcedStops},
+		},
+	}
+
+	return []output.Table{executions, summary}
+}
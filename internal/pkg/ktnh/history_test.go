@@ -0,0 +1,77 @@
+package ktnh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_classifyTrigger(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "RDS event",
+			input:    `{"source":"aws.rds","detail-type":"RDS DB Cluster Event"}`,
+			expected: "event",
+		},
+		{
+			name:     "Manual trigger",
+			input:    `{"source":"ktnh.manual"}`,
+			expected: "manual",
+		},
+		{
+			name:     "Empty object",
+			input:    `{}`,
+			expected: "schedule",
+		},
+		{
+			name:     "Not JSON",
+			input:    "",
+			expected: "schedule",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, classifyTrigger(tc.input), "Trigger does not match")
+		})
+	}
+}
+
+func Test_HistoryReport_Tables(t *testing.T) {
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	stopDate := startDate.Add(time.Minute)
+
+	report := &HistoryReport{
+		Executions: []HistoryEntry{
+			{
+				Name:        "exec-2",
+				Trigger:     "event",
+				StartDate:   startDate,
+				Status:      "RUNNING",
+				StopInvoked: false,
+			},
+			{
+				Name:        "exec-1",
+				Trigger:     "schedule",
+				StartDate:   startDate,
+				StopDate:    &stopDate,
+				Status:      "SUCCEEDED",
+				StopInvoked: true,
+			},
+		},
+		ForcedStops: 1,
+	}
+
+	tables := report.Tables()
+
+	assert.Len(t, tables, 2, "Executions and summary tables should be returned")
+	assert.Equal(t, []string{"name", "trigger", "start", "stop", "status", "stopped-db"}, tables[0].Headers, "Headers do not match")
+	assert.Equal(t, []any{"exec-2", "event", startDate, (*time.Time)(nil), "RUNNING", false}, tables[0].Rows[0], "First row does not match")
+	assert.Equal(t, []any{"exec-1", "schedule", startDate, &stopDate, "SUCCEEDED", true}, tables[0].Rows[1], "Second row does not match")
+	assert.Equal(t, [][]any{{2, 1}}, tables[1].Rows, "Summary does not match")
+}
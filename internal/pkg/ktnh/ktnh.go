@@ -17,7 +17,11 @@ import (
 
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/awsfactory"
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/events"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/logs"
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/scheduler"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/sfn"
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/utils"
 )
 
@@ -26,11 +30,17 @@ ktnh is the core component of the ktnh command that manages the lifecycle of
 Aurora clusters and RDS instances.
 */
 type ktnh struct {
-	dbIdentifier      string              // DB cluster/instance identifier
-	dbIdentifierShort string              // shortened DB identifier for display
-	stackNamePrefix   string              // prefix for CloudFormation stack name
-	cfn               *cfn.CloudFormation // CloudFormation operations wrapper
-	rds               *rds.RDS            // RDS operations wrapper
+	dbIdentifier      string               // DB cluster/instance identifier
+	dbIdentifierShort string               // shortened DB identifier for display
+	stackNamePrefix   string               // prefix for CloudFormation stack name
+	stackName         string               // exact name of the stack to adopt (empty to find stacks by the prefix only)
+	stackOptions      cfn.StackOptions     // default tags and notification targets of the stacks (see `SetStackOptions`)
+	cfn               *cfn.CloudFormation  // CloudFormation operations wrapper
+	rds               *rds.RDS             // RDS operations wrapper
+	sfn               *sfn.StepFunctions   // Step Functions operations wrapper
+	events            *events.EventBridge  // EventBridge operations wrapper
+	scheduler         *scheduler.Scheduler // EventBridge Scheduler operations wrapper
+	logs              *logs.CloudWatchLogs // CloudWatch Logs operations wrapper
 }
 
 /*
@@ -54,6 +64,8 @@ const dbIdentifierTruncateLength = 10
 NewKtnh creates and returns a new instance of ktnh.
 */
 func NewKtnh(dbIdentifier string, stackNamePrefix string) (*ktnh, error) {
+	dbIdentifier = normalizeIdentifier(dbIdentifier)
+
 	cfnFactory, err := awsfactory.NewCloudFormationFactory()
 
 	if err != nil {
@@ -66,15 +78,102 @@ func NewKtnh(dbIdentifier string, stackNamePrefix string) (*ktnh, error) {
 		return nil, fmt.Errorf("failed to create RDS factory: %w", err)
 	}
 
+	sfnFactory, err := awsfactory.NewSFNFactory()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Step Functions factory: %w", err)
+	}
+
+	eventBridgeFactory, err := awsfactory.NewEventBridgeFactory()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EventBridge factory: %w", err)
+	}
+
+	schedulerFactory, err := awsfactory.NewSchedulerFactory()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EventBridge Scheduler factory: %w", err)
+	}
+
+	cloudWatchLogsFactory, err := awsfactory.NewCloudWatchLogsFactory()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CloudWatch Logs factory: %w", err)
+	}
+
 	return &ktnh{
 		dbIdentifier:      dbIdentifier,
 		dbIdentifierShort: shortenIdentifier(dbIdentifier),
 		stackNamePrefix:   stackNamePrefix,
 		cfn:               cfn.NewCloudFormation(cfnFactory),
 		rds:               rds.NewRDS(rdsFactory),
+		sfn:               sfn.NewStepFunctions(sfnFactory),
+		events:            events.NewEventBridge(eventBridgeFactory),
+		scheduler:         scheduler.NewScheduler(schedulerFactory),
+		logs:              logs.NewCloudWatchLogs(cloudWatchLogsFactory),
 	}, nil
 }
 
+/*
+forDatabase returns a copy of the ktnh instance for another DB identifier, sharing the AWS service wrappers.
+*/
+func (k *ktnh) forDatabase(dbIdentifier string) *ktnh {
+	clone := *k
+
+	clone.dbIdentifier = normalizeIdentifier(dbIdentifier)
+	clone.dbIdentifierShort = shortenIdentifier(clone.dbIdentifier)
+	clone.stackName = ""
+
+	return &clone
+}
+
+/*
+SetStackName sets the exact name of the stack of the DB, so that a stack not named after the prefix
+(e.g., one created by Service Catalog) can be adopted. The stack is found by the name in addition to the prefix,
+and freeze creates the stack with the name.
+*/
+func (k *ktnh) SetStackName(stackName string) {
+	k.stackName = stackName
+}
+
+/*
+SetStackOptions sets the default tags and notification targets of the stacks.
+They are given to the stacks created by freeze, scan and apply, and are merged into the options
+declared in the manifest by plan and apply, where the options of the manifest take precedence.
+*/
+func (k *ktnh) SetStackOptions(options cfn.StackOptions) error {
+	if err := validateStackOptions(options); err != nil {
+		return err
+	}
+
+	k.stackOptions = options
+
+	return nil
+}
+
+/*
+defaultStackOptions returns the default tags and notification targets of the stacks, or nil if there are none.
+*/
+func (k *ktnh) defaultStackOptions() *cfn.StackOptions {
+	if k.stackOptions.Equal(cfn.StackOptions{}) {
+		return nil
+	}
+
+	options := k.stackOptions
+
+	return &options
+}
+
+/*
+normalizeIdentifier converts the DB identifier into lowercase.
+RDS identifiers are case-insensitive and always returned in lowercase by the API,
+while those given by the user or recorded in the stack metadata may be in any case.
+*/
+func normalizeIdentifier(dbIdentifier string) string {
+	return strings.ToLower(dbIdentifier)
+}
+
 /*
 shortenIdentifier shortens the DB identifier by truncating it to the specified length.
 If the last character after truncation is not alphanumeric, it extends the length by one
@@ -138,49 +237,115 @@ func (k *ktnh) findMatchingStack() (string, bool, error) {
 		return "", false, fmt.Errorf("failed to determine DB type: %w", err)
 	}
 
-	pattern := fmt.Sprintf(
-		"^%s$",
-		k.generateStackName(&stackNameOption{
-			dbIdentifierShort: k.dbIdentifierShort,
-		}),
-	)
+	return k.findMatchingStackByType(string(dbType))
+}
+
+/*
+findMatchingStackByType finds the CloudFormation stack matching the DB identifier and the given DB type.
+Returns the stack name, whether a stack was found, and any error encountered.
+*/
+func (k *ktnh) findMatchingStackByType(dbType string) (string, bool, error) {
+	stacks, err := k.findStacks(&stackSearchOption{
+		dbIdentifier:      k.dbIdentifier,
+		dbIdentifierShort: k.dbIdentifierShort,
+		dbType:            dbType,
+	})
+
+	if err != nil {
+		return "", false, err
+	}
+
+	stacksCount := len(stacks)
+
+	slog.Debug("Found stacks matching criteria", "count", stacksCount)
+
+	if stacksCount == 0 {
+		return "", false, nil
+	} else if 2 <= stacksCount {
+		return "", false, fmt.Errorf("multiple stacks found for DB identifier")
+	}
+
+	stackName := stacks[0].stackName
+
+	slog.Debug("Found single matching stack", "stackName", stackName)
+
+	return stackName, true, nil
+}
+
+/*
+stackSearchOption defines the criteria of the stacks found by findStacks.
+Empty fields are not used for filtering.
+*/
+type stackSearchOption struct {
+	dbIdentifier      string // DB cluster/instance identifier
+	dbIdentifierShort string // shortened DB identifier, by which the stack is named
+	dbType            string // type of the DB (see `internal/pkg/rds`)
+	anyPrefix         bool   // whether to find the stacks of all prefixes, not only those named after the prefix
+}
+
+/*
+findStacks finds the ktnh stacks matching the criteria, and returns the databases they protect.
+Stacks named after the prefix (and the stack given by `SetStackName`) are verified by their `Metadata.KTNH` sections.
+The other stacks created from the templates of ktnh, which are told apart from unrelated stacks by the descriptions
+of their templates, are verified in the same way, and are found if their metadata records the prefix
+(e.g., stacks created by Service Catalog from the generic template) or, with anyPrefix, whatever the prefix is.
+*/
+func (k *ktnh) findStacks(option *stackSearchOption) ([]displayDBInfo, error) {
+	nameOption := &stackNameOption{}
+
+	// NOTE: stacks created before identifiers were normalized may be named after the identifier in any case.
+	if option.dbIdentifierShort != "" {
+		nameOption.dbIdentifierShort = "(?i:" + option.dbIdentifierShort + ")"
+	}
+
+	pattern := k.generateStackName(nameOption)
+
+	if k.stackName != "" {
+		pattern += "|" + regexp.QuoteMeta(k.stackName)
+	}
+
+	pattern = fmt.Sprintf("^(?:%s)$", pattern)
 
 	slog.Debug("Generated stack name pattern for matching", "pattern", pattern)
 
 	re, err := regexp.Compile(pattern)
 
 	if err != nil {
-		return "", false, fmt.Errorf("failed to compile regex pattern '%s': %w", pattern, err)
+		return nil, fmt.Errorf("failed to compile regex pattern '%s': %w", pattern, err)
 	}
 
-	verifyOotion := cfn.MetadataVerifyOption{
-		DBIdentifier: k.dbIdentifier,
-		DBType:       string(dbType),
+	verifyOption := cfn.MetadataVerifyOption{
+		DBIdentifier: option.dbIdentifier,
+		DBType:       option.dbType,
 	}
 
-	evaluator := func(stackName string) bool {
-		if !re.MatchString(stackName) {
+	var databases []displayDBInfo
+
+	evaluator := func(summary cfn.StackSummary) bool {
+		nameMatched := re.MatchString(summary.Name)
+
+		if !nameMatched && !summary.IsKTNHCandidate() {
 			slog.Debug("Stack name does not match pattern")
 
 			return false
 		}
 
-		metadata, err := k.cfn.GetKTNHMetadata(stackName)
+		metadata, err := k.cfn.GetKTNHMetadata(summary.Name)
 
 		if err != nil {
 			slog.Warn("Failed to retrieve metadata for stack during evaluation",
-				"stackName", stackName,
+				"stackName", summary.Name,
 				"error", err,
 			)
 
 			return false
 		}
 
-		isMatched, err := cfn.VerifyMetadata(metadata, &verifyOotion)
+		isMatched, err := cfn.VerifyMetadata(metadata, &verifyOption)
 
 		if err != nil {
 			slog.Warn("Failed to verify metadata for stack during evaluation",
-				"stackName", stackName,
+				"stackName", summary.Name,
 				"error", err,
 			)
 
@@ -193,28 +358,59 @@ func (k *ktnh) findMatchingStack() (string, bool, error) {
 			return false
 		}
 
+		if !nameMatched && !option.anyPrefix && (metadata.Prefix != k.stackNamePrefix) {
+			slog.Debug("Stack is managed under another prefix", "prefix", metadata.Prefix)
+
+			return false
+		}
+
+		databases = append(databases, displayDBInfo{
+			dbIdentifier: normalizeIdentifier(metadata.DBIdentifier),
+			dbType:       metadata.DBType,
+			stackName:    summary.Name,
+		})
+
 		return true
 	}
 
-	stacks, err := k.cfn.ListStacks(evaluator)
+	if _, err := k.cfn.ListStacks(evaluator); err != nil {
+		return nil, fmt.Errorf("failed to list CloudFormation stacks: %w", err)
+	}
+
+	return databases, nil
+}
+
+/*
+findStackResource finds the ktnh stack of the DB and returns the physical ID of the resource
+with the given logical ID.
+*/
+func (k *ktnh) findStackResource(logicalID string) (string, error) {
+	stackName, found, err := k.findMatchingStack()
 
 	if err != nil {
-		return "", false, fmt.Errorf("failed to list CloudFormation stacks: %w", err)
+		return "", fmt.Errorf("failed to find matching stack: %w", err)
 	}
 
-	stacksCount := len(stacks)
+	if !found {
+		return "", fmt.Errorf("no stacks found for DB identifier")
+	}
 
-	slog.Debug("Found stacks matching criteria", "count", stacksCount)
+	resources, err := k.cfn.ListStackResources(stackName)
 
-	if stacksCount == 0 {
-		return "", false, nil
-	} else if 2 <= stacksCount {
-		return "", false, fmt.Errorf("multiple stacks found for DB identifier")
+	if err != nil {
+		return "", fmt.Errorf("failed to list stack resources: %w", err)
 	}
 
-	stackName := stacks[0]
+	physicalID, ok := cfn.FindPhysicalID(resources, logicalID)
 
-	slog.Debug("Found single matching stack", "stackName", stackName)
+	if !ok {
+		return "", fmt.Errorf("resource '%s' not found in stack '%s'", logicalID, stackName)
+	}
 
-	return stackName, true, nil
+	slog.Debug("Found stack resource",
+		"logicalID", logicalID,
+		"physicalID", physicalID,
+	)
+
+	return physicalID, nil
 }
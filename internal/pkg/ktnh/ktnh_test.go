@@ -133,6 +133,7 @@ func Test_findMatchingStack(t *testing.T) {
 		dbIdentifier             string
 		dbIdentifierShort        string
 		stackNamePrefix          string
+		stackName                string
 		mockDetermineDBTypeSetup func(*appmock.MockRDSFactory, *appmock.MockRDSClient)
 		mockListStacksSetup      func(*appmock.MockCloudFormationFactory, *appmock.MockListStacksPaginator)
 		mockGetTemplateSetup     func(*appmock.MockCloudFormationFactory, *appmock.MockCloudFormationClient)
@@ -671,6 +672,254 @@ func Test_findMatchingStack(t *testing.T) {
 			expectedFound:     true,
 			wantErr:           false,
 		},
+		{
+			name:              "Adopted stack found by name",
+			dbIdentifier:      "db-9-1234567890",
+			dbIdentifierShort: "db-9-12345",
+			stackNamePrefix:   "H",
+			stackName:         "SC-123456789012-pp-abcdef",
+			mockDetermineDBTypeSetup: func(f *appmock.MockRDSFactory, c *appmock.MockRDSClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &rds.DescribeDBClustersInput{
+					DBClusterIdentifier: aws.String("db-9-1234567890"),
+				}
+
+				result := &rds.DescribeDBClustersOutput{
+					DBClusters: []rdstypes.DBCluster{
+						{
+							Engine: aws.String("aurora-mysql"),
+						},
+					},
+				}
+
+				c.On("DescribeDBClusters", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			mockListStacksSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockListStacksPaginator) {
+				f.On("NewListStacksPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				result := &cloudformation.ListStacksOutput{
+					StackSummaries: []cfntypes.StackSummary{
+						{
+							StackName: aws.String("SC-123456789012-pp-abcdef"),
+						},
+						{
+							StackName: aws.String("SC-123456789012-pp-abcdefg"),
+						},
+						{
+							StackName: aws.String("I-db-9-12345-abcdef"),
+						},
+					},
+				}
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(result, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			mockGetTemplateSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params1 := &cloudformation.GetTemplateInput{
+					StackName: aws.String("SC-123456789012-pp-abcdef"),
+				}
+
+				templateBody1 := strings.Join([]string{
+					"Metadata:",
+					"  KTNH:",
+					"    Generator: 'koreru-toki-no-hiho'",
+					"    Version: '1'",
+					"    DBIdentifier: 'db-9-1234567890'",
+					"    DBType: 'aurora'",
+				}, "\n")
+
+				result1 := &cloudformation.GetTemplateOutput{
+					TemplateBody: aws.String(templateBody1),
+				}
+
+				c.On("GetTemplate", mock.Anything, params1, mock.Anything).
+					Return(result1, nil).
+					Once()
+			},
+			expectedStackName: "SC-123456789012-pp-abcdef",
+			expectedFound:     true,
+			wantErr:           false,
+		},
+		{
+			name:              "Stack found by prefix in metadata",
+			dbIdentifier:      "db-10-1234567890",
+			dbIdentifierShort: "db-10-1234",
+			stackNamePrefix:   "K",
+			mockDetermineDBTypeSetup: func(f *appmock.MockRDSFactory, c *appmock.MockRDSClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &rds.DescribeDBClustersInput{
+					DBClusterIdentifier: aws.String("db-10-1234567890"),
+				}
+
+				result := &rds.DescribeDBClustersOutput{
+					DBClusters: []rdstypes.DBCluster{
+						{
+							Engine: aws.String("aurora-mysql"),
+						},
+					},
+				}
+
+				c.On("DescribeDBClusters", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			mockListStacksSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockListStacksPaginator) {
+				f.On("NewListStacksPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				result := &cloudformation.ListStacksOutput{
+					StackSummaries: []cfntypes.StackSummary{
+						{
+							StackName:           aws.String("SC-123456789012-pp-abcdef"),
+							TemplateDescription: aws.String("ktnh - Keep an Aurora cluster or an RDS instance stopped permanently"),
+						},
+						{
+							StackName:           aws.String("SC-123456789012-pp-ghijkl"),
+							TemplateDescription: aws.String("ktnh - Keep an Aurora cluster or an RDS instance stopped permanently"),
+						},
+						{
+							StackName:           aws.String("SC-123456789012-pp-mnopqr"),
+							TemplateDescription: aws.String("Something else"),
+						},
+					},
+				}
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(result, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			mockGetTemplateSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				for stackName, prefix := range map[string]string{
+					"SC-123456789012-pp-abcdef": "K",
+					"SC-123456789012-pp-ghijkl": "L",
+				} {
+					templateBody := strings.Join([]string{
+						"Metadata:",
+						"  KTNH:",
+						"    Generator: 'koreru-toki-no-hiho'",
+						"    Version: '1'",
+						"    Prefix: '" + prefix + "'",
+						"    DBIdentifier: 'db-10-1234567890'",
+						"    DBType: 'aurora'",
+					}, "\n")
+
+					c.On("GetTemplate", mock.Anything, &cloudformation.GetTemplateInput{StackName: aws.String(stackName)}, mock.Anything).
+						Return(&cloudformation.GetTemplateOutput{TemplateBody: aws.String(templateBody)}, nil).
+						Once()
+				}
+			},
+			expectedStackName: "SC-123456789012-pp-abcdef",
+			expectedFound:     true,
+			wantErr:           false,
+		},
+		{
+			name:              "Stack named in different case",
+			dbIdentifier:      "my-db",
+			dbIdentifierShort: "my-db",
+			stackNamePrefix:   "J",
+			mockDetermineDBTypeSetup: func(f *appmock.MockRDSFactory, c *appmock.MockRDSClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &rds.DescribeDBClustersInput{
+					DBClusterIdentifier: aws.String("my-db"),
+				}
+
+				result := &rds.DescribeDBClustersOutput{
+					DBClusters: []rdstypes.DBCluster{
+						{
+							Engine: aws.String("aurora-mysql"),
+						},
+					},
+				}
+
+				c.On("DescribeDBClusters", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			mockListStacksSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockListStacksPaginator) {
+				f.On("NewListStacksPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				result := &cloudformation.ListStacksOutput{
+					StackSummaries: []cfntypes.StackSummary{
+						{
+							StackName: aws.String("J-My-DB-abcdef"),
+						},
+						{
+							StackName: aws.String("j-my-db-ghijkl"),
+						},
+					},
+				}
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(result, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			mockGetTemplateSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params1 := &cloudformation.GetTemplateInput{
+					StackName: aws.String("J-My-DB-abcdef"),
+				}
+
+				templateBody1 := strings.Join([]string{
+					"Metadata:",
+					"  KTNH:",
+					"    Generator: 'koreru-toki-no-hiho'",
+					"    Version: '1'",
+					"    DBIdentifier: 'My-DB'",
+					"    DBType: 'aurora'",
+				}, "\n")
+
+				result1 := &cloudformation.GetTemplateOutput{
+					TemplateBody: aws.String(templateBody1),
+				}
+
+				c.On("GetTemplate", mock.Anything, params1, mock.Anything).
+					Return(result1, nil).
+					Once()
+			},
+			expectedStackName: "J-My-DB-abcdef",
+			expectedFound:     true,
+			wantErr:           false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -689,6 +938,7 @@ func Test_findMatchingStack(t *testing.T) {
 				dbIdentifier:      tc.dbIdentifier,
 				dbIdentifierShort: tc.dbIdentifierShort,
 				stackNamePrefix:   tc.stackNamePrefix,
+				stackName:         tc.stackName,
 				rds:               apprds.NewRDS(mockFactoryRDS),
 				cfn:               appcfn.NewCloudFormation(mockFactoryCloudFormation),
 			}
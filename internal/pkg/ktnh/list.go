@@ -3,80 +3,403 @@ package ktnh
 import (
 	"fmt"
 	"log/slog"
-	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
 )
 
 /*
 displayDBInfo is a structure used for displaying managed database information.
+Fields that could not be retrieved are left empty (or nil) and displayed as "(unknown)".
 */
 type displayDBInfo struct {
-	dbIdentifier   string // DB cluster/instance identifier
-	dbType         string // type of the DB (see `internal/pkg/rds`)
-	stackName      string // CloudFormation stack name
-	hasMaintenance bool   // whether there are pending maintenance actions
+	dbIdentifier   string         // DB cluster/instance identifier
+	dbType         string         // type of the DB (see `internal/pkg/rds`)
+	stackName      string         // CloudFormation stack name
+	state          string         // protection state of the stack ("active", "paused" or "(unknown)")
+	hasMaintenance bool           // whether there are pending maintenance actions
+	maintenance    string         // pending maintenance status ("required", "available" or "none")
+	stack          *cfn.Stack     // attributes of the stack
+	details        *rds.DBDetails // attributes of the DB
+	lastStop       *time.Time     // time of the last stop event of the DB, by ktnh or not (nil if not stopped recently)
+	lastStopKnown  bool           // whether the last stop time has been retrieved
+	orphaned       bool           // whether the DB no longer exists
 }
 
 /*
-convertDBsToStringRows transforms database information into a string slice.
-It returns a header slice containing column names and a 2D slice
-where each inner slice represents a row of database information.
+listDataSource identifies the API calls required to fill in a column of the list.
 */
-func convertDBsToStringRows(databases []displayDBInfo, isShowMaintenance bool) ([]string, [][]string) {
-	slog.Debug("Converting databases information to string rows")
+type listDataSource int
 
-	body := make([][]string, len(databases))
+const (
+	sourceStacks      listDataSource = iota // DescribeStacks
+	sourceDBDetails                         // DescribeDBClusters / DescribeDBInstances
+	sourceMaintenance                       // DescribePendingMaintenanceActions
+	sourceStopEvents                        // DescribeEvents
+)
+
+/*
+listColumn defines a column that can be displayed by List.
+*/
+type listColumn struct {
+	name    string                     // column name
+	sources []listDataSource           // data required to fill in the column (nil if available from stack metadata)
+	value   func(db displayDBInfo) any // function that extracts the typed value from the database information
+}
+
+/*
+allListColumnsKeyword selects all available columns.
+*/
+const allListColumnsKeyword = "all"
+
+/*
+listColumns lists the available columns in the order they are displayed with "all".
+*/
+var listColumns = []listColumn{
+	{"id", nil, func(db displayDBInfo) any { return db.dbIdentifier }},
+	{"type", nil, func(db displayDBInfo) any { return db.dbType }},
+	{"stack", nil, func(db displayDBInfo) any { return db.stackName }},
+	{"state", []listDataSource{sourceStacks, sourceDBDetails}, func(db displayDBInfo) any {
+		if db.orphaned {
+			return stateOrphaned
+		}
+
+		return orUnknown(db.state)
+	}},
+	{"maintenance", []listDataSource{sourceMaintenance}, func(db displayDBInfo) any { return orUnknown(db.maintenance) }},
+	{"status", []listDataSource{sourceDBDetails}, func(db displayDBInfo) any {
+		if db.details == nil {
+			return unknownValue
+		}
+
+		return orUnknown(db.details.Status)
+	}},
+	{"engine", []listDataSource{sourceDBDetails}, func(db displayDBInfo) any {
+		if db.details == nil {
+			return unknownValue
+		}
+
+		return orUnknown(strings.TrimSpace(db.details.Engine + " " + db.details.EngineVersion))
+	}},
+	{"class", []listDataSource{sourceDBDetails}, func(db displayDBInfo) any {
+		if db.details == nil {
+			return unknownValue
+		}
+
+		if db.dbType == "aurora" {
+			return fmt.Sprintf("%d members", db.details.MemberCount)
+		}
+
+		return orUnknown(db.details.InstanceClass)
+	}},
+	{"stack-status", []listDataSource{sourceStacks}, func(db displayDBInfo) any {
+		if db.stack == nil {
+			return unknownValue
+		}
+
+		return db.stack.Status
+	}},
+	{"frozen-since", []listDataSource{sourceStacks}, func(db displayDBInfo) any {
+		if db.stack == nil {
+			return unknownValue
+		}
+
+		return db.stack.CreationTime
+	}},
+	{"last-stopped", []listDataSource{sourceStopEvents}, func(db displayDBInfo) any {
+		if !db.lastStopKnown {
+			return unknownValue
+		}
+
+		return db.lastStop
+	}},
+}
+
+/*
+defaultListColumns lists the columns displayed when no columns are specified.
+*/
+var defaultListColumns = []string{"id", "type", "stack", "state", "maintenance"}
+
+/*
+ListColumns returns the names of the columns available for List.
+*/
+func ListColumns() []string {
+	names := make([]string, len(listColumns))
+
+	for i, column := range listColumns {
+		names[i] = column.name
+	}
+
+	return names
+}
+
+/*
+resolveListColumns converts column names into column definitions.
+An empty slice selects the default columns, and "all" expands to all available columns.
+*/
+func resolveListColumns(names []string) ([]listColumn, error) {
+	if len(names) == 0 {
+		names = defaultListColumns
+	}
+
+	var columns []listColumn
+
+	for _, name := range names {
+		if name == allListColumnsKeyword {
+			columns = append(columns, listColumns...)
+
+			continue
+		}
+
+		index := slices.IndexFunc(listColumns, func(column listColumn) bool {
+			return column.name == name
+		})
+
+		if index < 0 {
+			return nil, fmt.Errorf("unknown column '%s' (available: %s)", name, strings.Join(ListColumns(), ", "))
+		}
+
+		columns = append(columns, listColumns[index])
+	}
+
+	return columns, nil
+}
+
+/*
+convertDBsToTable transforms database information into a table of typed values,
+where each row represents a database and each column is one of the given columns.
+*/
+func convertDBsToTable(databases []displayDBInfo, columns []listColumn) *output.Table {
+	slog.Debug("Converting databases information to table")
+
+	table := &output.Table{
+		Headers: make([]string, len(columns)),
+		Rows:    make([][]any, len(databases)),
+	}
+
+	for i, column := range columns {
+		table.Headers[i] = column.name
+	}
 
 	for i, db := range databases {
-		var maintenanceStatus string
+		table.Rows[i] = make([]any, len(columns))
+
+		for j, column := range columns {
+			table.Rows[i][j] = column.value(db)
+		}
+	}
+
+	slog.Debug("Converted databases information to table")
+
+	return table
+}
+
+/*
+ListOption holds the options for List.
+Empty fields are not used for filtering.
+*/
+type ListOption struct {
+	Columns     []string          // columns to display (default columns if empty)
+	Type        string            // DB type to include ("aurora" or "rds")
+	Maintenance string            // maintenance status to include ("required", "available", "none" or "pending" for either of the former)
+	Status      string            // DB status to include (e.g., "stopped")
+	NameRegex   string            // regular expression that DB identifiers must match
+	Tags        map[string]string // tags that DBs must have
+	StackStatus string            // stack status to include (e.g., "ROLLBACK_COMPLETE")
+	SortBy      string            // column to sort by ("-" prefix for descending order)
+}
+
+/*
+listDataFetchers maps each data source to the method that retrieves it for all databases.
+*/
+var listDataFetchers = map[listDataSource]func(k *ktnh, databases []displayDBInfo) ([]displayDBInfo, error){
+	sourceStacks:      (*ktnh).updateProtectionState,
+	sourceMaintenance: (*ktnh).updateMaintenanceStatus,
+	sourceDBDetails:   (*ktnh).updateDBDetails,
+	sourceStopEvents:  (*ktnh).updateLastStopTime,
+}
+
+/*
+List returns a list of managed databases with the given columns, filtered and sorted as specified.
+Only the information required by the columns, the filters and the sort key is retrieved, in bulk for all databases.
+Failures to retrieve information that is only displayed are logged and reported as "(unknown)",
+while failures to retrieve information required for filtering or sorting are returned as errors.
+*/
+func (k *ktnh) List(option *ListOption) (*output.Table, error) {
+	columns, err := resolveListColumns(option.Columns)
+
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := newListFilter(option)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sorter, err := newListSorter(option.SortBy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	requiredSources := filter.sources
+
+	if sorter != nil {
+		for _, source := range sorter.column.sources {
+			requiredSources[source] = true
+		}
+	}
+
+	displayedSources := map[listDataSource]bool{}
+
+	for _, column := range columns {
+		for _, source := range column.sources {
+			displayedSources[source] = true
+		}
+	}
 
-		if isShowMaintenance {
-			if db.hasMaintenance {
-				maintenanceStatus = "pending"
-			} else {
-				maintenanceStatus = "none"
+	databases, err := k.collectManagedDatabases()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect managed databases: %w", err)
+	}
+
+	for _, source := range []listDataSource{sourceStacks, sourceMaintenance, sourceDBDetails, sourceStopEvents} {
+		if !requiredSources[source] && !displayedSources[source] {
+			continue
+		}
+
+		updated, err := listDataFetchers[source](k, databases)
+
+		if err != nil {
+			if requiredSources[source] {
+				return nil, fmt.Errorf("failed to retrieve information required for filtering or sorting: %w", err)
 			}
-		} else {
-			maintenanceStatus = "(unknown)"
+
+			slog.Warn("Failed to retrieve information for display", "error", err)
+
+			continue
 		}
 
-		body[i] = []string{
-			db.dbIdentifier,
-			db.dbType,
-			db.stackName,
-			maintenanceStatus,
+		databases = updated
+	}
+
+	databases = filter.apply(databases)
+
+	if sorter != nil {
+		sorter.apply(databases)
+	}
+
+	return convertDBsToTable(databases, columns), nil
+}
+
+/*
+updateProtectionState updates the protection state and the stack attributes for each database.
+All stacks are described at once. On failure, the state is reported as "(unknown)".
+*/
+func (k *ktnh) updateProtectionState(databases []displayDBInfo) ([]displayDBInfo, error) {
+	slog.Debug("Updating protection state for databases")
+
+	for i := range databases {
+		databases[i].state = unknownValue
+	}
+
+	if len(databases) == 0 {
+		return databases, nil
+	}
+
+	stacks, err := k.cfn.DescribeStacks()
+
+	if err != nil {
+		return databases, fmt.Errorf("failed to retrieve protection state: %w", err)
+	}
+
+	for i, db := range databases {
+		if stack, ok := stacks[db.stackName]; ok {
+			databases[i].state = protectionState(stack)
+			databases[i].stack = stack
 		}
 	}
 
-	slog.Debug("Converted databases information to string rows")
+	slog.Debug("Updated protection state for databases")
 
-	return []string{"id", "type", "stack", "maintenance"}, body
+	return databases, nil
 }
 
 /*
-List returns a list of managed databases.
+updateDBDetails updates the DB attributes (status, engine, instance class, tags, etc.) for each database.
+All DBs are described at once, and databases that no longer exist are marked as orphaned.
 */
-func (k *ktnh) List() ([]string, [][]string, error) {
-	databases, err := k.collectManagedDatabases()
+func (k *ktnh) updateDBDetails(databases []displayDBInfo) ([]displayDBInfo, error) {
+	slog.Debug("Updating DB details for databases")
+
+	if len(databases) == 0 {
+		return databases, nil
+	}
+
+	clusters, instances := splitDBsByType(databases)
+
+	details, err := k.rds.DescribeDBDetails(clusters, instances)
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to collect managed databases: %w", err)
+		return databases, fmt.Errorf("failed to retrieve DB details: %w", err)
+	}
+
+	for i, db := range databases {
+		detail, ok := details[rdsKey(db)]
+
+		if !ok {
+			slog.Debug("DB no longer exists", "dbIdentifier", db.dbIdentifier)
+
+			databases[i].orphaned = true
+
+			continue
+		}
+
+		databases[i].details = &detail
+	}
+
+	slog.Debug("Updated DB details for databases")
+
+	return databases, nil
+}
+
+/*
+updateLastStopTime updates the time when each database was last stopped, based on RDS events.
+Since RDS events are retained only for 14 days, DBs not stopped within the period have no last stop time.
+*/
+func (k *ktnh) updateLastStopTime(databases []displayDBInfo) ([]displayDBInfo, error) {
+	slog.Debug("Updating last stop time for databases")
+
+	if len(databases) == 0 {
+		return databases, nil
 	}
 
-	isShowMaintenance := true
+	clusters, instances := splitDBsByType(databases)
 
-	databasesWithMaintenance, err := k.updateMaintenanceStatus(databases)
+	stopTimes, err := k.rds.GetLastStopTimes(clusters, instances)
 
 	if err != nil {
-		slog.Warn("Failed to add maintenance status", "error", err)
+		return databases, fmt.Errorf("failed to retrieve last stop time: %w", err)
+	}
+
+	for i, db := range databases {
+		if stopTime, ok := stopTimes[rdsKey(db)]; ok {
+			databases[i].lastStop = &stopTime
+		}
 
-		isShowMaintenance = false
+		databases[i].lastStopKnown = true
 	}
 
-	headers, body := convertDBsToStringRows(databasesWithMaintenance, isShowMaintenance)
+	slog.Debug("Updated last stop time for databases")
 
-	return headers, body, nil
+	return databases, nil
 }
 
 /*
@@ -91,7 +414,7 @@ func (k *ktnh) updateMaintenanceStatus(databases []displayDBInfo) ([]displayDBIn
 		return databases, fmt.Errorf("failed to categorize databases: %w", err)
 	}
 
-	pendingMaintenance, err := k.rds.GetPendingMaintenanceActions(clusters, instances, clusterMembers)
+	pendingMaintenance, err := k.rds.DescribePendingMaintenanceActions(clusters, instances, clusterMembers)
 
 	if err != nil {
 		return databases, fmt.Errorf("failed to get pending maintenance actions: %w", err)
@@ -102,15 +425,10 @@ func (k *ktnh) updateMaintenanceStatus(databases []displayDBInfo) ([]displayDBIn
 	copy(databasesWithMaintenance, databases)
 
 	for i, db := range databasesWithMaintenance {
-		var prefix string
-
-		if db.dbType == "aurora" {
-			prefix = "cluster:"
-		} else {
-			prefix = "db:"
-		}
+		actions, hasMaintenance := pendingMaintenance[rdsKey(db)]
 
-		databasesWithMaintenance[i].hasMaintenance = pendingMaintenance[prefix+db.dbIdentifier]
+		databasesWithMaintenance[i].hasMaintenance = hasMaintenance
+		databasesWithMaintenance[i].maintenance = maintenanceCategory(actions, hasMaintenance)
 	}
 
 	slog.Debug("Updated maintenance status for databases")
@@ -118,6 +436,25 @@ func (k *ktnh) updateMaintenanceStatus(databases []displayDBInfo) ([]displayDBIn
 	return databasesWithMaintenance, nil
 }
 
+/*
+maintenanceCategory summarizes the pending maintenance actions of a database:
+"required" if any of them is scheduled to be applied automatically, "available" if there are others,
+or "none" if there are no pending maintenance actions.
+*/
+func maintenanceCategory(actions []rds.MaintenanceAction, hasMaintenance bool) string {
+	if !hasMaintenance {
+		return maintenanceNone
+	}
+
+	for _, action := range actions {
+		if action.Category() == rds.MaintenanceRequired {
+			return rds.MaintenanceRequired
+		}
+	}
+
+	return rds.MaintenanceAvailable
+}
+
 /*
 categorizeDBsByType separates DB identifiers into clusters and instances based on their type.
 It returns:
@@ -134,13 +471,7 @@ func (k *ktnh) categorizeDBsByType(databases []displayDBInfo) (
 ) {
 	slog.Debug("Categorizing databases by type")
 
-	for _, db := range databases {
-		if db.dbType == "aurora" {
-			clusters = append(clusters, db.dbIdentifier)
-		} else {
-			instances = append(instances, db.dbIdentifier)
-		}
-	}
+	clusters, instances = splitDBsByType(databases)
 
 	clusterMembers, err = k.rds.GetClusterMembers(clusters)
 
@@ -157,76 +488,53 @@ func (k *ktnh) categorizeDBsByType(databases []displayDBInfo) (
 }
 
 /*
-collectManagedDatabases finds all databases managed by ktnh.
+splitDBsByType separates DB identifiers into Aurora cluster IDs and standalone RDS instance IDs.
 */
-func (k *ktnh) collectManagedDatabases() ([]displayDBInfo, error) {
-	slog.Debug("Finding all managed databases")
-
-	pattern := fmt.Sprintf(
-		"^%s$",
-		k.generateStackName(&stackNameOption{}),
-	)
-
-	slog.Debug("Generated stack name pattern for matching", "pattern", pattern)
-
-	re, err := regexp.Compile(pattern)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to compile regex pattern '%s': %w", pattern, err)
-	}
-
-	var databases []displayDBInfo
-
-	verifyOption := cfn.MetadataVerifyOption{}
-
-	evaluator := func(stackName string) bool {
-		if !re.MatchString(stackName) {
-			slog.Debug("Stack name does not match pattern")
-
-			return false
-		}
-
-		metadata, err := k.cfn.GetKTNHMetadata(stackName)
-
-		if err != nil {
-			slog.Warn("Failed to retrieve metadata for stack during evaluation",
-				"stackName", stackName,
-				"error", err,
-			)
-
-			return false
+func splitDBsByType(databases []displayDBInfo) (clusters []string, instances []string) {
+	for _, db := range databases {
+		if db.dbType == "aurora" {
+			clusters = append(clusters, db.dbIdentifier)
+		} else {
+			instances = append(instances, db.dbIdentifier)
 		}
+	}
 
-		isMatched, err := cfn.VerifyMetadata(metadata, &verifyOption)
-
-		if err != nil {
-			slog.Warn("Failed to verify metadata for stack during evaluation",
-				"stackName", stackName,
-				"error", err,
-			)
+	return
+}
 
-			return false
-		}
+/*
+rdsKey returns the key of the database in maps returned by `internal/pkg/rds`
+(e.g., "cluster:my-cluster" or "db:my-instance").
+*/
+func rdsKey(db displayDBInfo) string {
+	if db.dbType == "aurora" {
+		return "cluster:" + db.dbIdentifier
+	}
 
-		if !isMatched {
-			slog.Debug("Stack metadata does not match criteria")
+	return "db:" + db.dbIdentifier
+}
 
-			return false
-		}
+/*
+orUnknown returns "(unknown)" if the value is empty.
+*/
+func orUnknown(value string) string {
+	if value == "" {
+		return unknownValue
+	}
 
-		databases = append(databases, displayDBInfo{
-			dbIdentifier: metadata.DBIdentifier,
-			dbType:       metadata.DBType,
-			stackName:    stackName,
-		})
+	return value
+}
 
-		return true
-	}
+/*
+collectManagedDatabases finds all databases managed by ktnh.
+*/
+func (k *ktnh) collectManagedDatabases() ([]displayDBInfo, error) {
+	slog.Debug("Finding all managed databases")
 
-	_, err = k.cfn.ListStacks(evaluator)
+	databases, err := k.findStacks(&stackSearchOption{})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to list CloudFormation stacks: %w", err)
+		return nil, err
 	}
 
 	slog.Debug("Found managed databases", "count", len(databases))
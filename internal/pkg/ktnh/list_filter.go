@@ -0,0 +1,206 @@
+package ktnh
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+/*
+listFilter selects databases matching all the conditions given to List.
+*/
+type listFilter struct {
+	predicates []func(db displayDBInfo) bool // conditions that databases must satisfy
+	sources    map[listDataSource]bool       // data required to evaluate the conditions
+}
+
+/*
+listSorter sorts databases by the value of a column.
+*/
+type listSorter struct {
+	column     listColumn // column to sort by
+	descending bool       // whether to sort in descending order
+}
+
+/*
+newListFilter builds a filter from the options.
+It returns an error if an option has an invalid value.
+*/
+func newListFilter(option *ListOption) (*listFilter, error) {
+	filter := &listFilter{
+		sources: map[listDataSource]bool{},
+	}
+
+	add := func(predicate func(db displayDBInfo) bool, sources ...listDataSource) {
+		filter.predicates = append(filter.predicates, predicate)
+
+		for _, source := range sources {
+			filter.sources[source] = true
+		}
+	}
+
+	if option.Type != "" {
+		if !slices.Contains([]string{"aurora", "rds"}, option.Type) {
+			return nil, fmt.Errorf("invalid type '%s': must be 'aurora' or 'rds'", option.Type)
+		}
+
+		add(func(db displayDBInfo) bool {
+			return db.dbType == option.Type
+		})
+	}
+
+	if option.Maintenance != "" {
+		if !slices.Contains([]string{rds.MaintenanceRequired, rds.MaintenanceAvailable, maintenancePending, maintenanceNone}, option.Maintenance) {
+			return nil, fmt.Errorf("invalid maintenance status '%s': must be 'required', 'available', 'pending' or 'none'", option.Maintenance)
+		}
+
+		add(func(db displayDBInfo) bool {
+			if option.Maintenance == maintenancePending {
+				return db.hasMaintenance
+			}
+
+			return db.maintenance == option.Maintenance
+		}, sourceMaintenance)
+	}
+
+	if option.Status != "" {
+		add(func(db displayDBInfo) bool {
+			return (db.details != nil) && strings.EqualFold(db.details.Status, option.Status)
+		}, sourceDBDetails)
+	}
+
+	if option.NameRegex != "" {
+		re, err := regexp.Compile(option.NameRegex)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid name regex '%s': %w", option.NameRegex, err)
+		}
+
+		add(func(db displayDBInfo) bool {
+			return re.MatchString(db.dbIdentifier)
+		})
+	}
+
+	if 0 < len(option.Tags) {
+		add(func(db displayDBInfo) bool {
+			if db.details == nil {
+				return false
+			}
+
+			for key, value := range option.Tags {
+				if actual, ok := db.details.Tags[key]; !ok || actual != value {
+					return false
+				}
+			}
+
+			return true
+		}, sourceDBDetails)
+	}
+
+	if option.StackStatus != "" {
+		add(func(db displayDBInfo) bool {
+			return (db.stack != nil) && strings.EqualFold(db.stack.Status, option.StackStatus)
+		}, sourceStacks)
+	}
+
+	return filter, nil
+}
+
+/*
+apply returns the databases satisfying all the conditions, keeping their order.
+*/
+func (f *listFilter) apply(databases []displayDBInfo) []displayDBInfo {
+	return slices.DeleteFunc(databases, func(db displayDBInfo) bool {
+		for _, predicate := range f.predicates {
+			if !predicate(db) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+/*
+newListSorter builds a sorter from the column name, which may be prefixed with "-" for descending order.
+It returns nil if no column is specified.
+*/
+func newListSorter(sortBy string) (*listSorter, error) {
+	if sortBy == "" {
+		return nil, nil
+	}
+
+	name, descending := strings.CutPrefix(sortBy, "-")
+
+	index := slices.IndexFunc(listColumns, func(column listColumn) bool {
+		return column.name == name
+	})
+
+	if index < 0 {
+		return nil, fmt.Errorf("unknown sort column '%s' (available: %s)", name, strings.Join(ListColumns(), ", "))
+	}
+
+	return &listSorter{
+		column:     listColumns[index],
+		descending: descending,
+	}, nil
+}
+
+/*
+apply sorts the databases in place. Databases with equal values keep their order.
+*/
+func (s *listSorter) apply(databases []displayDBInfo) {
+	slices.SortStableFunc(databases, func(a, b displayDBInfo) int {
+		result := compareListValues(s.column.value(a), s.column.value(b))
+
+		if s.descending {
+			return -result
+		}
+
+		return result
+	})
+}
+
+/*
+compareListValues compares two column values.
+Timestamps and numbers are compared by value (a missing timestamp sorts first), and other values as text.
+*/
+func compareListValues(a, b any) int {
+	if timeA, ok := asTime(a); ok {
+		if timeB, ok := asTime(b); ok {
+			return timeA.Compare(timeB)
+		}
+	}
+
+	if intA, ok := a.(int); ok {
+		if intB, ok := b.(int); ok {
+			return cmp.Compare(intA, intB)
+		}
+	}
+
+	return strings.Compare(output.FormatValue(a), output.FormatValue(b))
+}
+
+/*
+asTime converts a timestamp value into time.Time; a nil `*time.Time` is converted into the zero time.
+*/
+func asTime(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, true
+		}
+
+		return *v, true
+	default:
+		return time.Time{}, false
+	}
+}
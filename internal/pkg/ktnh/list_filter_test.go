@@ -0,0 +1,221 @@
+package ktnh
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	appcfn "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	apprds "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+func Test_listFilter(t *testing.T) {
+	databases := []displayDBInfo{
+		{
+			dbIdentifier:   "app-db1",
+			dbType:         "aurora",
+			maintenance:    "required",
+			hasMaintenance: true,
+			stack:          &appcfn.Stack{Status: "CREATE_COMPLETE"},
+			details: &apprds.DBDetails{
+				Status: "stopped",
+				Tags:   map[string]string{"env": "dev", "team": "a"},
+			},
+		},
+		{
+			dbIdentifier: "app-db2",
+			dbType:       "rds",
+			maintenance:  "none",
+			stack:        &appcfn.Stack{Status: "ROLLBACK_COMPLETE"},
+			details: &apprds.DBDetails{
+				Status: "available",
+				Tags:   map[string]string{"env": "prd"},
+			},
+		},
+		{
+			dbIdentifier: "other-db3",
+			dbType:       "rds",
+			maintenance:  "none",
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		option          *ListOption
+		expected        []string
+		expectedSources map[listDataSource]bool
+		wantErr         bool
+	}{
+		{
+			name:            "No filters",
+			option:          &ListOption{},
+			expected:        []string{"app-db1", "app-db2", "other-db3"},
+			expectedSources: map[listDataSource]bool{},
+			wantErr:         false,
+		},
+		{
+			name:            "Type and name",
+			option:          &ListOption{Type: "rds", NameRegex: "^app-"},
+			expected:        []string{"app-db2"},
+			expectedSources: map[listDataSource]bool{},
+			wantErr:         false,
+		},
+		{
+			name:            "Maintenance",
+			option:          &ListOption{Maintenance: "pending"},
+			expected:        []string{"app-db1"},
+			expectedSources: map[listDataSource]bool{sourceMaintenance: true},
+			wantErr:         false,
+		},
+		{
+			name:            "Maintenance category",
+			option:          &ListOption{Maintenance: "available"},
+			expected:        []string{},
+			expectedSources: map[listDataSource]bool{sourceMaintenance: true},
+			wantErr:         false,
+		},
+		{
+			name:            "Status is case-insensitive",
+			option:          &ListOption{Status: "STOPPED"},
+			expected:        []string{"app-db1"},
+			expectedSources: map[listDataSource]bool{sourceDBDetails: true},
+			wantErr:         false,
+		},
+		{
+			name:            "Tags",
+			option:          &ListOption{Tags: map[string]string{"env": "dev", "team": "a"}},
+			expected:        []string{"app-db1"},
+			expectedSources: map[listDataSource]bool{sourceDBDetails: true},
+			wantErr:         false,
+		},
+		{
+			name:            "Stack status",
+			option:          &ListOption{StackStatus: "rollback_complete"},
+			expected:        []string{"app-db2"},
+			expectedSources: map[listDataSource]bool{sourceStacks: true},
+			wantErr:         false,
+		},
+		{
+			name:     "Invalid type",
+			option:   &ListOption{Type: "docdb"},
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid maintenance status",
+			option:   &ListOption{Maintenance: "scheduled"},
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid name regex",
+			option:   &ListOption{NameRegex: "[invalid"},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := newListFilter(tc.option)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+
+				return
+			}
+
+			assert.NoError(t, err, "Unexpected error occurred")
+
+			assert.Equal(t, tc.expectedSources, filter.sources, "Required data sources do not match")
+
+			got := filter.apply(slices.Clone(databases))
+
+			identifiers := make([]string, len(got))
+
+			for i, db := range got {
+				identifiers[i] = db.dbIdentifier
+			}
+
+			assert.Equal(t, tc.expected, identifiers, "Filtered databases do not match")
+		})
+	}
+}
+
+func Test_listSorter(t *testing.T) {
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := date.Add(time.Hour)
+
+	databases := []displayDBInfo{
+		{dbIdentifier: "db-b", stack: &appcfn.Stack{CreationTime: later}, lastStopKnown: true, lastStop: &date},
+		{dbIdentifier: "db-c", stack: &appcfn.Stack{CreationTime: date}, lastStopKnown: true},
+		{dbIdentifier: "db-a", stack: &appcfn.Stack{CreationTime: later}, lastStopKnown: true, lastStop: &later},
+	}
+
+	testCases := []struct {
+		name     string
+		sortBy   string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "Ascending by text",
+			sortBy:   "id",
+			expected: []string{"db-a", "db-b", "db-c"},
+			wantErr:  false,
+		},
+		{
+			name:     "Descending by time",
+			sortBy:   "-last-stopped",
+			expected: []string{"db-a", "db-b", "db-c"},
+			wantErr:  false,
+		},
+		{
+			name:     "Stable for equal values",
+			sortBy:   "frozen-since",
+			expected: []string{"db-c", "db-b", "db-a"},
+			wantErr:  false,
+		},
+		{
+			name:     "Unknown column",
+			sortBy:   "unknown",
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sorter, err := newListSorter(tc.sortBy)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+
+				return
+			}
+
+			assert.NoError(t, err, "Unexpected error occurred")
+
+			got := slices.Clone(databases)
+
+			sorter.apply(got)
+
+			identifiers := make([]string, len(got))
+
+			for i, db := range got {
+				identifiers[i] = db.dbIdentifier
+			}
+
+			assert.Equal(t, tc.expected, identifiers, "Sorted databases do not match")
+		})
+	}
+
+	t.Run("No column", func(t *testing.T) {
+		sorter, err := newListSorter("")
+
+		assert.NoError(t, err, "Unexpected error occurred")
+		assert.Nil(t, sorter, "No sorter should be returned")
+	})
+}
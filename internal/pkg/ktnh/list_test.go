@@ -3,6 +3,7 @@ package ktnh
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
@@ -25,7 +26,8 @@ func Test_List(t *testing.T) {
 		mockGetTemplateSetup                       func(*appmock.MockCloudFormationFactory, *appmock.MockCloudFormationClient)
 		mockDescribeDBClustersSetup                func(*appmock.MockRDSFactory, *appmock.MockDescribeDBClustersPaginator)
 		mockDescribePendingMaintenanceActionsSetup func(*appmock.MockRDSFactory, *appmock.MockDescribePendingMaintenanceActionsPaginator)
-		expected                                   [][]string
+		mockDescribeStacksSetup                    func(*appmock.MockCloudFormationFactory, *appmock.MockDescribeStacksPaginator)
+		expected                                   [][]any
 		wantErr                                    bool
 	}{
 		{
@@ -144,7 +146,8 @@ func Test_List(t *testing.T) {
 				}
 
 				f.On("NewDescribeDBClustersPaginator", params).
-					Return(p, nil)
+					Return(p, nil).
+					Once()
 
 				p.On("HasMorePages").
 					Return(true).
@@ -207,9 +210,42 @@ func Test_List(t *testing.T) {
 					Return(false).
 					Once()
 			},
-			expected: [][]string{
-				{"db1", "aurora", "A-db1-abcdef", "pending"},
-				{"db4", "rds", "A-db4-stuvwx", "none"},
+			mockDescribeStacksSetup: func(f *appmock.MockCloudFormationFactory, p *appmock.MockDescribeStacksPaginator) {
+				f.On("NewDescribeStacksPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				result := &cloudformation.DescribeStacksOutput{
+					Stacks: []cfntypes.Stack{
+						{
+							StackName: aws.String("A-db1-abcdef"),
+							Parameters: []cfntypes.Parameter{
+								{
+									ParameterKey:   aws.String("ProtectionState"),
+									ParameterValue: aws.String("DISABLED"),
+								},
+							},
+						},
+						{
+							StackName: aws.String("A-db4-stuvwx"),
+						},
+					},
+				}
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(result, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			expected: [][]any{
+				{"db1", "aurora", "A-db1-abcdef", "paused", "available"},
+				{"db4", "rds", "A-db4-stuvwx", "active", "none"},
 			},
 			wantErr: false,
 		},
@@ -239,7 +275,7 @@ func Test_List(t *testing.T) {
 			mockGetTemplateSetup:                       func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {},
 			mockDescribeDBClustersSetup:                func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBClustersPaginator) {},
 			mockDescribePendingMaintenanceActionsSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribePendingMaintenanceActionsPaginator) {},
-			expected: [][]string{},
+			expected: [][]any{},
 			wantErr:  false,
 		},
 		{
@@ -343,7 +379,8 @@ func Test_List(t *testing.T) {
 				}
 
 				f.On("NewDescribeDBClustersPaginator", params).
-					Return(p, nil)
+					Return(p, nil).
+					Once()
 
 				p.On("HasMorePages").
 					Return(true).
@@ -394,8 +431,8 @@ func Test_List(t *testing.T) {
 					Return(false).
 					Once()
 			},
-			expected: [][]string{
-				{"db2", "aurora", "D-db2-ghijkl", "none"},
+			expected: [][]any{
+				{"db2", "aurora", "D-db2-ghijkl", "(unknown)", "none"},
 			},
 			wantErr: false,
 		},
@@ -487,7 +524,8 @@ func Test_List(t *testing.T) {
 				}
 
 				f.On("NewDescribeDBClustersPaginator", params).
-					Return(p, nil)
+					Return(p, nil).
+					Once()
 
 				p.On("HasMorePages").
 					Return(true).
@@ -538,8 +576,8 @@ func Test_List(t *testing.T) {
 					Return(false).
 					Once()
 			},
-			expected: [][]string{
-				{"db2", "aurora", "E-db2-ghijkl", "none"},
+			expected: [][]any{
+				{"db2", "aurora", "E-db2-ghijkl", "(unknown)", "none"},
 			},
 			wantErr: false,
 		},
@@ -611,8 +649,8 @@ func Test_List(t *testing.T) {
 					Return(nil, assert.AnError)
 			},
 			mockDescribePendingMaintenanceActionsSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribePendingMaintenanceActionsPaginator) {},
-			expected: [][]string{
-				{"db1", "aurora", "F-db1-abcdef", "(unknown)"},
+			expected: [][]any{
+				{"db1", "aurora", "F-db1-abcdef", "(unknown)", "(unknown)"},
 			},
 			wantErr: false,
 		},
@@ -688,8 +726,8 @@ func Test_List(t *testing.T) {
 				f.On("NewDescribePendingMaintenanceActionsPaginator", params).
 					Return(nil, assert.AnError)
 			},
-			expected: [][]string{
-				{"db1", "rds", "G-db1-abcdef", "(unknown)"},
+			expected: [][]any{
+				{"db1", "rds", "G-db1-abcdef", "(unknown)", "(unknown)"},
 			},
 			wantErr: false,
 		},
@@ -703,26 +741,45 @@ func Test_List(t *testing.T) {
 			mockFactoryCloudFormation := new(appmock.MockCloudFormationFactory)
 			mockClientCloudFormation := new(appmock.MockCloudFormationClient)
 			mockListStacksPaginator := new(appmock.MockListStacksPaginator)
+			mockDescribeStacksPaginator := new(appmock.MockDescribeStacksPaginator)
+
+			if tc.mockDescribeStacksSetup != nil {
+				tc.mockDescribeStacksSetup(mockFactoryCloudFormation, mockDescribeStacksPaginator)
+			} else {
+				mockFactoryCloudFormation.On("NewDescribeStacksPaginator", mock.Anything).
+					Return(nil, assert.AnError).
+					Maybe()
+			}
 
 			tc.mockDescribeDBClustersSetup(mockFactoryRDS, mockDescribeDBClustersPaginator)
 			tc.mockDescribePendingMaintenanceActionsSetup(mockFactoryRDS, mockDescribePendingMaintenanceActionsPaginator)
 			tc.mockListStacksSetup(mockFactoryCloudFormation, mockListStacksPaginator)
 			tc.mockGetTemplateSetup(mockFactoryCloudFormation, mockClientCloudFormation)
 
+			// NOTE: DB details are retrieved after the maintenance status, which uses up the expectations above,
+			//       and fail by default.
+			mockFactoryRDS.On("NewDescribeDBClustersPaginator", mock.Anything).
+				Return(nil, assert.AnError).
+				Maybe()
+
+			mockFactoryRDS.On("NewDescribeDBInstancesPaginator", mock.Anything).
+				Return(nil, assert.AnError).
+				Maybe()
+
 			k := &ktnh{
 				stackNamePrefix: tc.stackNamePrefix,
 				rds:             apprds.NewRDS(mockFactoryRDS),
 				cfn:             appcfn.NewCloudFormation(mockFactoryCloudFormation),
 			}
 
-			_, got, err := k.List()
+			got, err := k.List(&ListOption{})
 
 			if tc.wantErr {
 				assert.Error(t, err, "Expected an error to be returned")
 			} else {
 				assert.NoError(t, err, "Unexpected error occurred")
 
-				assert.Equal(t, tc.expected, got, "Output body does not match expected body")
+				assert.Equal(t, tc.expected, got.Rows, "Output body does not match expected body")
 			}
 
 			mockFactoryRDS.AssertExpectations(t)
@@ -731,6 +788,163 @@ func Test_List(t *testing.T) {
 			mockFactoryCloudFormation.AssertExpectations(t)
 			mockClientCloudFormation.AssertExpectations(t)
 			mockListStacksPaginator.AssertExpectations(t)
+			mockDescribeStacksPaginator.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_resolveListColumns(t *testing.T) {
+	testCases := []struct {
+		name     string
+		columns  []string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "Default columns",
+			columns:  nil,
+			expected: []string{"id", "type", "stack", "state", "maintenance"},
+			wantErr:  false,
+		},
+		{
+			name:     "Selected columns",
+			columns:  []string{"id", "last-stopped", "engine"},
+			expected: []string{"id", "last-stopped", "engine"},
+			wantErr:  false,
+		},
+		{
+			name:     "All columns",
+			columns:  []string{"all"},
+			expected: ListColumns(),
+			wantErr:  false,
+		},
+		{
+			name:     "Unknown column",
+			columns:  []string{"id", "unknown"},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveListColumns(tc.columns)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				names := make([]string, len(got))
+
+				for i, column := range got {
+					names[i] = column.name
+				}
+
+				assert.Equal(t, tc.expected, names, "Resolved columns do not match expected columns")
+			}
 		})
 	}
 }
+
+func Test_convertDBsToTable(t *testing.T) {
+	creationTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	databases := []displayDBInfo{
+		{
+			dbIdentifier: "db1",
+			dbType:       "aurora",
+			stackName:    "A-db1-abcdef",
+			state:        "active",
+			maintenance:  "none",
+			stack: &appcfn.Stack{
+				Status:       "CREATE_COMPLETE",
+				CreationTime: creationTime,
+			},
+			details: &apprds.DBDetails{
+				Status:        "stopped",
+				Engine:        "aurora-postgresql",
+				EngineVersion: "16.4",
+				MemberCount:   2,
+			},
+			lastStopKnown: true,
+		},
+		{
+			dbIdentifier: "db2",
+			dbType:       "rds",
+			stackName:    "A-db2-ghijkl",
+			state:        "(unknown)",
+			details: &apprds.DBDetails{
+				Status:        "available",
+				Engine:        "mysql",
+				EngineVersion: "8.0.39",
+				InstanceClass: "db.t4g.micro",
+			},
+		},
+	}
+
+	columns, err := resolveListColumns([]string{"all"})
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	got := convertDBsToTable(databases, columns)
+
+	assert.Equal(t, []string{"id", "type", "stack", "state", "maintenance", "status", "engine", "class", "stack-status", "frozen-since", "last-stopped"}, got.Headers, "Headers do not match expected headers")
+
+	assert.Equal(t, [][]any{
+		{"db1", "aurora", "A-db1-abcdef", "active", "none", "stopped", "aurora-postgresql 16.4", "2 members", "CREATE_COMPLETE", creationTime, (*time.Time)(nil)},
+		{"db2", "rds", "A-db2-ghijkl", "(unknown)", "(unknown)", "available", "mysql 8.0.39", "db.t4g.micro", "(unknown)", "(unknown)", "(unknown)"},
+	}, got.Rows, "Body does not match expected body")
+}
+
+func Test_updateDBDetails(t *testing.T) {
+	mockFactoryRDS := new(appmock.MockRDSFactory)
+	mockDescribeDBInstancesPaginator := new(appmock.MockDescribeDBInstancesPaginator)
+
+	mockFactoryRDS.On("NewDescribeDBInstancesPaginator", mock.Anything).
+		Return(mockDescribeDBInstancesPaginator, nil)
+
+	mockDescribeDBInstancesPaginator.On("HasMorePages").
+		Return(true).
+		Once()
+
+	mockDescribeDBInstancesPaginator.On("NextPage", mock.Anything, mock.Anything).
+		Return(&rds.DescribeDBInstancesOutput{
+			DBInstances: []rdstypes.DBInstance{
+				{
+					DBInstanceIdentifier: aws.String("db1"),
+					DBInstanceStatus:     aws.String("stopped"),
+				},
+			},
+		}, nil).
+		Once()
+
+	mockDescribeDBInstancesPaginator.On("HasMorePages").
+		Return(false).
+		Once()
+
+	k := &ktnh{
+		rds: apprds.NewRDS(mockFactoryRDS),
+	}
+
+	got, err := k.updateDBDetails([]displayDBInfo{
+		{dbIdentifier: "db1", dbType: "rds"},
+		{dbIdentifier: "db2", dbType: "rds"},
+	})
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	assert.False(t, got[0].orphaned, "Existing DB should not be orphaned")
+	assert.Equal(t, "stopped", got[0].details.Status, "DB status does not match")
+
+	assert.True(t, got[1].orphaned, "Deleted DB should be orphaned")
+	assert.Nil(t, got[1].details, "Deleted DB should have no details")
+
+	columns, err := resolveListColumns([]string{"state"})
+
+	assert.NoError(t, err, "Unexpected error occurred")
+	assert.Equal(t, "orphaned", columns[0].value(got[1]), "State of deleted DB should be orphaned")
+
+	mockFactoryRDS.AssertExpectations(t)
+	mockDescribeDBInstancesPaginator.AssertExpectations(t)
+}
@@ -0,0 +1,134 @@
+package ktnh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/logs"
+)
+
+/*
+logsPollInterval is the interval between polls of the log group when following log events.
+*/
+var logsPollInterval = 5 * time.Second
+
+/*
+stateMachineLogEvent is the subset of a Step Functions execution log event used for display.
+*/
+type stateMachineLogEvent struct {
+	Type         string `json:"type"`          // event type (e.g., "TaskStateEntered")
+	ExecutionArn string `json:"execution_arn"` // ARN of the execution
+	Details      struct {
+		Name  string `json:"name"`  // name of the state
+		Error string `json:"error"` // error code of a failure
+		Cause string `json:"cause"` // cause of a failure
+	} `json:"details"`
+}
+
+/*
+Logs reads the log events of the state machine written at or after the given time
+and passes them to the handler in chronological order.
+If follow is true, it keeps polling the log group for new events until the context is canceled.
+*/
+func (k *ktnh) Logs(ctx context.Context, since time.Time, follow bool, handler func(logs.LogEvent)) error {
+	logGroupName, err := k.findStackResource(cfn.LogicalIDStateMachineLogGroup)
+
+	if err != nil {
+		return fmt.Errorf("failed to find log group: %w", err)
+	}
+
+	slog.Debug("Reading log events", "logGroupName", logGroupName)
+
+	return k.streamLogEvents(ctx, logGroupName, since, follow, handler)
+}
+
+/*
+streamLogEvents polls the log group and passes each event to the handler exactly once.
+Since the start time of a query is inclusive, events sharing the timestamp of the last
+event seen are returned again by the next poll, so they are skipped by their IDs.
+*/
+func (k *ktnh) streamLogEvents(ctx context.Context, logGroupName string, since time.Time, follow bool, handler func(logs.LogEvent)) error {
+	seen := map[string]bool{}
+
+	for {
+		events, err := k.logs.FilterLogEvents(logGroupName, since)
+
+		if err != nil {
+			return fmt.Errorf("failed to filter log events: %w", err)
+		}
+
+		for _, event := range events {
+			if seen[event.ID] {
+				continue
+			}
+
+			if event.Timestamp.After(since) {
+				since = event.Timestamp
+
+				clear(seen)
+			}
+
+			seen[event.ID] = true
+
+			handler(event)
+		}
+
+		if !follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logsPollInterval):
+		}
+	}
+}
+
+/*
+FormatLogEvent formats a log event of the state machine as a single line for display.
+Messages that are not Step Functions execution log events are returned as is.
+*/
+func FormatLogEvent(event logs.LogEvent) string {
+	var message stateMachineLogEvent
+
+	if err := json.Unmarshal([]byte(event.Message), &message); err != nil || message.Type == "" {
+		return fmt.Sprintf("%s  %s", formatTime(event.Timestamp), strings.TrimSpace(event.Message))
+	}
+
+	items := []string{
+		formatTime(event.Timestamp),
+		executionName(message.ExecutionArn),
+		message.Type,
+	}
+
+	if message.Details.Name != "" {
+		items = append(items, message.Details.Name)
+	}
+
+	if message.Details.Error != "" {
+		items = append(items, fmt.Sprintf("error=%s", message.Details.Error))
+	}
+
+	if message.Details.Cause != "" {
+		items = append(items, fmt.Sprintf("cause=%s", message.Details.Cause))
+	}
+
+	return strings.Join(items, "  ")
+}
+
+/*
+executionName extracts the execution name from an execution ARN.
+*/
+func executionName(executionArn string) string {
+	if executionArn == "" {
+		return "-"
+	}
+
+	return executionArn[strings.LastIndex(executionArn, ":")+1:]
+}
@@ -0,0 +1,187 @@
+package ktnh
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/logs"
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_streamLogEvents(t *testing.T) {
+	originalInterval := logsPollInterval
+
+	logsPollInterval = time.Millisecond
+
+	defer func() {
+		logsPollInterval = originalInterval
+	}()
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	filteredEvent := func(id string, timestamp time.Time) types.FilteredLogEvent {
+		return types.FilteredLogEvent{
+			EventId:   aws.String(id),
+			Timestamp: aws.Int64(timestamp.UnixMilli()),
+			Message:   aws.String(id),
+		}
+	}
+
+	setupPoll := func(f *appmock.MockCloudWatchLogsFactory, startTime time.Time, events ...types.FilteredLogEvent) {
+		p := new(appmock.MockFilterLogEventsPaginator)
+
+		f.On("NewFilterLogEventsPaginator", &cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName: aws.String("lg-1"),
+			StartTime:    aws.Int64(startTime.UnixMilli()),
+		}).
+			Return(p, nil).
+			Once()
+
+		p.On("HasMorePages").
+			Return(true).
+			Once()
+
+		p.On("NextPage", mock.Anything, mock.Anything).
+			Return(&cloudwatchlogs.FilterLogEventsOutput{Events: events}, nil).
+			Once()
+
+		p.On("HasMorePages").
+			Return(false).
+			Once()
+	}
+
+	testCases := []struct {
+		name      string
+		follow    bool
+		mockSetup func(*appmock.MockCloudWatchLogsFactory)
+		expected  []string
+		wantErr   bool
+	}{
+		{
+			name:   "Without follow",
+			follow: false,
+			mockSetup: func(f *appmock.MockCloudWatchLogsFactory) {
+				setupPoll(f, since,
+					filteredEvent("e-1", since),
+					filteredEvent("e-2", since.Add(time.Second)),
+				)
+			},
+			expected: []string{"e-1", "e-2"},
+			wantErr:  false,
+		},
+		{
+			name:   "Follow skips events already seen",
+			follow: true,
+			mockSetup: func(f *appmock.MockCloudWatchLogsFactory) {
+				setupPoll(f, since,
+					filteredEvent("e-1", since),
+					filteredEvent("e-2", since.Add(time.Second)),
+				)
+
+				setupPoll(f, since.Add(time.Second),
+					filteredEvent("e-2", since.Add(time.Second)),
+					filteredEvent("e-3", since.Add(time.Second)),
+				)
+
+				setupPoll(f, since.Add(time.Second),
+					filteredEvent("e-2", since.Add(time.Second)),
+					filteredEvent("e-3", since.Add(time.Second)),
+					filteredEvent("e-4", since.Add(2*time.Second)),
+				)
+			},
+			expected: []string{"e-1", "e-2", "e-3", "e-4"},
+			wantErr:  false,
+		},
+		{
+			name:   "API error",
+			follow: true,
+			mockSetup: func(f *appmock.MockCloudWatchLogsFactory) {
+				f.On("NewFilterLogEventsPaginator", mock.Anything).
+					Return(nil, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudWatchLogsFactory)
+
+			tc.mockSetup(mockFactory)
+
+			k := &ktnh{
+				logs: logs.NewCloudWatchLogs(mockFactory),
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			defer cancel()
+
+			var got []string
+
+			err := k.streamLogEvents(ctx, "lg-1", since, tc.follow, func(event logs.LogEvent) {
+				got = append(got, event.ID)
+
+				if len(got) == len(tc.expected) {
+					cancel()
+				}
+			})
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Log events do not match")
+			}
+
+			mockFactory.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_FormatLogEvent(t *testing.T) {
+	timestamp := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{
+			name:     "State entered",
+			message:  `{"id":"3","type":"TaskStateEntered","details":{"name":"StopDB","input":"{}"},"execution_arn":"arn:aws:states:ap-northeast-1:123456789012:execution:sm-1:exec-1"}`,
+			expected: formatTime(timestamp) + "  exec-1  TaskStateEntered  StopDB",
+		},
+		{
+			name:     "Execution failed",
+			message:  `{"id":"9","type":"ExecutionFailed","details":{"error":"States.TaskFailed","cause":"AccessDenied"},"execution_arn":"arn:aws:states:ap-northeast-1:123456789012:execution:sm-1:exec-2"}`,
+			expected: formatTime(timestamp) + "  exec-2  ExecutionFailed  error=States.TaskFailed  cause=AccessDenied",
+		},
+		{
+			name:     "Not a state machine event",
+			message:  "plain text\n",
+			expected: formatTime(timestamp) + "  plain text",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FormatLogEvent(logs.LogEvent{
+				ID:        "e-1",
+				Timestamp: timestamp,
+				Message:   tc.message,
+			})
+
+			assert.Equal(t, tc.expected, got, "Formatted log event does not match")
+		})
+	}
+}
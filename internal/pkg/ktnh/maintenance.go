@@ -0,0 +1,182 @@
+package ktnh
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+const (
+	maintenancePending = "pending" // there are pending maintenance actions, either required or available
+	maintenanceNone    = "none"    // there are no pending maintenance actions
+)
+
+/*
+MaintenanceReport holds the pending maintenance actions of databases.
+*/
+type MaintenanceReport struct {
+	Actions []PendingAction `json:"actions"` // pending maintenance actions, grouped by database
+}
+
+/*
+PendingAction holds a pending maintenance action together with the database it belongs to.
+For Aurora clusters, the resource is either the cluster itself or one of its member instances.
+*/
+type PendingAction struct {
+	DBIdentifier     string     `json:"id"`               // DB cluster/instance identifier
+	DBType           string     `json:"type"`             // type of the DB (see `internal/pkg/rds`)
+	ResourceType     string     `json:"resourceType"`     // type of the resource the action applies to ("cluster" or "db")
+	Resource         string     `json:"resource"`         // identifier of the resource the action applies to
+	Action           string     `json:"action"`           // type of the action (e.g., "system-update")
+	Category         string     `json:"category"`         // "required" or "available"
+	AutoAppliedAfter *time.Time `json:"autoAppliedAfter"` // date after which the action is applied in the maintenance window
+	ForcedApplyDate  *time.Time `json:"forcedApplyDate"`  // date on which the action is applied regardless of the maintenance window
+	OptInStatus      string     `json:"optInStatus"`      // opt-in request of the action (empty if not opted in)
+	Description      string     `json:"description"`      // description of the action
+}
+
+/*
+Maintenance returns the pending maintenance actions of the database associated with the DB identifier,
+or of all databases managed under the stack name prefix if no DB identifier is given.
+*/
+func (k *ktnh) Maintenance() (*MaintenanceReport, error) {
+	databases, err := k.maintenanceTargets()
+
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := k.pendingActions(databases)
+
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Retrieved pending maintenance actions", "count", len(actions))
+
+	return &MaintenanceReport{
+		Actions: actions,
+	}, nil
+}
+
+/*
+pendingActions returns the pending maintenance actions of the databases, grouped by database.
+*/
+func (k *ktnh) pendingActions(databases []displayDBInfo) ([]PendingAction, error) {
+	if len(databases) == 0 {
+		return nil, nil
+	}
+
+	clusters, instances, clusterMembers, err := k.categorizeDBsByType(databases)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to categorize databases: %w", err)
+	}
+
+	pendingMaintenance, err := k.rds.DescribePendingMaintenanceActions(clusters, instances, clusterMembers)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending maintenance actions: %w", err)
+	}
+
+	var actions []PendingAction
+
+	for _, db := range databases {
+		for _, action := range pendingMaintenance[rdsKey(db)] {
+			actions = append(actions, PendingAction{
+				DBIdentifier:     db.dbIdentifier,
+				DBType:           db.dbType,
+				ResourceType:     action.ResourceType,
+				Resource:         action.ResourceID,
+				Action:           action.Action,
+				Category:         action.Category(),
+				AutoAppliedAfter: action.AutoAppliedAfter,
+				ForcedApplyDate:  action.ForcedApplyDate,
+				OptInStatus:      action.OptInStatus,
+				Description:      action.Description,
+			})
+		}
+	}
+
+	return actions, nil
+}
+
+/*
+summarizePendingActions summarizes the pending maintenance actions of a database
+in the same way as `maintenanceCategory`.
+*/
+func summarizePendingActions(actions []PendingAction) string {
+	if len(actions) == 0 {
+		return maintenanceNone
+	}
+
+	for _, action := range actions {
+		if action.Category == rds.MaintenanceRequired {
+			return rds.MaintenanceRequired
+		}
+	}
+
+	return rds.MaintenanceAvailable
+}
+
+/*
+maintenanceTargets returns the databases whose pending maintenance actions are reported.
+A single DB does not have to be managed by ktnh, in the same way as `Status`.
+*/
+func (k *ktnh) maintenanceTargets() ([]displayDBInfo, error) {
+	if k.dbIdentifier == "" {
+		databases, err := k.collectManagedDatabases()
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect managed databases: %w", err)
+		}
+
+		return databases, nil
+	}
+
+	dbType, err := k.rds.DetermineDBType(k.dbIdentifier)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine DB type: %w", err)
+	}
+
+	return []displayDBInfo{
+		{
+			dbIdentifier: k.dbIdentifier,
+			dbType:       string(dbType),
+		},
+	}, nil
+}
+
+/*
+Tables converts the report into a table for display.
+*/
+func (r *MaintenanceReport) Tables() []output.Table {
+	table := output.Table{
+		Headers: []string{"id", "resource", "action", "category", "auto-applied-after", "forced-apply", "opt-in", "description"},
+	}
+
+	for _, action := range r.Actions {
+		var optIn any
+
+		if action.OptInStatus != "" {
+			optIn = action.OptInStatus
+		}
+
+		table.Rows = append(table.Rows, []any{
+			action.DBIdentifier,
+			action.ResourceType + ":" + action.Resource,
+			action.Action,
+			action.Category,
+			action.AutoAppliedAfter,
+			action.ForcedApplyDate,
+			optIn,
+			action.Description,
+		})
+	}
+
+	return []output.Table{table}
+}
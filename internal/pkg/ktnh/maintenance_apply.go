@@ -0,0 +1,314 @@
+package ktnh
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+/*
+maintenancePollInterval is the interval between checks of the remaining maintenance actions.
+*/
+var maintenancePollInterval = 30 * time.Second
+
+/*
+ApplyMaintenance applies all pending maintenance actions of the database associated with the DB identifier.
+Protection is paused and the DB is started while the actions are applied, and both are restored afterwards,
+even if applying the actions fails.
+The timeout applies to each stack update and to starting the DB,
+while maintenanceTimeout applies to waiting for the actions to be applied.
+*/
+func (k *ktnh) ApplyMaintenance(timeout time.Duration, maintenanceTimeout time.Duration) error {
+	dbType, err := k.rds.DetermineDBType(k.dbIdentifier)
+
+	if err != nil {
+		return fmt.Errorf("failed to determine DB type: %w", err)
+	}
+
+	return k.applyMaintenance(string(dbType), timeout, maintenanceTimeout)
+}
+
+/*
+ApplyMaintenanceAll applies pending maintenance actions of all databases managed under the stack name prefix.
+Databases are processed one at a time, so that only one of them is running at any moment.
+A failure on one database does not prevent the others from being processed.
+*/
+func (k *ktnh) ApplyMaintenanceAll(timeout time.Duration, maintenanceTimeout time.Duration) error {
+	databases, err := k.collectManagedDatabases()
+
+	if err != nil {
+		return fmt.Errorf("failed to collect managed databases: %w", err)
+	}
+
+	if len(databases) == 0 {
+		slog.Info("No databases are currently being managed by ktnh")
+
+		return nil
+	}
+
+	databases, err = k.updateMaintenanceStatus(databases)
+
+	if err != nil {
+		return fmt.Errorf("failed to check pending maintenance actions: %w", err)
+	}
+
+	var (
+		applied int
+		errs    []error
+	)
+
+	for _, db := range databases {
+		if !db.hasMaintenance {
+			slog.Debug("No pending maintenance actions", "dbIdentifier", db.dbIdentifier)
+
+			continue
+		}
+
+		applied++
+
+		if err := k.forDatabase(db.dbIdentifier).applyMaintenance(db.dbType, timeout, maintenanceTimeout); err != nil {
+			slog.Warn("Failed to apply maintenance",
+				"dbIdentifier", db.dbIdentifier,
+				"error", err,
+			)
+
+			errs = append(errs, fmt.Errorf("DB '%s': %w", db.dbIdentifier, err))
+		}
+	}
+
+	slog.Info("Applied maintenance",
+		"databases", len(databases),
+		"withMaintenance", applied,
+		"failed", len(errs),
+	)
+
+	return errors.Join(errs...)
+}
+
+/*
+applyMaintenance pauses protection, starts the DB, applies the pending maintenance actions and waits
+until none remain, then stops the DB and resumes protection.
+The DB is stopped and protection is resumed only if they were changed by this function.
+*/
+func (k *ktnh) applyMaintenance(dbType string, timeout time.Duration, maintenanceTimeout time.Duration) (err error) {
+	stackName, found, err := k.findMatchingStackByType(dbType)
+
+	if err != nil {
+		return fmt.Errorf("failed to find matching stack: %w", err)
+	}
+
+	if !found {
+		return fmt.Errorf("no stacks found for DB identifier")
+	}
+
+	actions, err := k.pendingMaintenanceActions(dbType)
+
+	if err != nil {
+		return err
+	}
+
+	if len(actions) == 0 {
+		slog.Info("No pending maintenance actions", "dbIdentifier", k.dbIdentifier)
+
+		return nil
+	}
+
+	slog.Info("Applying maintenance", "dbIdentifier", k.dbIdentifier, "actions", len(actions))
+
+	deadline := time.Now().Add(timeout)
+
+	slog.Info("Pausing protection", "stackName", stackName)
+
+	paused, err := k.startProtectionStateUpdate(stackName, cfn.ProtectionStateDisabled)
+
+	if err != nil {
+		return fmt.Errorf("failed to pause protection: %w", err)
+	}
+
+	started := false
+
+	defer func() {
+		err = errors.Join(err, k.restoreAfterMaintenance(stackName, dbType, started, paused, timeout))
+	}()
+
+	if paused {
+		if err := k.cfn.WaitForStackUpdate(stackName, max(time.Until(deadline), time.Second)); err != nil {
+			return fmt.Errorf("failed while waiting for protection to be paused: %w", err)
+		}
+	}
+
+	status, err := k.rds.DescribeDBStatus(k.dbIdentifier, dbType)
+
+	if err != nil {
+		return fmt.Errorf("failed to describe DB status: %w", err)
+	}
+
+	switch status.Status {
+	case rds.StatusAvailable:
+		slog.Info("DB is already available")
+	case rds.StatusStopped:
+		slog.Info("Starting DB", "dbIdentifier", k.dbIdentifier)
+
+		if err := k.rds.StartDB(k.dbIdentifier, dbType); err != nil {
+			return fmt.Errorf("failed to start DB: %w", err)
+		}
+
+		started = true
+	default:
+		return fmt.Errorf("DB is '%s'; retry when it is stopped or available", status.Status)
+	}
+
+	slog.Info("Waiting for DB to be available", "timeout", time.Until(deadline).Seconds())
+
+	if err := k.rds.WaitForDBStatus(k.dbIdentifier, dbType, rds.StatusAvailable, max(time.Until(deadline), time.Second)); err != nil {
+		return fmt.Errorf("failed while waiting for DB to be available: %w", err)
+	}
+
+	var errs []error
+
+	for _, action := range actions {
+		slog.Info("Applying pending maintenance action",
+			"resource", action.ResourceID,
+			"action", action.Action,
+		)
+
+		if err := k.rds.ApplyPendingMaintenanceAction(action); err != nil {
+			errs = append(errs, fmt.Errorf("action '%s' on '%s': %w", action.Action, action.ResourceID, err))
+		}
+	}
+
+	if 0 < len(errs) {
+		return fmt.Errorf("failed to apply pending maintenance actions: %w", errors.Join(errs...))
+	}
+
+	slog.Info("Waiting for maintenance to complete", "timeout", maintenanceTimeout.Seconds())
+
+	return k.waitForMaintenance(dbType, time.Now().Add(maintenanceTimeout))
+}
+
+/*
+waitForMaintenance waits until no pending maintenance actions remain and the DB is available again.
+*/
+func (k *ktnh) waitForMaintenance(dbType string, deadline time.Time) error {
+	startTime := time.Now()
+
+	for {
+		actions, err := k.pendingMaintenanceActions(dbType)
+
+		if err != nil {
+			return err
+		}
+
+		status, err := k.rds.DescribeDBStatus(k.dbIdentifier, dbType)
+
+		if err != nil {
+			return fmt.Errorf("failed to describe DB status: %w", err)
+		}
+
+		if (len(actions) == 0) && (status.Status == rds.StatusAvailable) {
+			slog.Info("All pending maintenance actions have been applied")
+
+			return nil
+		}
+
+		if !time.Now().Add(maintenancePollInterval).Before(deadline) {
+			return fmt.Errorf("timed out waiting for maintenance to complete (remaining actions: %d, DB status: %s)", len(actions), status.Status)
+		}
+
+		slog.Info("Waiting for maintenance to complete",
+			"remainingActions", len(actions),
+			"status", status.Status,
+			"elapsed", time.Since(startTime).Seconds(),
+		)
+
+		time.Sleep(maintenancePollInterval)
+	}
+}
+
+/*
+restoreAfterMaintenance stops the DB if it was started for the maintenance, and resumes protection
+if it was paused for the maintenance. Both are attempted even if one of them fails.
+Since a DB can be stopped only while it is available, the DB is waited for before it is stopped
+(e.g., when the maintenance timed out while being applied).
+Likewise, the update pausing protection is waited for before protection is resumed,
+since the stack cannot be updated while it is still in progress.
+*/
+func (k *ktnh) restoreAfterMaintenance(stackName string, dbType string, started bool, paused bool, timeout time.Duration) error {
+	var errs []error
+
+	if started {
+		if err := k.stopAfterMaintenance(dbType, timeout); err != nil {
+			slog.Warn("Failed to stop DB; it will be stopped by the state machine once protection is resumed", "error", err)
+
+			errs = append(errs, fmt.Errorf("failed to stop DB: %w", err))
+		}
+	}
+
+	if !paused {
+		return errors.Join(errs...)
+	}
+
+	if err := k.cfn.WaitForStackUpdate(stackName, timeout); err != nil {
+		slog.Warn("Stack update pausing protection did not complete", "stackName", stackName, "error", err)
+	}
+
+	slog.Info("Resuming protection", "stackName", stackName)
+
+	resumed, err := k.startProtectionStateUpdate(stackName, cfn.ProtectionStateEnabled)
+
+	if err != nil {
+		errs = append(errs, fmt.Errorf("protection left paused on stack '%s'; run `ktnh resume %s`: %w", stackName, k.dbIdentifier, err))
+	} else if resumed {
+		if err := k.cfn.WaitForStackUpdate(stackName, timeout); err != nil {
+			errs = append(errs, fmt.Errorf("failed while waiting for protection to be resumed: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+/*
+stopAfterMaintenance waits for the DB to be available, and then stops it.
+*/
+func (k *ktnh) stopAfterMaintenance(dbType string, timeout time.Duration) error {
+	slog.Info("Waiting for DB to be available before stopping", "timeout", timeout.Seconds())
+
+	if err := k.rds.WaitForDBStatus(k.dbIdentifier, dbType, rds.StatusAvailable, timeout); err != nil {
+		return fmt.Errorf("failed while waiting for DB to be available: %w", err)
+	}
+
+	slog.Info("Stopping DB", "dbIdentifier", k.dbIdentifier)
+
+	return k.rds.StopDB(k.dbIdentifier, dbType)
+}
+
+/*
+pendingMaintenanceActions returns the pending maintenance actions of the database,
+including those of its member instances for Aurora clusters.
+*/
+func (k *ktnh) pendingMaintenanceActions(dbType string) ([]rds.MaintenanceAction, error) {
+	databases := []displayDBInfo{
+		{
+			dbIdentifier: k.dbIdentifier,
+			dbType:       dbType,
+		},
+	}
+
+	clusters, instances, clusterMembers, err := k.categorizeDBsByType(databases)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to categorize databases: %w", err)
+	}
+
+	pendingMaintenance, err := k.rds.DescribePendingMaintenanceActions(clusters, instances, clusterMembers)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending maintenance actions: %w", err)
+	}
+
+	return pendingMaintenance[rdsKey(databases[0])], nil
+}
@@ -0,0 +1,211 @@
+package ktnh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appcfn "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+	apprds "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+func Test_restoreAfterMaintenance(t *testing.T) {
+	pausedStack := &cloudformation.DescribeStacksOutput{
+		Stacks: []cfntypes.Stack{
+			{
+				StackName: aws.String("A-db1-abcdef"),
+				Parameters: []cfntypes.Parameter{
+					{
+						ParameterKey:   aws.String("ProtectionState"),
+						ParameterValue: aws.String("DISABLED"),
+					},
+				},
+			},
+		},
+	}
+
+	availableInstance := &rds.DescribeDBInstancesOutput{
+		DBInstances: []rdstypes.DBInstance{
+			{
+				DBInstanceIdentifier: aws.String("db1"),
+				DBInstanceStatus:     aws.String("available"),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		started   bool
+		paused    bool
+		mockSetup func(*appmock.MockCloudFormationFactory, *appmock.MockCloudFormationClient, *appmock.MockStackUpdateCompleteWaiter, *appmock.MockRDSClient)
+		wantErr   bool
+		errSubstr string
+	}{
+		{
+			name:    "Stop DB and resume protection",
+			started: true,
+			paused:  true,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient, w *appmock.MockStackUpdateCompleteWaiter, r *appmock.MockRDSClient) {
+				r.On("DescribeDBInstances", mock.Anything, mock.Anything, mock.Anything).
+					Return(availableInstance, nil).
+					Once()
+
+				r.On("StopDBInstance", mock.Anything, &rds.StopDBInstanceInput{DBInstanceIdentifier: aws.String("db1")}, mock.Anything).
+					Return(&rds.StopDBInstanceOutput{}, nil).
+					Once()
+
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(pausedStack, nil).
+					Once()
+
+				c.On("UpdateStack", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.UpdateStackOutput{}, nil).
+					Once()
+
+				f.On("NewStackUpdateCompleteWaiter").
+					Return(w, nil).
+					Twice()
+
+				w.On("Wait", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).
+					Twice()
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Protection is resumed even if stopping DB fails",
+			started: true,
+			paused:  true,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient, w *appmock.MockStackUpdateCompleteWaiter, r *appmock.MockRDSClient) {
+				r.On("DescribeDBInstances", mock.Anything, mock.Anything, mock.Anything).
+					Return(availableInstance, nil).
+					Once()
+
+				r.On("StopDBInstance", mock.Anything, mock.Anything, mock.Anything).
+					Return(&rds.StopDBInstanceOutput{}, assert.AnError).
+					Once()
+
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(pausedStack, nil).
+					Once()
+
+				c.On("UpdateStack", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.UpdateStackOutput{}, nil).
+					Once()
+
+				f.On("NewStackUpdateCompleteWaiter").
+					Return(w, nil).
+					Twice()
+
+				w.On("Wait", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).
+					Twice()
+			},
+			wantErr: true,
+		},
+		{
+			name:    "DB is not stopped unless it becomes available",
+			started: true,
+			paused:  true,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient, w *appmock.MockStackUpdateCompleteWaiter, r *appmock.MockRDSClient) {
+				r.On("DescribeDBInstances", mock.Anything, mock.Anything, mock.Anything).
+					Return(&rds.DescribeDBInstancesOutput{}, assert.AnError).
+					Once()
+
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(pausedStack, nil).
+					Once()
+
+				c.On("UpdateStack", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.UpdateStackOutput{}, nil).
+					Once()
+
+				f.On("NewStackUpdateCompleteWaiter").
+					Return(w, nil).
+					Twice()
+
+				w.On("Wait", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).
+					Twice()
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Pausing update is still in progress",
+			started: false,
+			paused:  true,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient, w *appmock.MockStackUpdateCompleteWaiter, r *appmock.MockRDSClient) {
+				f.On("NewStackUpdateCompleteWaiter").
+					Return(w, nil).
+					Once()
+
+				w.On("Wait", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(assert.AnError).
+					Once()
+
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(pausedStack, nil).
+					Once()
+
+				c.On("UpdateStack", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.UpdateStackOutput{}, assert.AnError).
+					Once()
+			},
+			wantErr:   true,
+			errSubstr: "protection left paused on stack 'A-db1-abcdef'; run `ktnh resume db1`",
+		},
+		{
+			name:    "Nothing to restore",
+			started: false,
+			paused:  false,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient, w *appmock.MockStackUpdateCompleteWaiter, r *appmock.MockRDSClient) {
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactoryCloudFormation := new(appmock.MockCloudFormationFactory)
+			mockClientCloudFormation := new(appmock.MockCloudFormationClient)
+			mockWaiter := new(appmock.MockStackUpdateCompleteWaiter)
+			mockFactoryRDS := new(appmock.MockRDSFactory)
+			mockClientRDS := new(appmock.MockRDSClient)
+
+			mockFactoryCloudFormation.On("GetClient").
+				Return(mockClientCloudFormation)
+
+			mockFactoryRDS.On("GetClient").
+				Return(mockClientRDS)
+
+			tc.mockSetup(mockFactoryCloudFormation, mockClientCloudFormation, mockWaiter, mockClientRDS)
+
+			k := &ktnh{
+				dbIdentifier: "db1",
+				cfn:          appcfn.NewCloudFormation(mockFactoryCloudFormation),
+				rds:          apprds.NewRDS(mockFactoryRDS),
+			}
+
+			err := k.restoreAfterMaintenance("A-db1-abcdef", "rds", tc.started, tc.paused, time.Minute)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+				assert.ErrorContains(t, err, tc.errSubstr, "Error message should match")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+
+			mockClientCloudFormation.AssertExpectations(t)
+			mockWaiter.AssertExpectations(t)
+			mockClientRDS.AssertExpectations(t)
+		})
+	}
+}
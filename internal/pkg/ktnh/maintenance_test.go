@@ -0,0 +1,91 @@
+package ktnh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apprds "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+func Test_maintenanceCategory(t *testing.T) {
+	date := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		actions        []apprds.MaintenanceAction
+		hasMaintenance bool
+		expected       string
+	}{
+		{
+			name: "Required and available",
+			actions: []apprds.MaintenanceAction{
+				{Action: "os-upgrade"},
+				{Action: "system-update", ForcedApplyDate: &date},
+			},
+			hasMaintenance: true,
+			expected:       "required",
+		},
+		{
+			name: "Available only",
+			actions: []apprds.MaintenanceAction{
+				{Action: "os-upgrade"},
+			},
+			hasMaintenance: true,
+			expected:       "available",
+		},
+		{
+			name:           "None",
+			actions:        nil,
+			hasMaintenance: false,
+			expected:       "none",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, maintenanceCategory(tc.actions, tc.hasMaintenance), "Maintenance category does not match expected value")
+		})
+	}
+}
+
+func Test_MaintenanceReport_Tables(t *testing.T) {
+	date := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	report := &MaintenanceReport{
+		Actions: []PendingAction{
+			{
+				DBIdentifier:     "db1",
+				DBType:           "aurora",
+				ResourceType:     "db",
+				Resource:         "member-1",
+				Action:           "system-update",
+				Category:         "required",
+				AutoAppliedAfter: &date,
+				Description:      "a",
+			},
+			{
+				DBIdentifier: "db2",
+				DBType:       "rds",
+				ResourceType: "db",
+				Resource:     "db2",
+				Action:       "os-upgrade",
+				Category:     "available",
+				OptInStatus:  "next-maintenance",
+				Description:  "b",
+			},
+		},
+	}
+
+	tables := report.Tables()
+
+	assert.Len(t, tables, 1, "Number of tables does not match expected value")
+
+	assert.Equal(t, []string{"id", "resource", "action", "category", "auto-applied-after", "forced-apply", "opt-in", "description"}, tables[0].Headers, "Headers do not match expected headers")
+
+	assert.Equal(t, [][]any{
+		{"db1", "db:member-1", "system-update", "required", &date, (*time.Time)(nil), nil, "a"},
+		{"db2", "db:db2", "os-upgrade", "available", (*time.Time)(nil), (*time.Time)(nil), "next-maintenance", "b"},
+	}, tables[0].Rows, "Rows do not match expected rows")
+}
@@ -0,0 +1,293 @@
+package ktnh
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+/*
+Manifest declares the databases that should be frozen under the stack name prefix.
+Databases not listed in the manifest are defrosted when the manifest is applied.
+*/
+type Manifest struct {
+	Databases []ManifestDatabase `yaml:"databases"` // databases to be frozen
+}
+
+/*
+ManifestDatabase declares a database to be frozen and its options.
+The type is required only to tell apart an Aurora cluster and an RDS instance that share the identifier;
+a database without the type matches the managed database of either type.
+The tags and notification targets replace those of the stack, so omitting them removes them from the stack.
+The stop schedule and the log retention are stack parameters, which are left as they are unless declared
+(i.e., new stacks get the freeze policy given by `cfn.SetFreezePolicy`).
+*/
+type ManifestDatabase struct {
+	DBIdentifier     string            `yaml:"id"`                         // DB cluster/instance identifier
+	DBType           string            `yaml:"type,omitempty"`             // type of the DB (see `internal/pkg/rds`); empty if not declared
+	Paused           bool              `yaml:"paused,omitempty"`           // whether protection is paused
+	StopSchedule     string            `yaml:"stopSchedule,omitempty"`     // schedule expression to stop the DB periodically; empty if not declared
+	LogRetention     int               `yaml:"logRetention,omitempty"`     // retention period of the state machine logs in days; 0 if not declared
+	Tags             map[string]string `yaml:"tags,omitempty"`             // stack tags, propagated to the stack resources
+	NotificationARNs []string          `yaml:"notificationArns,omitempty"` // ARNs of the SNS topics notified of the stack events
+}
+
+/*
+maxNotificationARNs is the maximum number of notification targets of a CloudFormation stack.
+*/
+const maxNotificationARNs = 5
+
+/*
+ParseManifest parses a manifest in YAML.
+Unknown fields, unknown DB types and duplicate databases are rejected, so that typos do not cause unintended changes.
+Databases with the same identifier are duplicates unless both declare different types.
+DB identifiers are converted into lowercase, in the same way as those given on the command line.
+*/
+func ParseManifest(data []byte) (*Manifest, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	decoder.KnownFields(true)
+
+	manifest := &Manifest{}
+
+	if err := decoder.Decode(manifest); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	for i, db := range manifest.Databases {
+		if strings.TrimSpace(db.DBIdentifier) == "" {
+			return nil, fmt.Errorf("databases[%d]: id is required", i)
+		}
+
+		if db.DBType != "" {
+			if _, err := rds.ParseDBType(db.DBType); err != nil {
+				return nil, fmt.Errorf("databases[%d]: %w", i, err)
+			}
+		}
+
+		if db.StopSchedule != "" {
+			if err := cfn.ValidateScheduleExpression(db.StopSchedule); err != nil {
+				return nil, fmt.Errorf("databases[%d]: %w", i, err)
+			}
+		}
+
+		if db.LogRetention != 0 {
+			if err := cfn.ValidateLogRetention(db.LogRetention); err != nil {
+				return nil, fmt.Errorf("databases[%d]: %w", i, err)
+			}
+		}
+
+		if err := validateStackOptions(db.stackOptions(cfn.StackOptions{})); err != nil {
+			return nil, fmt.Errorf("databases[%d]: %w", i, err)
+		}
+
+		db.DBIdentifier = normalizeIdentifier(db.DBIdentifier)
+
+		manifest.Databases[i] = db
+
+		isDuplicate := slices.ContainsFunc(manifest.Databases[:i], func(other ManifestDatabase) bool {
+			return (other.DBIdentifier == db.DBIdentifier) && ((other.DBType == "") || (db.DBType == "") || (other.DBType == db.DBType))
+		})
+
+		if isDuplicate {
+			return nil, fmt.Errorf("databases[%d]: duplicate id '%s'", i, db.DBIdentifier)
+		}
+	}
+
+	slog.Debug("Parsed manifest", "databases", len(manifest.Databases))
+
+	return manifest, nil
+}
+
+/*
+stackOptions returns the tags and notification targets declared for the database, merged into the defaults.
+Tags declared for the database override the default tags of the same key,
+and notification targets declared for the database replace the default ones.
+*/
+func (db ManifestDatabase) stackOptions(defaults cfn.StackOptions) cfn.StackOptions {
+	var tags map[string]string
+
+	if (0 < len(defaults.Tags)) || (0 < len(db.Tags)) {
+		tags = maps.Clone(defaults.Tags)
+
+		if tags == nil {
+			tags = map[string]string{}
+		}
+
+		maps.Copy(tags, db.Tags)
+	}
+
+	notificationARNs := db.NotificationARNs
+
+	if len(notificationARNs) == 0 {
+		notificationARNs = defaults.NotificationARNs
+	}
+
+	return cfn.StackOptions{
+		Tags:             tags,
+		NotificationARNs: notificationARNs,
+	}
+}
+
+/*
+stackParameters returns the stack parameters of the stop schedule and the log retention declared for the database,
+or nil if neither is declared.
+*/
+func (db ManifestDatabase) stackParameters() map[string]string {
+	parameters := map[string]string{}
+
+	if db.StopSchedule != "" {
+		parameters[cfn.ParameterScheduleExpression] = db.StopSchedule
+	}
+
+	if db.LogRetention != 0 {
+		parameters[cfn.ParameterLogRetentionInDays] = strconv.Itoa(db.LogRetention)
+	}
+
+	if len(parameters) == 0 {
+		return nil
+	}
+
+	return parameters
+}
+
+/*
+validateStackOptions validates the tags and notification targets of a stack.
+*/
+func validateStackOptions(options cfn.StackOptions) error {
+	for key := range options.Tags {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("tag key must not be empty")
+		}
+
+		if strings.HasPrefix(strings.ToLower(key), "aws:") {
+			return fmt.Errorf("tag key '%s' must not start with 'aws:', which is reserved by AWS", key)
+		}
+	}
+
+	if maxNotificationARNs < len(options.NotificationARNs) {
+		return fmt.Errorf("at most %d notification ARNs can be given", maxNotificationARNs)
+	}
+
+	for _, arn := range options.NotificationARNs {
+		if !strings.HasPrefix(arn, "arn:") || !strings.Contains(arn, ":sns:") {
+			return fmt.Errorf("notification ARN '%s' is not an ARN of an SNS topic", arn)
+		}
+	}
+
+	return nil
+}
+
+/*
+matches reports whether the manifest declares the managed database.
+*/
+func (db ManifestDatabase) matches(dbIdentifier string, dbType string) bool {
+	return (db.DBIdentifier == dbIdentifier) && ((db.DBType == "") || (db.DBType == dbType))
+}
+
+/*
+Marshal converts the manifest into YAML.
+*/
+func (m *Manifest) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(m); err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+/*
+Export returns a manifest of the databases currently managed under the stack name prefix,
+which results in no changes when applied as is.
+*/
+func (k *ktnh) Export() (*Manifest, error) {
+	databases, err := k.collectManagedDatabases()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect managed databases: %w", err)
+	}
+
+	databases, err = k.updateProtectionState(databases)
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := newManifest(databases)
+
+	slog.Debug("Exported manifest", "databases", len(manifest.Databases))
+
+	return manifest, nil
+}
+
+/*
+newManifest builds a manifest declaring the databases in their current protection state.
+The type is written only for the identifiers shared by an Aurora cluster and an RDS instance,
+and the stop schedule and the log retention only for the stacks that have them as parameters.
+*/
+func newManifest(databases []displayDBInfo) *Manifest {
+	manifest := &Manifest{
+		Databases: []ManifestDatabase{},
+	}
+
+	counts := map[string]int{}
+
+	for _, db := range databases {
+		counts[db.dbIdentifier]++
+	}
+
+	for _, db := range databases {
+		entry := ManifestDatabase{
+			DBIdentifier: db.dbIdentifier,
+			Paused:       db.state == statePaused,
+		}
+
+		if db.stack != nil {
+			entry.StopSchedule = db.stack.Parameters[cfn.ParameterScheduleExpression]
+
+			if days, err := strconv.Atoi(db.stack.Parameters[cfn.ParameterLogRetentionInDays]); err == nil {
+				entry.LogRetention = days
+			}
+
+			entry.Tags = db.stack.Options.Tags
+			entry.NotificationARNs = db.stack.Options.NotificationARNs
+		}
+
+		if 1 < counts[db.dbIdentifier] {
+			entry.DBType = db.dbType
+		}
+
+		manifest.Databases = append(manifest.Databases, entry)
+	}
+
+	slices.SortFunc(manifest.Databases, func(a, b ManifestDatabase) int {
+		return cmp.Or(
+			strings.Compare(a.DBIdentifier, b.DBIdentifier),
+			strings.Compare(a.DBType, b.DBType),
+		)
+	})
+
+	return manifest
+}
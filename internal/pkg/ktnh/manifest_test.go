@@ -0,0 +1,249 @@
+package ktnh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+)
+
+func Test_ParseManifest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		expected *Manifest
+		wantErr  bool
+	}{
+		{
+			name: "Valid manifest",
+			data: `databases:
+  - id: db1
+  - id: db2
+    paused: true
+`,
+			expected: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db1"},
+					{DBIdentifier: "db2", Paused: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Mixed-case id",
+			data: `databases:
+  - id: My-DB
+`,
+			expected: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "my-db"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Cluster and instance with the same id",
+			data: `databases:
+  - id: db1
+    type: aurora
+  - id: db1
+    type: rds
+    paused: true
+`,
+			expected: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db1", DBType: "aurora"},
+					{DBIdentifier: "db1", DBType: "rds", Paused: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unknown type",
+			data: `databases:
+  - id: db1
+    type: dynamodb
+`,
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "Duplicate id with and without type",
+			data: `databases:
+  - id: db1
+    type: aurora
+  - id: db1
+`,
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name:     "Empty manifest",
+			data:     "",
+			expected: &Manifest{},
+			wantErr:  false,
+		},
+		{
+			name: "Unknown field",
+			data: `databases:
+  - id: db1
+    pasued: true
+`,
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "Tags and notification targets",
+			data: `databases:
+  - id: db1
+    tags:
+      env: dev
+    notificationArns:
+      - arn:aws:sns:ap-northeast-1:123456789012:topic
+`,
+			expected: &Manifest{
+				Databases: []ManifestDatabase{
+					{
+						DBIdentifier:     "db1",
+						Tags:             map[string]string{"env": "dev"},
+						NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:topic"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Reserved tag key",
+			data: `databases:
+  - id: db1
+    tags:
+      aws:owner: me
+`,
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "Notification target other than SNS topic",
+			data: `databases:
+  - id: db1
+    notificationArns:
+      - arn:aws:sqs:ap-northeast-1:123456789012:queue
+`,
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "Missing id",
+			data: `databases:
+  - paused: true
+`,
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "Duplicate id",
+			data: `databases:
+  - id: db1
+  - id: db1
+    paused: true
+`,
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "Stop schedule and log retention",
+			data: `databases:
+  - id: db1
+    stopSchedule: rate(3 hours)
+    logRetention: 30
+`,
+			expected: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db1", StopSchedule: "rate(3 hours)", LogRetention: 30},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid stop schedule",
+			data: `databases:
+  - id: db1
+    stopSchedule: 3 hours
+`,
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "Unsupported log retention",
+			data: `databases:
+  - id: db1
+    logRetention: 10
+`,
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "Duplicate id in different case",
+			data: `databases:
+  - id: db1
+  - id: DB1
+`,
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseManifest([]byte(tc.data))
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Manifest does not match expected value")
+			}
+		})
+	}
+}
+
+func Test_Manifest_Marshal(t *testing.T) {
+	manifest := &Manifest{
+		Databases: []ManifestDatabase{
+			{DBIdentifier: "db1"},
+			{DBIdentifier: "db2", Paused: true},
+		},
+	}
+
+	data, err := manifest.Marshal()
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	assert.Equal(t, "databases:\n  - id: db1\n  - id: db2\n    paused: true\n", string(data), "Marshaled manifest does not match expected value")
+
+	parsed, err := ParseManifest(data)
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	assert.Equal(t, manifest, parsed, "Marshaled manifest cannot be parsed back")
+}
+
+func Test_newManifest(t *testing.T) {
+	databases := []displayDBInfo{
+		{dbIdentifier: "db2", dbType: "rds", state: "active", stack: &cfn.Stack{Parameters: map[string]string{"ScheduleExpression": "rate(3 hours)", "LogRetentionInDays": "30"}, Options: cfn.StackOptions{Tags: map[string]string{"env": "dev"}}}},
+		{dbIdentifier: "db1", dbType: "rds", state: "paused"},
+		{dbIdentifier: "db1", dbType: "aurora", state: "active"},
+	}
+
+	expected := &Manifest{
+		Databases: []ManifestDatabase{
+			{DBIdentifier: "db1", DBType: "aurora"},
+			{DBIdentifier: "db1", DBType: "rds", Paused: true},
+			{DBIdentifier: "db2", StopSchedule: "rate(3 hours)", LogRetention: 30, Tags: map[string]string{"env": "dev"}},
+		},
+	}
+
+	assert.Equal(t, expected, newManifest(databases), "Manifest does not match expected value")
+}
@@ -0,0 +1,235 @@
+package ktnh
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+)
+
+const (
+	stateActive = "active" // the event rule and the schedule are enabled
+	statePaused = "paused" // the event rule and the schedule are disabled
+)
+
+/*
+Pause disables the event rule and the schedule of the stack associated with the DB identifier,
+keeping the stack itself so that protection can be resumed later.
+*/
+func (k *ktnh) Pause(timeout time.Duration) error {
+	return k.setProtectionState(cfn.ProtectionStateDisabled, timeout)
+}
+
+/*
+Resume re-enables the event rule and the schedule of the stack associated with the DB identifier.
+*/
+func (k *ktnh) Resume(timeout time.Duration) error {
+	return k.setProtectionState(cfn.ProtectionStateEnabled, timeout)
+}
+
+/*
+PauseAll pauses protection of all databases managed under the stack name prefix.
+*/
+func (k *ktnh) PauseAll(timeout time.Duration) error {
+	return k.setAllProtectionState(cfn.ProtectionStateDisabled, timeout)
+}
+
+/*
+ResumeAll resumes protection of all databases managed under the stack name prefix.
+*/
+func (k *ktnh) ResumeAll(timeout time.Duration) error {
+	return k.setAllProtectionState(cfn.ProtectionStateEnabled, timeout)
+}
+
+/*
+setProtectionState updates the protection state of the stack associated with the DB identifier.
+*/
+func (k *ktnh) setProtectionState(state string, timeout time.Duration) error {
+	stackName, found, err := k.findMatchingStack()
+
+	if err != nil {
+		return fmt.Errorf("failed to find matching stack: %w", err)
+	}
+
+	if !found {
+		return fmt.Errorf("no stacks found for DB identifier")
+	}
+
+	started, err := k.startProtectionStateUpdate(stackName, state)
+
+	if err != nil {
+		return err
+	}
+
+	if !started {
+		return nil
+	}
+
+	if timeout == 0 {
+		slog.Info("Skipped wait for stack update")
+
+		return nil
+	}
+
+	slog.Info("Waiting for CloudFormation stack update to complete", "timeout", timeout.Seconds())
+
+	err = k.cfn.WaitForStackUpdate(stackName, timeout)
+
+	if err != nil {
+		return fmt.Errorf("failed while waiting for stack update: %w", err)
+	}
+
+	return nil
+}
+
+/*
+setAllProtectionState updates the protection state of all stacks under the stack name prefix.
+All updates are started first and then waited for, so that a large number of stacks can be
+handled quickly. A failure on one stack does not prevent the others from being updated.
+*/
+func (k *ktnh) setAllProtectionState(state string, timeout time.Duration) error {
+	databases, err := k.collectManagedDatabases()
+
+	if err != nil {
+		return fmt.Errorf("failed to collect managed databases: %w", err)
+	}
+
+	if len(databases) == 0 {
+		slog.Info("No databases are currently being managed by ktnh")
+
+		return nil
+	}
+
+	var (
+		updatedStacks []string
+		errs          []error
+	)
+
+	for _, db := range databases {
+		started, err := k.startProtectionStateUpdate(db.stackName, state)
+
+		if err != nil {
+			slog.Warn("Failed to update protection state",
+				"dbIdentifier", db.dbIdentifier,
+				"error", err,
+			)
+
+			errs = append(errs, fmt.Errorf("DB '%s': %w", db.dbIdentifier, err))
+
+			continue
+		}
+
+		if started {
+			updatedStacks = append(updatedStacks, db.stackName)
+		}
+	}
+
+	if (timeout != 0) && (0 < len(updatedStacks)) {
+		slog.Info("Waiting for CloudFormation stack updates to complete",
+			"stacks", len(updatedStacks),
+			"timeout", timeout.Seconds(),
+		)
+
+		deadline := time.Now().Add(timeout)
+
+		for _, stackName := range updatedStacks {
+			err := k.cfn.WaitForStackUpdate(stackName, max(time.Until(deadline), time.Second))
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed while waiting for update of stack '%s': %w", stackName, err))
+			}
+		}
+	}
+
+	slog.Info("Updated protection state",
+		"databases", len(databases),
+		"updated", len(updatedStacks),
+		"failed", len(errs),
+	)
+
+	return errors.Join(errs...)
+}
+
+/*
+startProtectionStateUpdate starts an update of the stack parameter that controls the state
+of the event rule and the schedule. Updating the parameter instead of the resources directly
+keeps the stack free of drift.
+Returns whether an update was started; no update is needed if the stack is already in the given state.
+*/
+func (k *ktnh) startProtectionStateUpdate(stackName string, state string) (bool, error) {
+	return k.startStackUpdate(stackName, state, nil, nil)
+}
+
+/*
+startStackUpdate starts an update of the protection state, the given stack parameters (e.g., the stop schedule) and,
+if options is not nil, the tags and notification targets of the stack, in a single stack update.
+Returns whether an update was started; no update is needed if the stack is already up to date.
+*/
+func (k *ktnh) startStackUpdate(stackName string, state string, parameters map[string]string, options *cfn.StackOptions) (bool, error) {
+	stack, err := k.cfn.DescribeStack(stackName)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to describe stack: %w", err)
+	}
+
+	current, ok := stack.Parameters[cfn.ParameterProtectionState]
+
+	if !ok {
+		return false, fmt.Errorf("stack '%s' was created by an older version of ktnh and does not support pausing; defrost and freeze the DB again", stackName)
+	}
+
+	upToDate := true
+
+	for _, key := range slices.Sorted(maps.Keys(parameters)) {
+		value, ok := stack.Parameters[key]
+
+		if !ok {
+			return false, fmt.Errorf("stack '%s' was created by an older version of ktnh and does not have parameter '%s'; defrost and freeze the DB again", stackName, key)
+		}
+
+		upToDate = upToDate && (value == parameters[key])
+	}
+
+	if upToDate && (current == state) && ((options == nil) || stack.Options.Equal(*options)) {
+		slog.Info("Protection state is already up to date",
+			"stackName", stackName,
+			"state", protectionState(stack),
+		)
+
+		return false, nil
+	}
+
+	slog.Info("Updating CloudFormation stack", "stackName", stackName, "protectionState", state, "parameters", parameters)
+
+	overrides := maps.Clone(parameters)
+
+	if overrides == nil {
+		overrides = map[string]string{}
+	}
+
+	overrides[cfn.ParameterProtectionState] = state
+
+	err = k.cfn.UpdateStackParameters(stack, overrides, options)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to update CloudFormation stack: %w", err)
+	}
+
+	return true, nil
+}
+
+/*
+protectionState returns the protection state of the stack for display.
+Stacks without the parameter were created before pausing was supported, and are always active.
+*/
+func protectionState(stack *cfn.Stack) string {
+	if stack.Parameters[cfn.ParameterProtectionState] == cfn.ProtectionStateDisabled {
+		return statePaused
+	}
+
+	return stateActive
+}
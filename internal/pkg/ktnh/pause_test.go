@@ -0,0 +1,280 @@
+package ktnh
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appcfn "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_startProtectionStateUpdate(t *testing.T) {
+	describeStacksOutput := func(parameters ...cfntypes.Parameter) *cloudformation.DescribeStacksOutput {
+		return &cloudformation.DescribeStacksOutput{
+			Stacks: []cfntypes.Stack{
+				{
+					StackName:  aws.String("A-db1-abcdef"),
+					Parameters: parameters,
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		state     string
+		mockSetup func(*appmock.MockCloudFormationClient)
+		expected  bool
+		wantErr   bool
+	}{
+		{
+			name:  "Pause active stack",
+			state: "DISABLED",
+			mockSetup: func(c *appmock.MockCloudFormationClient) {
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(describeStacksOutput(cfntypes.Parameter{
+						ParameterKey:   aws.String("ProtectionState"),
+						ParameterValue: aws.String("ENABLED"),
+					}), nil)
+
+				params := &cloudformation.UpdateStackInput{
+					StackName:           aws.String("A-db1-abcdef"),
+					UsePreviousTemplate: aws.Bool(true),
+					Parameters: []cfntypes.Parameter{
+						{
+							ParameterKey:   aws.String("ProtectionState"),
+							ParameterValue: aws.String("DISABLED"),
+						},
+					},
+					Capabilities: []cfntypes.Capability{cfntypes.CapabilityCapabilityNamedIam},
+				}
+
+				c.On("UpdateStack", mock.Anything, params, mock.Anything).
+					Return(&cloudformation.UpdateStackOutput{}, nil)
+			},
+			expected: true,
+			wantErr:  false,
+		},
+		{
+			name:  "Already paused",
+			state: "DISABLED",
+			mockSetup: func(c *appmock.MockCloudFormationClient) {
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(describeStacksOutput(cfntypes.Parameter{
+						ParameterKey:   aws.String("ProtectionState"),
+						ParameterValue: aws.String("DISABLED"),
+					}), nil)
+			},
+			expected: false,
+			wantErr:  false,
+		},
+		{
+			name:  "Stack without parameter",
+			state: "DISABLED",
+			mockSetup: func(c *appmock.MockCloudFormationClient) {
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(describeStacksOutput(), nil)
+			},
+			expected: false,
+			wantErr:  true,
+		},
+		{
+			name:  "Update error",
+			state: "ENABLED",
+			mockSetup: func(c *appmock.MockCloudFormationClient) {
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(describeStacksOutput(cfntypes.Parameter{
+						ParameterKey:   aws.String("ProtectionState"),
+						ParameterValue: aws.String("DISABLED"),
+					}), nil)
+
+				c.On("UpdateStack", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.UpdateStackOutput{}, assert.AnError)
+			},
+			expected: false,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudFormationFactory)
+			mockClient := new(appmock.MockCloudFormationClient)
+
+			mockFactory.On("GetClient").
+				Return(mockClient)
+
+			tc.mockSetup(mockClient)
+
+			k := &ktnh{
+				cfn: appcfn.NewCloudFormation(mockFactory),
+			}
+
+			got, err := k.startProtectionStateUpdate("A-db1-abcdef", tc.state)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Whether an update was started does not match")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_startStackUpdate_Parameters(t *testing.T) {
+	stackParameters := []cfntypes.Parameter{
+		{
+			ParameterKey:   aws.String("LogRetentionInDays"),
+			ParameterValue: aws.String("14"),
+		},
+		{
+			ParameterKey:   aws.String("ProtectionState"),
+			ParameterValue: aws.String("ENABLED"),
+		},
+		{
+			ParameterKey:   aws.String("ScheduleExpression"),
+			ParameterValue: aws.String("rate(6 hours)"),
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		parameters []cfntypes.Parameter
+		overrides  map[string]string
+		mockSetup  func(*appmock.MockCloudFormationClient)
+		expected   bool
+		wantErr    bool
+	}{
+		{
+			name:       "Change stop schedule",
+			parameters: stackParameters,
+			overrides:  map[string]string{"ScheduleExpression": "rate(3 hours)"},
+			mockSetup: func(c *appmock.MockCloudFormationClient) {
+				params := &cloudformation.UpdateStackInput{
+					StackName:           aws.String("A-db1-abcdef"),
+					UsePreviousTemplate: aws.Bool(true),
+					Parameters: []cfntypes.Parameter{
+						{
+							ParameterKey:     aws.String("LogRetentionInDays"),
+							UsePreviousValue: aws.Bool(true),
+						},
+						{
+							ParameterKey:   aws.String("ProtectionState"),
+							ParameterValue: aws.String("ENABLED"),
+						},
+						{
+							ParameterKey:   aws.String("ScheduleExpression"),
+							ParameterValue: aws.String("rate(3 hours)"),
+						},
+					},
+					Capabilities: []cfntypes.Capability{cfntypes.CapabilityCapabilityNamedIam},
+				}
+
+				c.On("UpdateStack", mock.Anything, params, mock.Anything).
+					Return(&cloudformation.UpdateStackOutput{}, nil)
+			},
+			expected: true,
+			wantErr:  false,
+		},
+		{
+			name:       "Already up to date",
+			parameters: stackParameters,
+			overrides:  map[string]string{"ScheduleExpression": "rate(6 hours)", "LogRetentionInDays": "14"},
+			mockSetup:  func(c *appmock.MockCloudFormationClient) {},
+			expected:   false,
+			wantErr:    false,
+		},
+		{
+			name: "Stack without parameter",
+			parameters: []cfntypes.Parameter{
+				{
+					ParameterKey:   aws.String("ProtectionState"),
+					ParameterValue: aws.String("ENABLED"),
+				},
+			},
+			overrides: map[string]string{"ScheduleExpression": "rate(3 hours)"},
+			mockSetup: func(c *appmock.MockCloudFormationClient) {},
+			expected:  false,
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudFormationFactory)
+			mockClient := new(appmock.MockCloudFormationClient)
+
+			mockFactory.On("GetClient").
+				Return(mockClient)
+
+			mockClient.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+				Return(&cloudformation.DescribeStacksOutput{
+					Stacks: []cfntypes.Stack{
+						{
+							StackName:  aws.String("A-db1-abcdef"),
+							Parameters: tc.parameters,
+						},
+					},
+				}, nil)
+
+			tc.mockSetup(mockClient)
+
+			k := &ktnh{
+				cfn: appcfn.NewCloudFormation(mockFactory),
+			}
+
+			got, err := k.startStackUpdate("A-db1-abcdef", "ENABLED", tc.overrides, nil)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Whether an update was started does not match")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_protectionState(t *testing.T) {
+	testCases := []struct {
+		name       string
+		parameters map[string]string
+		expected   string
+	}{
+		{
+			name:       "Enabled",
+			parameters: map[string]string{"ProtectionState": "ENABLED"},
+			expected:   "active",
+		},
+		{
+			name:       "Disabled",
+			parameters: map[string]string{"ProtectionState": "DISABLED"},
+			expected:   "paused",
+		},
+		{
+			name:       "Without parameter",
+			parameters: map[string]string{},
+			expected:   "active",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := protectionState(&appcfn.Stack{Parameters: tc.parameters})
+
+			assert.Equal(t, tc.expected, got, "Protection state does not match")
+		})
+	}
+}
@@ -0,0 +1,531 @@
+package ktnh
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+)
+
+const (
+	planActionCreate = "create" // a stack is created to freeze the DB
+	planActionUpdate = "update" // the protection state, stack parameters, tags or notification targets of the stack are changed
+	planActionDelete = "delete" // the stack is deleted to defrost the DB
+)
+
+const (
+	planResultApplying = "applying" // the change has been started but not waited for
+	planResultApplied  = "applied"  // the change has completed
+	planResultFailed   = "failed"   // the change failed
+)
+
+/*
+PlanReport holds the changes required to reconcile the stacks with a manifest.
+*/
+type PlanReport struct {
+	Changes []PlannedChange `json:"changes"` // changes, in the order of the DB identifier
+}
+
+/*
+PlannedChange holds a change to a single stack.
+*/
+type PlannedChange struct {
+	Action       string            `json:"action"`               // "create", "update" or "delete"
+	DBIdentifier string            `json:"id"`                   // DB cluster/instance identifier
+	DBType       string            `json:"type"`                 // type of the DB (see `internal/pkg/rds`)
+	StackName    string            `json:"stackName"`            // CloudFormation stack name (empty until created)
+	State        string            `json:"state"`                // protection state after the change (empty for deletion)
+	Parameters   map[string]string `json:"parameters,omitempty"` // stop schedule and log retention parameters after the change (nil if not changed)
+	Options      *cfn.StackOptions `json:"options,omitempty"`    // tags and notification targets after the change (nil if not changed)
+	Result       string            `json:"result,omitempty"`     // result of applying the change (empty if not applied)
+}
+
+/*
+Plan compares the manifest with the databases managed under the stack name prefix,
+and returns the stacks to be created, updated and deleted.
+Databases to be frozen must exist, so that the plan can be applied as is.
+*/
+func (k *ktnh) Plan(manifest *Manifest) (*PlanReport, error) {
+	databases, err := k.collectManagedDatabases()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect managed databases: %w", err)
+	}
+
+	// NOTE: unknown protection states would result in wrong updates, so failures are not tolerated.
+	databases, err = k.updateProtectionState(databases)
+
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PlanReport{
+		Changes: planChanges(manifest, databases, k.stackOptions),
+	}
+
+	for i := range report.Changes {
+		change := &report.Changes[i]
+
+		if change.Action != planActionCreate {
+			continue
+		}
+
+		if change.DBType != "" {
+			if _, err := k.rds.DescribeDBStatus(change.DBIdentifier, change.DBType); err != nil {
+				return nil, fmt.Errorf("failed to find %s DB '%s': %w", change.DBType, change.DBIdentifier, err)
+			}
+
+			continue
+		}
+
+		dbType, err := k.rds.DetermineDBType(change.DBIdentifier)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine DB type of '%s': %w", change.DBIdentifier, err)
+		}
+
+		change.DBType = string(dbType)
+	}
+
+	if err := k.checkCreations(report.Changes); err != nil {
+		return nil, err
+	}
+
+	sortChanges(report.Changes)
+
+	slog.Info("Planned changes", "changes", len(report.Changes))
+
+	return report, nil
+}
+
+/*
+checkCreations makes sure that the databases to be frozen are not protected by stacks found only under other prefixes
+or by their names (e.g., stacks adopted with `--stack-name` from templates that do not record the prefix),
+since freezing them would create duplicate stacks.
+*/
+func (k *ktnh) checkCreations(changes []PlannedChange) error {
+	if !slices.ContainsFunc(changes, func(change PlannedChange) bool { return change.Action == planActionCreate }) {
+		return nil
+	}
+
+	protected, err := k.findStacks(&stackSearchOption{anyPrefix: true})
+
+	if err != nil {
+		return fmt.Errorf("failed to find stacks of the databases to be frozen: %w", err)
+	}
+
+	return findDuplicateCreation(changes, protected)
+}
+
+/*
+findDuplicateCreation returns an error if any database to be frozen is already protected by one of the stacks.
+*/
+func findDuplicateCreation(changes []PlannedChange, protected []displayDBInfo) error {
+	for _, change := range changes {
+		if change.Action != planActionCreate {
+			continue
+		}
+
+		i := slices.IndexFunc(protected, func(db displayDBInfo) bool {
+			return (db.dbIdentifier == change.DBIdentifier) && (db.dbType == change.DBType)
+		})
+
+		if 0 <= i {
+			return fmt.Errorf("%s DB '%s' is already protected by stack '%s', which is not managed under the prefix; delete the stack or remove the DB from the manifest", change.DBType, change.DBIdentifier, protected[i].stackName)
+		}
+	}
+
+	return nil
+}
+
+/*
+Apply makes the changes of the plan, and records the result of each change in the report.
+The plan is applied as is, so that the changes confirmed by the user are the ones made.
+All changes are started first and then waited for, in the same way as `PauseAll`;
+a failure on one stack does not prevent the others from being changed.
+*/
+func (k *ktnh) Apply(report *PlanReport, timeout time.Duration) error {
+	return k.applyChanges(report, timeout)
+}
+
+/*
+Deletions returns the changes that delete stacks, i.e. defrost databases.
+*/
+func (r *PlanReport) Deletions() []PlannedChange {
+	var deletions []PlannedChange
+
+	for _, change := range r.Changes {
+		if change.Action == planActionDelete {
+			deletions = append(deletions, change)
+		}
+	}
+
+	return deletions
+}
+
+/*
+planChanges computes the changes required to reconcile the managed databases with the manifest.
+Databases are matched by their identifiers and, if declared in the manifest, their types.
+The types of the databases to be frozen are left empty unless declared.
+The tags and notification targets declared in the manifest are merged into the defaults,
+and the stop schedule and the log retention are compared with the stack parameters only if declared.
+*/
+func planChanges(manifest *Manifest, databases []displayDBInfo, defaults cfn.StackOptions) []PlannedChange {
+	matched := make([]bool, len(manifest.Databases))
+
+	changes := []PlannedChange{}
+
+	for _, db := range databases {
+		i := slices.IndexFunc(manifest.Databases, func(target ManifestDatabase) bool {
+			return target.matches(db.dbIdentifier, db.dbType)
+		})
+
+		if i < 0 {
+			changes = append(changes, PlannedChange{
+				Action:       planActionDelete,
+				DBIdentifier: db.dbIdentifier,
+				DBType:       db.dbType,
+				StackName:    db.stackName,
+			})
+
+			continue
+		}
+
+		matched[i] = true
+
+		state := manifestState(manifest.Databases[i])
+
+		parameters := changedStackParameters(db, manifest.Databases[i].stackParameters())
+
+		options := changedStackOptions(db, manifest.Databases[i].stackOptions(defaults))
+
+		if (db.state != state) || (parameters != nil) || (options != nil) {
+			changes = append(changes, PlannedChange{
+				Action:       planActionUpdate,
+				DBIdentifier: db.dbIdentifier,
+				DBType:       db.dbType,
+				StackName:    db.stackName,
+				State:        state,
+				Parameters:   parameters,
+				Options:      options,
+			})
+		}
+	}
+
+	for i, db := range manifest.Databases {
+		if matched[i] {
+			continue
+		}
+
+		var options *cfn.StackOptions
+
+		if desired := db.stackOptions(defaults); !desired.Equal(cfn.StackOptions{}) {
+			options = &desired
+		}
+
+		changes = append(changes, PlannedChange{
+			Action:       planActionCreate,
+			DBIdentifier: db.DBIdentifier,
+			DBType:       db.DBType,
+			State:        manifestState(db),
+			Parameters:   db.stackParameters(),
+			Options:      options,
+		})
+	}
+
+	sortChanges(changes)
+
+	return changes
+}
+
+/*
+changedStackParameters returns the desired stack parameters that differ from those of the stack,
+or nil if none do. If the stack has not been described, all desired parameters are changed.
+*/
+func changedStackParameters(db displayDBInfo, desired map[string]string) map[string]string {
+	changed := map[string]string{}
+
+	for key, value := range desired {
+		if (db.stack == nil) || (db.stack.Parameters[key] != value) {
+			changed[key] = value
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	return changed
+}
+
+/*
+changedStackOptions returns the desired tags and notification targets if they differ from those of the stack,
+or nil if they do not. If the stack has not been described, they are changed only if any are desired.
+*/
+func changedStackOptions(db displayDBInfo, desired cfn.StackOptions) *cfn.StackOptions {
+	current := cfn.StackOptions{}
+
+	if db.stack != nil {
+		current = db.stack.Options
+	}
+
+	if current.Equal(desired) {
+		return nil
+	}
+
+	return &desired
+}
+
+/*
+sortChanges sorts the changes in the order of the DB identifier and the DB type.
+*/
+func sortChanges(changes []PlannedChange) {
+	slices.SortStableFunc(changes, func(a, b PlannedChange) int {
+		return cmp.Or(
+			cmp.Compare(a.DBIdentifier, b.DBIdentifier),
+			cmp.Compare(a.DBType, b.DBType),
+		)
+	})
+}
+
+/*
+manifestState returns the protection state declared for the database.
+*/
+func manifestState(db ManifestDatabase) string {
+	if db.Paused {
+		return statePaused
+	}
+
+	return stateActive
+}
+
+/*
+applyChanges starts all changes in the report, waits for them against a shared deadline unless timeout is 0,
+and records the results.
+*/
+func (k *ktnh) applyChanges(report *PlanReport, timeout time.Duration) error {
+	var errs []error
+
+	for i := range report.Changes {
+		change := &report.Changes[i]
+
+		started, err := k.startChange(change)
+
+		if err != nil {
+			slog.Warn("Failed to apply change",
+				"action", change.Action,
+				"dbIdentifier", change.DBIdentifier,
+				"error", err,
+			)
+
+			change.Result = planResultFailed
+
+			errs = append(errs, fmt.Errorf("%s '%s': %w", change.Action, change.DBIdentifier, err))
+
+			continue
+		}
+
+		if started {
+			change.Result = planResultApplying
+		} else {
+			change.Result = planResultApplied
+		}
+	}
+
+	if timeout != 0 {
+		slog.Info("Waiting for CloudFormation stack operations to complete", "timeout", timeout.Seconds())
+
+		deadline := time.Now().Add(timeout)
+
+		for i := range report.Changes {
+			change := &report.Changes[i]
+
+			if change.Result != planResultApplying {
+				continue
+			}
+
+			if err := k.waitForChange(change, max(time.Until(deadline), time.Second)); err != nil {
+				change.Result = planResultFailed
+
+				errs = append(errs, fmt.Errorf("failed while waiting for stack '%s': %w", change.StackName, err))
+
+				continue
+			}
+
+			change.Result = planResultApplied
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+/*
+startChange starts the stack operation of the change without waiting for it to complete.
+Returns whether an operation was started; no operation is needed if the stack is already up to date.
+*/
+func (k *ktnh) startChange(change *PlannedChange) (bool, error) {
+	switch change.Action {
+	case planActionCreate:
+		target := k.forDatabase(change.DBIdentifier)
+
+		// NOTE: the type is not looked up again, since the identifier may be shared by an Aurora cluster and an RDS instance.
+		templateBody, qualifier, err := GenerateTemplate(k.stackNamePrefix, change.DBIdentifier, change.DBType, "", TemplateFormatYAML)
+
+		if err != nil {
+			return false, fmt.Errorf("failed to generate CloudFormation template: %w", err)
+		}
+
+		parameters := maps.Clone(change.Parameters)
+
+		if change.State == statePaused {
+			if parameters == nil {
+				parameters = map[string]string{}
+			}
+
+			parameters[cfn.ParameterProtectionState] = cfn.ProtectionStateDisabled
+		}
+
+		slog.Info("Freezing DB", "dbIdentifier", change.DBIdentifier)
+
+		stackName, err := target.startFreezeByType(change.DBType, templateBody, qualifier, parameters, change.Options)
+
+		if err != nil {
+			return false, err
+		}
+
+		change.StackName = stackName
+
+		return true, nil
+	case planActionUpdate:
+		state := cfn.ProtectionStateEnabled
+
+		if change.State == statePaused {
+			state = cfn.ProtectionStateDisabled
+		}
+
+		return k.startStackUpdate(change.StackName, state, change.Parameters, change.Options)
+	case planActionDelete:
+		slog.Info("Defrosting DB", "dbIdentifier", change.DBIdentifier, "stackName", change.StackName)
+
+		if err := k.cfn.DeleteStack(change.StackName); err != nil {
+			return false, fmt.Errorf("failed to delete CloudFormation stack: %w", err)
+		}
+
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown action '%s'", change.Action)
+	}
+}
+
+/*
+waitForChange waits for the stack operation of the change to complete.
+*/
+func (k *ktnh) waitForChange(change *PlannedChange, timeout time.Duration) error {
+	switch change.Action {
+	case planActionCreate:
+		return k.cfn.WaitForStackCreation(change.StackName, timeout)
+	case planActionUpdate:
+		return k.cfn.WaitForStackUpdate(change.StackName, timeout)
+	case planActionDelete:
+		return k.cfn.WaitForStackDeletion(change.StackName, timeout)
+	default:
+		return fmt.Errorf("unknown action '%s'", change.Action)
+	}
+}
+
+/*
+Tables converts the report into a table for display.
+The result column is included only if the changes have been applied.
+*/
+func (r *PlanReport) Tables() []output.Table {
+	applied := slices.ContainsFunc(r.Changes, func(change PlannedChange) bool {
+		return change.Result != ""
+	})
+
+	table := output.Table{
+		Headers: []string{"action", "id", "type", "stack", "state", "parameters", "options"},
+	}
+
+	if applied {
+		table.Headers = append(table.Headers, "result")
+	}
+
+	for _, change := range r.Changes {
+		var stackName, state any
+
+		if change.StackName != "" {
+			stackName = change.StackName
+		}
+
+		if change.State != "" {
+			state = change.State
+		}
+
+		var parameters, options any
+
+		if change.Parameters != nil {
+			parameters = formatStackParameters(change.Parameters)
+		}
+
+		if change.Options != nil {
+			options = formatStackOptions(*change.Options)
+		}
+
+		row := []any{change.Action, change.DBIdentifier, change.DBType, stackName, state, parameters, options}
+
+		if applied {
+			row = append(row, change.Result)
+		}
+
+		table.Rows = append(table.Rows, row)
+	}
+
+	return []output.Table{table}
+}
+
+/*
+formatStackParameters formats the stack parameters for display, e.g. "LogRetentionInDays=30,ScheduleExpression=rate(3 hours)".
+*/
+func formatStackParameters(parameters map[string]string) string {
+	items := make([]string, 0, len(parameters))
+
+	for _, key := range slices.Sorted(maps.Keys(parameters)) {
+		items = append(items, key+"="+parameters[key])
+	}
+
+	return strings.Join(items, ",")
+}
+
+/*
+formatStackOptions formats the tags and notification targets for display, e.g. "tags: env=dev; notifications: <ARN>".
+*/
+func formatStackOptions(options cfn.StackOptions) string {
+	if options.Equal(cfn.StackOptions{}) {
+		return "(none)"
+	}
+
+	var items []string
+
+	if 0 < len(options.Tags) {
+		tags := make([]string, 0, len(options.Tags))
+
+		for _, key := range slices.Sorted(maps.Keys(options.Tags)) {
+			tags = append(tags, key+"="+options.Tags[key])
+		}
+
+		items = append(items, "tags: "+strings.Join(tags, ","))
+	}
+
+	if 0 < len(options.NotificationARNs) {
+		items = append(items, "notifications: "+strings.Join(options.NotificationARNs, ","))
+	}
+
+	return strings.Join(items, "; ")
+}
@@ -0,0 +1,270 @@
+package ktnh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+)
+
+func Test_planChanges(t *testing.T) {
+	testCases := []struct {
+		name      string
+		manifest  *Manifest
+		databases []displayDBInfo
+		defaults  cfn.StackOptions
+		expected  []PlannedChange
+	}{
+		{
+			name: "No changes",
+			manifest: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db1"},
+					{DBIdentifier: "db2", Paused: true},
+				},
+			},
+			databases: []displayDBInfo{
+				{dbIdentifier: "db1", dbType: "aurora", stackName: "ktnh-db1-aaaaaa", state: "active"},
+				{dbIdentifier: "db2", dbType: "rds", stackName: "ktnh-db2-bbbbbb", state: "paused"},
+			},
+			expected: []PlannedChange{},
+		},
+		{
+			name: "Create, update and delete",
+			manifest: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db3", Paused: true},
+					{DBIdentifier: "db1", Paused: true},
+				},
+			},
+			databases: []displayDBInfo{
+				{dbIdentifier: "db2", dbType: "rds", stackName: "ktnh-db2-bbbbbb", state: "active"},
+				{dbIdentifier: "db1", dbType: "aurora", stackName: "ktnh-db1-aaaaaa", state: "active"},
+			},
+			expected: []PlannedChange{
+				{Action: "update", DBIdentifier: "db1", DBType: "aurora", StackName: "ktnh-db1-aaaaaa", State: "paused"},
+				{Action: "delete", DBIdentifier: "db2", DBType: "rds", StackName: "ktnh-db2-bbbbbb"},
+				{Action: "create", DBIdentifier: "db3", State: "paused"},
+			},
+		},
+		{
+			name: "Unknown state",
+			manifest: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db1"},
+				},
+			},
+			databases: []displayDBInfo{
+				{dbIdentifier: "db1", dbType: "aurora", stackName: "ktnh-db1-aaaaaa", state: "(unknown)"},
+			},
+			expected: []PlannedChange{
+				{Action: "update", DBIdentifier: "db1", DBType: "aurora", StackName: "ktnh-db1-aaaaaa", State: "active"},
+			},
+		},
+		{
+			name: "Cluster and instance with the same identifier",
+			manifest: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db1", DBType: "rds", Paused: true},
+				},
+			},
+			databases: []displayDBInfo{
+				{dbIdentifier: "db1", dbType: "aurora", stackName: "ktnh-db1-aaaaaa", state: "active"},
+				{dbIdentifier: "db1", dbType: "rds", stackName: "ktnh-db1-bbbbbb", state: "active"},
+			},
+			expected: []PlannedChange{
+				{Action: "delete", DBIdentifier: "db1", DBType: "aurora", StackName: "ktnh-db1-aaaaaa"},
+				{Action: "update", DBIdentifier: "db1", DBType: "rds", StackName: "ktnh-db1-bbbbbb", State: "paused"},
+			},
+		},
+		{
+			name: "Create with type",
+			manifest: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db1", DBType: "aurora"},
+					{DBIdentifier: "db1", DBType: "rds"},
+				},
+			},
+			databases: []displayDBInfo{
+				{dbIdentifier: "db1", dbType: "aurora", stackName: "ktnh-db1-aaaaaa", state: "active"},
+			},
+			expected: []PlannedChange{
+				{Action: "create", DBIdentifier: "db1", DBType: "rds", State: "active"},
+			},
+		},
+		{
+			name: "Tags and notification targets",
+			manifest: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db1", Tags: map[string]string{"env": "dev"}},
+					{DBIdentifier: "db2"},
+					{DBIdentifier: "db3", NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:topic"}},
+				},
+			},
+			databases: []displayDBInfo{
+				{dbIdentifier: "db1", dbType: "rds", stackName: "ktnh-db1-aaaaaa", state: "active", stack: &cfn.Stack{Options: cfn.StackOptions{Tags: map[string]string{"env": "prd"}}}},
+				{dbIdentifier: "db2", dbType: "rds", stackName: "ktnh-db2-bbbbbb", state: "active", stack: &cfn.Stack{Options: cfn.StackOptions{Tags: map[string]string{"env": "dev"}}}},
+			},
+			expected: []PlannedChange{
+				{Action: "update", DBIdentifier: "db1", DBType: "rds", StackName: "ktnh-db1-aaaaaa", State: "active", Options: &cfn.StackOptions{Tags: map[string]string{"env": "dev"}}},
+				{Action: "update", DBIdentifier: "db2", DBType: "rds", StackName: "ktnh-db2-bbbbbb", State: "active", Options: &cfn.StackOptions{}},
+				{Action: "create", DBIdentifier: "db3", State: "active", Options: &cfn.StackOptions{NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:topic"}}},
+			},
+		},
+		{
+			name: "Default tags and notification targets",
+			manifest: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db1", Tags: map[string]string{"env": "dev"}},
+					{DBIdentifier: "db2"},
+					{DBIdentifier: "db3", NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:db3"}},
+				},
+			},
+			databases: []displayDBInfo{
+				{dbIdentifier: "db1", dbType: "rds", stackName: "ktnh-db1-aaaaaa", state: "active", stack: &cfn.Stack{Options: cfn.StackOptions{Tags: map[string]string{"team": "db"}}}},
+				{dbIdentifier: "db2", dbType: "rds", stackName: "ktnh-db2-bbbbbb", state: "active", stack: &cfn.Stack{Options: cfn.StackOptions{Tags: map[string]string{"env": "prd", "team": "db"}, NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:topic"}}}},
+			},
+			defaults: cfn.StackOptions{
+				Tags:             map[string]string{"env": "prd", "team": "db"},
+				NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:topic"},
+			},
+			expected: []PlannedChange{
+				{Action: "update", DBIdentifier: "db1", DBType: "rds", StackName: "ktnh-db1-aaaaaa", State: "active", Options: &cfn.StackOptions{Tags: map[string]string{"env": "dev", "team": "db"}, NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:topic"}}},
+				{Action: "create", DBIdentifier: "db3", State: "active", Options: &cfn.StackOptions{Tags: map[string]string{"env": "prd", "team": "db"}, NotificationARNs: []string{"arn:aws:sns:ap-northeast-1:123456789012:db3"}}},
+			},
+		},
+		{
+			name: "Stop schedule and log retention",
+			manifest: &Manifest{
+				Databases: []ManifestDatabase{
+					{DBIdentifier: "db1", StopSchedule: "rate(3 hours)", LogRetention: 30},
+					{DBIdentifier: "db2", StopSchedule: "rate(6 hours)", LogRetention: 30},
+					{DBIdentifier: "db3"},
+					{DBIdentifier: "db4", StopSchedule: "rate(12 hours)"},
+				},
+			},
+			databases: []displayDBInfo{
+				{dbIdentifier: "db1", dbType: "rds", stackName: "ktnh-db1-aaaaaa", state: "active", stack: &cfn.Stack{Parameters: map[string]string{"ScheduleExpression": "rate(6 hours)", "LogRetentionInDays": "30"}}},
+				{dbIdentifier: "db2", dbType: "rds", stackName: "ktnh-db2-bbbbbb", state: "active", stack: &cfn.Stack{Parameters: map[string]string{"ScheduleExpression": "rate(6 hours)", "LogRetentionInDays": "30"}}},
+				{dbIdentifier: "db3", dbType: "rds", stackName: "ktnh-db3-cccccc", state: "active", stack: &cfn.Stack{Parameters: map[string]string{"ScheduleExpression": "rate(1 hour)", "LogRetentionInDays": "7"}}},
+			},
+			expected: []PlannedChange{
+				{Action: "update", DBIdentifier: "db1", DBType: "rds", StackName: "ktnh-db1-aaaaaa", State: "active", Parameters: map[string]string{"ScheduleExpression": "rate(3 hours)"}},
+				{Action: "create", DBIdentifier: "db4", State: "active", Parameters: map[string]string{"ScheduleExpression": "rate(12 hours)"}},
+			},
+		},
+		{
+			name:     "Empty manifest",
+			manifest: &Manifest{},
+			databases: []displayDBInfo{
+				{dbIdentifier: "db1", dbType: "aurora", stackName: "ktnh-db1-aaaaaa", state: "active"},
+			},
+			expected: []PlannedChange{
+				{Action: "delete", DBIdentifier: "db1", DBType: "aurora", StackName: "ktnh-db1-aaaaaa"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := planChanges(tc.manifest, tc.databases, tc.defaults)
+
+			assert.Equal(t, tc.expected, got, "Planned changes do not match expected value")
+		})
+	}
+}
+
+func Test_findDuplicateCreation(t *testing.T) {
+	testCases := []struct {
+		name      string
+		changes   []PlannedChange
+		protected []displayDBInfo
+		wantErr   bool
+	}{
+		{
+			name: "Not protected",
+			changes: []PlannedChange{
+				{Action: "create", DBIdentifier: "db1", DBType: "rds", State: "active"},
+			},
+			protected: []displayDBInfo{
+				{dbIdentifier: "db1", dbType: "aurora", stackName: "corp-db1"},
+				{dbIdentifier: "db2", dbType: "rds", stackName: "corp-db2"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Protected by adopted stack",
+			changes: []PlannedChange{
+				{Action: "update", DBIdentifier: "db2", DBType: "rds", StackName: "ktnh-db2-aaaaaa", State: "paused"},
+				{Action: "create", DBIdentifier: "db1", DBType: "rds", State: "active"},
+			},
+			protected: []displayDBInfo{
+				{dbIdentifier: "db1", dbType: "rds", stackName: "SC-123456789012-pp-abcdef"},
+				{dbIdentifier: "db2", dbType: "rds", stackName: "ktnh-db2-aaaaaa"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := findDuplicateCreation(tc.changes, tc.protected)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+		})
+	}
+}
+
+func Test_PlanReport_Tables(t *testing.T) {
+	testCases := []struct {
+		name            string
+		changes         []PlannedChange
+		expectedHeaders []string
+		expectedRows    [][]any
+	}{
+		{
+			name: "Plan only",
+			changes: []PlannedChange{
+				{Action: "create", DBIdentifier: "db1", DBType: "aurora", State: "active", Options: &cfn.StackOptions{Tags: map[string]string{"team": "db", "env": "dev"}}},
+				{Action: "update", DBIdentifier: "db2", DBType: "rds", StackName: "ktnh-db2-bbbbbb", State: "active", Parameters: map[string]string{"ScheduleExpression": "rate(3 hours)", "LogRetentionInDays": "30"}, Options: &cfn.StackOptions{}},
+				{Action: "delete", DBIdentifier: "db3", DBType: "rds", StackName: "ktnh-db3-cccccc"},
+			},
+			expectedHeaders: []string{"action", "id", "type", "stack", "state", "parameters", "options"},
+			expectedRows: [][]any{
+				{"create", "db1", "aurora", nil, "active", nil, "tags: env=dev,team=db"},
+				{"update", "db2", "rds", "ktnh-db2-bbbbbb", "active", "LogRetentionInDays=30,ScheduleExpression=rate(3 hours)", "(none)"},
+				{"delete", "db3", "rds", "ktnh-db3-cccccc", nil, nil, nil},
+			},
+		},
+		{
+			name: "Applied",
+			changes: []PlannedChange{
+				{Action: "create", DBIdentifier: "db1", DBType: "aurora", StackName: "ktnh-db1-aaaaaa", State: "active", Result: "applied"},
+				{Action: "update", DBIdentifier: "db2", DBType: "rds", StackName: "ktnh-db2-bbbbbb", State: "paused", Result: "failed"},
+			},
+			expectedHeaders: []string{"action", "id", "type", "stack", "state", "parameters", "options", "result"},
+			expectedRows: [][]any{
+				{"create", "db1", "aurora", "ktnh-db1-aaaaaa", "active", nil, nil, "applied"},
+				{"update", "db2", "rds", "ktnh-db2-bbbbbb", "paused", nil, nil, "failed"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := &PlanReport{Changes: tc.changes}
+
+			tables := report.Tables()
+
+			assert.Len(t, tables, 1, "Number of tables does not match expected value")
+
+			assert.Equal(t, tc.expectedHeaders, tables[0].Headers, "Headers do not match expected headers")
+			assert.Equal(t, tc.expectedRows, tables[0].Rows, "Rows do not match expected rows")
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package ktnh
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+)
+
+/*
+stateOrphaned is the state displayed for stacks whose DB no longer exists.
+*/
+const stateOrphaned = "orphaned"
+
+const (
+	pruneResultDryRun   = "would delete" // the stack would be deleted without `--dry-run`
+	pruneResultDeleting = "deleting"     // the deletion has been started but not waited for
+	pruneResultDeleted  = "deleted"      // the stack has been deleted
+	pruneResultFailed   = "failed"       // the deletion failed
+)
+
+/*
+Prune deletes the stacks whose DB no longer exists, and returns a table of the orphaned stacks
+with the result of each deletion. With dryRun, the stacks are only listed.
+All deletions are started first and then waited for, in the same way as `PauseAll`;
+a failure on one stack does not prevent the others from being deleted.
+*/
+func (k *ktnh) Prune(dryRun bool, timeout time.Duration) (*output.Table, error) {
+	orphans, err := k.findOrphanedDatabases()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphaned stacks: %w", err)
+	}
+
+	slog.Info("Found orphaned stacks", "count", len(orphans))
+
+	return k.deleteOrphanedStacks(orphans, dryRun, timeout)
+}
+
+/*
+findOrphanedDatabases returns the managed databases that no longer exist.
+Unlike `list`, failures to describe the DBs are returned as errors so that no stack is deleted by mistake.
+*/
+func (k *ktnh) findOrphanedDatabases() ([]displayDBInfo, error) {
+	databases, err := k.collectManagedDatabases()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect managed databases: %w", err)
+	}
+
+	databases, err = k.updateDBDetails(databases)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existence of databases: %w", err)
+	}
+
+	var orphans []displayDBInfo
+
+	for _, db := range databases {
+		if db.orphaned {
+			orphans = append(orphans, db)
+		}
+	}
+
+	return orphans, nil
+}
+
+/*
+deleteOrphanedStacks deletes the stacks of the given databases and returns a table of the results.
+*/
+func (k *ktnh) deleteOrphanedStacks(orphans []displayDBInfo, dryRun bool, timeout time.Duration) (*output.Table, error) {
+	results := make([]string, len(orphans))
+
+	var errs []error
+
+	for i, db := range orphans {
+		if dryRun {
+			results[i] = pruneResultDryRun
+
+			continue
+		}
+
+		slog.Info("Deleting orphaned stack", "dbIdentifier", db.dbIdentifier, "stackName", db.stackName)
+
+		if err := k.cfn.DeleteStack(db.stackName); err != nil {
+			slog.Warn("Failed to delete orphaned stack",
+				"stackName", db.stackName,
+				"error", err,
+			)
+
+			results[i] = pruneResultFailed
+
+			errs = append(errs, fmt.Errorf("stack '%s': %w", db.stackName, err))
+
+			continue
+		}
+
+		results[i] = pruneResultDeleting
+	}
+
+	if !dryRun && (timeout != 0) {
+		slog.Info("Waiting for CloudFormation stack deletions to complete", "timeout", timeout.Seconds())
+
+		deadline := time.Now().Add(timeout)
+
+		for i, db := range orphans {
+			if results[i] != pruneResultDeleting {
+				continue
+			}
+
+			err := k.cfn.WaitForStackDeletion(db.stackName, max(time.Until(deadline), time.Second))
+
+			if err != nil {
+				results[i] = pruneResultFailed
+
+				errs = append(errs, fmt.Errorf("failed while waiting for deletion of stack '%s': %w", db.stackName, err))
+
+				continue
+			}
+
+			results[i] = pruneResultDeleted
+		}
+	}
+
+	table := &output.Table{
+		Headers: []string{"id", "type", "stack", "result"},
+		Rows:    make([][]any, len(orphans)),
+	}
+
+	for i, db := range orphans {
+		table.Rows[i] = []any{db.dbIdentifier, db.dbType, db.stackName, results[i]}
+	}
+
+	return table, errors.Join(errs...)
+}
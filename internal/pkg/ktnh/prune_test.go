@@ -0,0 +1,235 @@
+package ktnh
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appcfn "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+	apprds "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+func Test_deleteOrphanedStacks(t *testing.T) {
+	orphans := []displayDBInfo{
+		{dbIdentifier: "db1", dbType: "rds", stackName: "A-db1-abcdef"},
+		{dbIdentifier: "db2", dbType: "aurora", stackName: "A-db2-ghijkl"},
+	}
+
+	deleteStackInput := func(stackName string) *cloudformation.DeleteStackInput {
+		return &cloudformation.DeleteStackInput{
+			StackName: aws.String(stackName),
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		dryRun    bool
+		timeout   time.Duration
+		mockSetup func(*appmock.MockCloudFormationFactory, *appmock.MockCloudFormationClient, *appmock.MockStackDeleteCompleteWaiter)
+		expected  []string
+		wantErr   bool
+	}{
+		{
+			name:    "Dry run",
+			dryRun:  true,
+			timeout: time.Minute,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient, w *appmock.MockStackDeleteCompleteWaiter) {
+			},
+			expected: []string{"would delete", "would delete"},
+			wantErr:  false,
+		},
+		{
+			name:    "No wait",
+			dryRun:  false,
+			timeout: 0,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient, w *appmock.MockStackDeleteCompleteWaiter) {
+				c.On("DeleteStack", mock.Anything, deleteStackInput("A-db1-abcdef"), mock.Anything).
+					Return(&cloudformation.DeleteStackOutput{}, nil).
+					Once()
+
+				c.On("DeleteStack", mock.Anything, deleteStackInput("A-db2-ghijkl"), mock.Anything).
+					Return(&cloudformation.DeleteStackOutput{}, nil).
+					Once()
+			},
+			expected: []string{"deleting", "deleting"},
+			wantErr:  false,
+		},
+		{
+			name:    "Partial failure",
+			dryRun:  false,
+			timeout: time.Minute,
+			mockSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient, w *appmock.MockStackDeleteCompleteWaiter) {
+				c.On("DeleteStack", mock.Anything, deleteStackInput("A-db1-abcdef"), mock.Anything).
+					Return(&cloudformation.DeleteStackOutput{}, assert.AnError).
+					Once()
+
+				c.On("DeleteStack", mock.Anything, deleteStackInput("A-db2-ghijkl"), mock.Anything).
+					Return(&cloudformation.DeleteStackOutput{}, nil).
+					Once()
+
+				f.On("NewStackDeleteCompleteWaiter").
+					Return(w, nil).
+					Once()
+
+				w.On("Wait", mock.Anything, &cloudformation.DescribeStacksInput{StackName: aws.String("A-db2-ghijkl")}, mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+			},
+			expected: []string{"failed", "deleted"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudFormationFactory)
+			mockClient := new(appmock.MockCloudFormationClient)
+			mockWaiter := new(appmock.MockStackDeleteCompleteWaiter)
+
+			mockFactory.On("GetClient").
+				Return(mockClient).
+				Maybe()
+
+			tc.mockSetup(mockFactory, mockClient, mockWaiter)
+
+			k := &ktnh{
+				cfn: appcfn.NewCloudFormation(mockFactory),
+			}
+
+			got, err := k.deleteOrphanedStacks(orphans, tc.dryRun, tc.timeout)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+
+			assert.Equal(t, []string{"id", "type", "stack", "result"}, got.Headers, "Headers do not match")
+
+			results := make([]string, len(got.Rows))
+
+			for i, row := range got.Rows {
+				results[i] = row[3].(string)
+			}
+
+			assert.Equal(t, tc.expected, results, "Results do not match")
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+			mockWaiter.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_findOrphanedDatabases(t *testing.T) {
+	mockFactoryCloudFormation := new(appmock.MockCloudFormationFactory)
+	mockClientCloudFormation := new(appmock.MockCloudFormationClient)
+	mockListStacksPaginator := new(appmock.MockListStacksPaginator)
+	mockFactoryRDS := new(appmock.MockRDSFactory)
+	mockDescribeDBInstancesPaginator := new(appmock.MockDescribeDBInstancesPaginator)
+
+	mockFactoryCloudFormation.On("GetClient").
+		Return(mockClientCloudFormation)
+
+	mockFactoryCloudFormation.On("NewListStacksPaginator", mock.Anything).
+		Return(mockListStacksPaginator, nil)
+
+	mockListStacksPaginator.On("HasMorePages").
+		Return(true).
+		Once()
+
+	mockListStacksPaginator.On("NextPage", mock.Anything, mock.Anything).
+		Return(&cloudformation.ListStacksOutput{
+			StackSummaries: []cfntypes.StackSummary{
+				{
+					StackName: aws.String("A-My-DB-abcdef"),
+				},
+				{
+					StackName: aws.String("A-db2-ghijkl"),
+				},
+			},
+		}, nil).
+		Once()
+
+	mockListStacksPaginator.On("HasMorePages").
+		Return(false).
+		Once()
+
+	for stackName, dbIdentifier := range map[string]string{
+		"A-My-DB-abcdef": "My-DB",
+		"A-db2-ghijkl":   "db2",
+	} {
+		templateBody := strings.Join([]string{
+			"Metadata:",
+			"  KTNH:",
+			"    Generator: 'koreru-toki-no-hiho'",
+			"    Version: '1'",
+			"    DBIdentifier: '" + dbIdentifier + "'",
+			"    DBType: 'rds'",
+		}, "\n")
+
+		mockClientCloudFormation.On("GetTemplate", mock.Anything, &cloudformation.GetTemplateInput{StackName: aws.String(stackName)}, mock.Anything).
+			Return(&cloudformation.GetTemplateOutput{TemplateBody: aws.String(templateBody)}, nil).
+			Once()
+	}
+
+	mockFactoryRDS.On("NewDescribeDBInstancesPaginator", &rds.DescribeDBInstancesInput{
+		Filters: []rdstypes.Filter{
+			{
+				Name:   aws.String("db-instance-id"),
+				Values: []string{"my-db", "db2"},
+			},
+		},
+	}).
+		Return(mockDescribeDBInstancesPaginator, nil)
+
+	mockDescribeDBInstancesPaginator.On("HasMorePages").
+		Return(true).
+		Once()
+
+	// NOTE: RDS returns identifiers in lowercase regardless of the case given on creation.
+	mockDescribeDBInstancesPaginator.On("NextPage", mock.Anything, mock.Anything).
+		Return(&rds.DescribeDBInstancesOutput{
+			DBInstances: []rdstypes.DBInstance{
+				{
+					DBInstanceIdentifier: aws.String("my-db"),
+					DBInstanceStatus:     aws.String("stopped"),
+				},
+			},
+		}, nil).
+		Once()
+
+	mockDescribeDBInstancesPaginator.On("HasMorePages").
+		Return(false).
+		Once()
+
+	k := &ktnh{
+		stackNamePrefix: "A",
+		cfn:             appcfn.NewCloudFormation(mockFactoryCloudFormation),
+		rds:             apprds.NewRDS(mockFactoryRDS),
+	}
+
+	got, err := k.findOrphanedDatabases()
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	if assert.Len(t, got, 1, "Only the deleted DB should be orphaned") {
+		assert.Equal(t, "db2", got[0].dbIdentifier, "Orphaned DB does not match")
+		assert.Equal(t, "A-db2-ghijkl", got[0].stackName, "Orphaned stack does not match")
+	}
+
+	mockFactoryCloudFormation.AssertExpectations(t)
+	mockClientCloudFormation.AssertExpectations(t)
+	mockListStacksPaginator.AssertExpectations(t)
+	mockFactoryRDS.AssertExpectations(t)
+	mockDescribeDBInstancesPaginator.AssertExpectations(t)
+}
@@ -0,0 +1,285 @@
+package ktnh
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+)
+
+/*
+ScanReport holds the stopped databases that are not protected by ktnh.
+*/
+type ScanReport struct {
+	Databases []AtRiskDatabase `json:"databases"` // at-risk databases, in the order of the estimated automatic start
+}
+
+/*
+AtRiskDatabase holds a stopped database that will be started automatically by AWS.
+*/
+type AtRiskDatabase struct {
+	DBIdentifier string     `json:"id"`               // DB cluster/instance identifier
+	DBType       string     `json:"type"`             // type of the DB (see `internal/pkg/rds`)
+	StoppedAt    *time.Time `json:"stoppedAt"`        // time when the DB was stopped (nil if unknown)
+	AutoStartAt  *time.Time `json:"autoStartAt"`      // estimated time of the automatic start (nil if unknown)
+	Result       string     `json:"result,omitempty"` // result of freezing the DB (empty if not frozen)
+}
+
+/*
+autoStartDelay is the period after which AWS automatically starts a stopped DB.
+*/
+const autoStartDelay = 7 * 24 * time.Hour
+
+const (
+	scanResultFreezing = "freezing" // the stack creation has been started but not waited for
+	scanResultFrozen   = "frozen"   // the stack has been created
+	scanResultFailed   = "failed"   // the stack creation failed
+)
+
+/*
+Scan finds the stopped Aurora clusters and RDS instances in the region that have no ktnh stack,
+and estimates when each of them will be started automatically from the time it was stopped.
+With freeze, a stack is created for each of them, in the same way as `PauseAll`;
+a failure on one DB does not prevent the others from being frozen.
+*/
+func (k *ktnh) Scan(freeze bool, timeout time.Duration) (*ScanReport, error) {
+	report, err := k.findAtRiskDatabases()
+
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Found stopped databases not protected by ktnh", "count", len(report.Databases))
+
+	if !freeze {
+		return report, nil
+	}
+
+	return report, k.freezeAtRiskDatabases(report, timeout)
+}
+
+/*
+AtRisk returns the number of databases that are still not protected by ktnh,
+i.e. those not frozen or whose freezing failed.
+*/
+func (r *ScanReport) AtRisk() int {
+	count := 0
+
+	for _, db := range r.Databases {
+		if (db.Result == "") || (db.Result == scanResultFailed) {
+			count++
+		}
+	}
+
+	return count
+}
+
+/*
+findAtRiskDatabases lists the stopped databases without a ktnh stack.
+The stacks of all prefixes and those adopted by name protect the databases as well,
+so that scan does not report (or freeze again) a database frozen under another prefix.
+Failures to retrieve the stop times are logged and the estimates are left unknown,
+since the databases are at risk regardless.
+*/
+func (k *ktnh) findAtRiskDatabases() (*ScanReport, error) {
+	managed, err := k.findStacks(&stackSearchOption{anyPrefix: true})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect managed databases: %w", err)
+	}
+
+	protected := map[string]bool{}
+
+	for _, db := range managed {
+		protected[rdsKey(db)] = true
+	}
+
+	stopped, err := k.rds.ListStoppedDBs()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stopped databases: %w", err)
+	}
+
+	var databases []displayDBInfo
+
+	for _, db := range stopped {
+		candidate := displayDBInfo{
+			dbIdentifier: normalizeIdentifier(db.DBIdentifier),
+			dbType:       db.DBType,
+		}
+
+		if protected[rdsKey(candidate)] {
+			slog.Debug("Database is protected by ktnh", "dbIdentifier", db.DBIdentifier)
+
+			continue
+		}
+
+		databases = append(databases, candidate)
+	}
+
+	report := &ScanReport{
+		Databases: make([]AtRiskDatabase, len(databases)),
+	}
+
+	if len(databases) == 0 {
+		return report, nil
+	}
+
+	clusters, instances := splitDBsByType(databases)
+
+	lastStops, err := k.rds.GetLastStopTimes(clusters, instances)
+
+	if err != nil {
+		slog.Warn("Failed to retrieve last stop times", "error", err)
+	}
+
+	for i, db := range databases {
+		report.Databases[i] = AtRiskDatabase{
+			DBIdentifier: db.dbIdentifier,
+			DBType:       db.dbType,
+		}
+
+		if stoppedAt, ok := lastStops[rdsKey(db)]; ok {
+			autoStartAt := stoppedAt.Add(autoStartDelay)
+
+			report.Databases[i].StoppedAt = &stoppedAt
+			report.Databases[i].AutoStartAt = &autoStartAt
+		}
+	}
+
+	// NOTE: databases with unknown estimates are listed last.
+	slices.SortStableFunc(report.Databases, func(a, b AtRiskDatabase) int {
+		switch {
+		case (a.AutoStartAt == nil) && (b.AutoStartAt == nil):
+			return 0
+		case a.AutoStartAt == nil:
+			return 1
+		case b.AutoStartAt == nil:
+			return -1
+		default:
+			return a.AutoStartAt.Compare(*b.AutoStartAt)
+		}
+	})
+
+	return report, nil
+}
+
+/*
+freezeAtRiskDatabases creates a stack for each database in the report and records the results.
+All stack creations are started first and then waited for against a shared deadline.
+*/
+func (k *ktnh) freezeAtRiskDatabases(report *ScanReport, timeout time.Duration) error {
+	stackNames := make([]string, len(report.Databases))
+
+	var errs []error
+
+	for i := range report.Databases {
+		db := &report.Databases[i]
+
+		stackName, err := k.startFreezeDatabase(db.DBIdentifier)
+
+		if err != nil {
+			slog.Warn("Failed to freeze DB",
+				"dbIdentifier", db.DBIdentifier,
+				"error", err,
+			)
+
+			db.Result = scanResultFailed
+
+			errs = append(errs, fmt.Errorf("DB '%s': %w", db.DBIdentifier, err))
+
+			continue
+		}
+
+		stackNames[i] = stackName
+		db.Result = scanResultFreezing
+	}
+
+	if timeout != 0 {
+		slog.Info("Waiting for CloudFormation stack creations to complete", "timeout", timeout.Seconds())
+
+		deadline := time.Now().Add(timeout)
+
+		for i := range report.Databases {
+			db := &report.Databases[i]
+
+			if db.Result != scanResultFreezing {
+				continue
+			}
+
+			err := k.cfn.WaitForStackCreation(stackNames[i], max(time.Until(deadline), time.Second))
+
+			if err != nil {
+				db.Result = scanResultFailed
+
+				errs = append(errs, fmt.Errorf("failed while waiting for creation of stack '%s': %w", stackNames[i], err))
+
+				continue
+			}
+
+			db.Result = scanResultFrozen
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+/*
+startFreezeDatabase generates the template for the database and starts the creation of its stack.
+*/
+func (k *ktnh) startFreezeDatabase(dbIdentifier string) (string, error) {
+	target := k.forDatabase(dbIdentifier)
+
+	templateBody, qualifier, err := target.Template()
+
+	if err != nil {
+		return "", fmt.Errorf("failed to generate CloudFormation template: %w", err)
+	}
+
+	slog.Info("Freezing DB", "dbIdentifier", dbIdentifier)
+
+	return target.startFreeze(templateBody, qualifier, nil)
+}
+
+/*
+Tables converts the report into a table for display.
+The result column is included only if freezing has been attempted.
+*/
+func (r *ScanReport) Tables() []output.Table {
+	frozen := slices.ContainsFunc(r.Databases, func(db AtRiskDatabase) bool {
+		return db.Result != ""
+	})
+
+	table := output.Table{
+		Headers: []string{"id", "type", "stopped-at", "auto-start-at"},
+	}
+
+	if frozen {
+		table.Headers = append(table.Headers, "result")
+	}
+
+	for _, db := range r.Databases {
+		var stoppedAt, autoStartAt any = unknownValue, unknownValue
+
+		if db.StoppedAt != nil {
+			stoppedAt = *db.StoppedAt
+		}
+
+		if db.AutoStartAt != nil {
+			autoStartAt = *db.AutoStartAt
+		}
+
+		row := []any{db.DBIdentifier, db.DBType, stoppedAt, autoStartAt}
+
+		if frozen {
+			row = append(row, db.Result)
+		}
+
+		table.Rows = append(table.Rows, row)
+	}
+
+	return []output.Table{table}
+}
@@ -0,0 +1,225 @@
+package ktnh
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appcfn "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+	apprds "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+func Test_ScanReport_AtRisk(t *testing.T) {
+	testCases := []struct {
+		name     string
+		results  []string
+		expected int
+	}{
+		{
+			name:     "Not frozen",
+			results:  []string{"", ""},
+			expected: 2,
+		},
+		{
+			name:     "Frozen",
+			results:  []string{"frozen", "freezing"},
+			expected: 0,
+		},
+		{
+			name:     "Partially failed",
+			results:  []string{"frozen", "failed"},
+			expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := &ScanReport{}
+
+			for _, result := range tc.results {
+				report.Databases = append(report.Databases, AtRiskDatabase{Result: result})
+			}
+
+			assert.Equal(t, tc.expected, report.AtRisk(), "Number of at-risk databases does not match expected value")
+		})
+	}
+}
+
+func Test_ScanReport_Tables(t *testing.T) {
+	stoppedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	autoStartAt := stoppedAt.Add(7 * 24 * time.Hour)
+
+	testCases := []struct {
+		name            string
+		databases       []AtRiskDatabase
+		expectedHeaders []string
+		expectedRows    [][]any
+	}{
+		{
+			name: "Scan only",
+			databases: []AtRiskDatabase{
+				{DBIdentifier: "db1", DBType: "aurora", StoppedAt: &stoppedAt, AutoStartAt: &autoStartAt},
+				{DBIdentifier: "db2", DBType: "rds"},
+			},
+			expectedHeaders: []string{"id", "type", "stopped-at", "auto-start-at"},
+			expectedRows: [][]any{
+				{"db1", "aurora", stoppedAt, autoStartAt},
+				{"db2", "rds", "(unknown)", "(unknown)"},
+			},
+		},
+		{
+			name: "Frozen",
+			databases: []AtRiskDatabase{
+				{DBIdentifier: "db1", DBType: "aurora", StoppedAt: &stoppedAt, AutoStartAt: &autoStartAt, Result: "frozen"},
+				{DBIdentifier: "db2", DBType: "rds", Result: "failed"},
+			},
+			expectedHeaders: []string{"id", "type", "stopped-at", "auto-start-at", "result"},
+			expectedRows: [][]any{
+				{"db1", "aurora", stoppedAt, autoStartAt, "frozen"},
+				{"db2", "rds", "(unknown)", "(unknown)", "failed"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := &ScanReport{Databases: tc.databases}
+
+			tables := report.Tables()
+
+			assert.Len(t, tables, 1, "Number of tables does not match expected value")
+
+			assert.Equal(t, tc.expectedHeaders, tables[0].Headers, "Headers do not match expected headers")
+			assert.Equal(t, tc.expectedRows, tables[0].Rows, "Rows do not match expected rows")
+		})
+	}
+}
+
+func Test_findAtRiskDatabases(t *testing.T) {
+	mockFactoryCloudFormation := new(appmock.MockCloudFormationFactory)
+	mockClientCloudFormation := new(appmock.MockCloudFormationClient)
+	mockListStacksPaginator := new(appmock.MockListStacksPaginator)
+	mockFactoryRDS := new(appmock.MockRDSFactory)
+	mockDescribeDBClustersPaginator := new(appmock.MockDescribeDBClustersPaginator)
+	mockDescribeDBInstancesPaginator := new(appmock.MockDescribeDBInstancesPaginator)
+
+	mockFactoryCloudFormation.On("GetClient").
+		Return(mockClientCloudFormation)
+
+	mockFactoryCloudFormation.On("NewListStacksPaginator", mock.Anything).
+		Return(mockListStacksPaginator, nil)
+
+	mockListStacksPaginator.On("HasMorePages").
+		Return(true).
+		Once()
+
+	mockListStacksPaginator.On("NextPage", mock.Anything, mock.Anything).
+		Return(&cloudformation.ListStacksOutput{
+			StackSummaries: []cfntypes.StackSummary{
+				{
+					StackName:           aws.String("A-db1-abcdef"),
+					TemplateDescription: aws.String("ktnh - Keep RDS instance 'db1' stopped"),
+				},
+				{
+					StackName:           aws.String("B-DB2-ghijkl"),
+					TemplateDescription: aws.String("ktnh - Keep RDS instance 'DB2' stopped"),
+				},
+				{
+					StackName:           aws.String("unrelated"),
+					TemplateDescription: aws.String("Something else"),
+				},
+			},
+		}, nil).
+		Once()
+
+	mockListStacksPaginator.On("HasMorePages").
+		Return(false).
+		Once()
+
+	for stackName, dbIdentifier := range map[string]string{
+		"A-db1-abcdef": "db1",
+		"B-DB2-ghijkl": "DB2",
+	} {
+		templateBody := strings.Join([]string{
+			"Metadata:",
+			"  KTNH:",
+			"    Generator: 'koreru-toki-no-hiho'",
+			"    Version: '1'",
+			"    DBIdentifier: '" + dbIdentifier + "'",
+			"    DBType: 'rds'",
+		}, "\n")
+
+		mockClientCloudFormation.On("GetTemplate", mock.Anything, &cloudformation.GetTemplateInput{StackName: aws.String(stackName)}, mock.Anything).
+			Return(&cloudformation.GetTemplateOutput{TemplateBody: aws.String(templateBody)}, nil).
+			Once()
+	}
+
+	mockFactoryRDS.On("NewDescribeDBClustersPaginator", mock.Anything).
+		Return(mockDescribeDBClustersPaginator, nil)
+
+	mockDescribeDBClustersPaginator.On("HasMorePages").
+		Return(false).
+		Once()
+
+	mockFactoryRDS.On("NewDescribeDBInstancesPaginator", mock.Anything).
+		Return(mockDescribeDBInstancesPaginator, nil)
+
+	mockDescribeDBInstancesPaginator.On("HasMorePages").
+		Return(true).
+		Once()
+
+	mockDescribeDBInstancesPaginator.On("NextPage", mock.Anything, mock.Anything).
+		Return(&rds.DescribeDBInstancesOutput{
+			DBInstances: []rdstypes.DBInstance{
+				{
+					DBInstanceIdentifier: aws.String("db1"),
+					DBInstanceStatus:     aws.String("stopped"),
+				},
+				{
+					DBInstanceIdentifier: aws.String("db2"),
+					DBInstanceStatus:     aws.String("stopped"),
+				},
+				{
+					DBInstanceIdentifier: aws.String("db3"),
+					DBInstanceStatus:     aws.String("stopped"),
+				},
+			},
+		}, nil).
+		Once()
+
+	mockDescribeDBInstancesPaginator.On("HasMorePages").
+		Return(false).
+		Once()
+
+	mockFactoryRDS.On("NewDescribeEventsPaginator", mock.Anything).
+		Return(nil, assert.AnError).
+		Once()
+
+	k := &ktnh{
+		stackNamePrefix: "A",
+		cfn:             appcfn.NewCloudFormation(mockFactoryCloudFormation),
+		rds:             apprds.NewRDS(mockFactoryRDS),
+	}
+
+	got, err := k.findAtRiskDatabases()
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	assert.Equal(t, []AtRiskDatabase{{DBIdentifier: "db3", DBType: "rds"}}, got.Databases, "Only the DB without a stack should be at risk")
+
+	mockFactoryCloudFormation.AssertExpectations(t)
+	mockClientCloudFormation.AssertExpectations(t)
+	mockListStacksPaginator.AssertExpectations(t)
+	mockFactoryRDS.AssertExpectations(t)
+	mockDescribeDBClustersPaginator.AssertExpectations(t)
+	mockDescribeDBInstancesPaginator.AssertExpectations(t)
+}
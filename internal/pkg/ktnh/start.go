@@ -0,0 +1,131 @@
+package ktnh
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+/*
+StartReport holds the connection information of a started database.
+*/
+type StartReport struct {
+	DBIdentifier   string `json:"id"`                       // DB cluster/instance identifier
+	DBType         string `json:"type"`                     // type of the DB (see `internal/pkg/rds`)
+	Status         string `json:"status"`                   // DB cluster/instance status ("starting" if not waited for)
+	Endpoint       string `json:"endpoint"`                 // endpoint address (writer endpoint for Aurora clusters)
+	ReaderEndpoint string `json:"readerEndpoint,omitempty"` // reader endpoint address (Aurora clusters only)
+	Port           int32  `json:"port"`                     // port number
+}
+
+/*
+Start starts the database and waits until it, and all member instances of Aurora clusters,
+are available unless timeout is 0.
+DBs that are already starting or available are not started again.
+A DB being stopped cannot be started until it is stopped, so it is waited for to be stopped
+and then started within the same timeout; with timeout 0, an error is returned instead.
+*/
+func (k *ktnh) Start(timeout time.Duration) (*StartReport, error) {
+	dbType, err := k.rds.DetermineDBType(k.dbIdentifier)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine DB type: %w", err)
+	}
+
+	status, err := k.rds.DescribeDBStatus(k.dbIdentifier, string(dbType))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe DB status: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	if status.Status == rds.StatusStopping {
+		if timeout == 0 {
+			return nil, fmt.Errorf("DB is being stopped; retry when it is stopped, or wait for it with a timeout")
+		}
+
+		slog.Info("Waiting for DB to be stopped before starting", "timeout", timeout.Seconds())
+
+		err = k.rds.WaitForDBStatus(k.dbIdentifier, string(dbType), rds.StatusStopped, timeout)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed while waiting for DB to be stopped: %w", err)
+		}
+
+		status.Status = rds.StatusStopped
+	}
+
+	if status.Status == rds.StatusStopped {
+		slog.Info("Starting DB", "dbIdentifier", k.dbIdentifier)
+
+		err = k.rds.StartDB(k.dbIdentifier, string(dbType))
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to start DB: %w", err)
+		}
+
+		status.Status = "starting"
+	} else {
+		slog.Info("DB is not stopped, skipped starting", "status", status.Status)
+	}
+
+	if timeout == 0 {
+		slog.Info("Skipped wait for DB to be available")
+
+		return newStartReport(string(dbType), status), nil
+	}
+
+	slog.Info("Waiting for DB to be available", "timeout", time.Until(deadline).Seconds())
+
+	err = k.rds.WaitForDBStatus(k.dbIdentifier, string(dbType), rds.StatusAvailable, max(time.Until(deadline), time.Second))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed while waiting for DB to be available: %w", err)
+	}
+
+	status, err = k.rds.DescribeDBStatus(k.dbIdentifier, string(dbType))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe DB status: %w", err)
+	}
+
+	return newStartReport(string(dbType), status), nil
+}
+
+/*
+newStartReport builds a StartReport from the status of the DB.
+*/
+func newStartReport(dbType string, status *rds.DBStatus) *StartReport {
+	return &StartReport{
+		DBIdentifier:   status.Identifier,
+		DBType:         dbType,
+		Status:         status.Status,
+		Endpoint:       status.Endpoint,
+		ReaderEndpoint: status.ReaderEndpoint,
+		Port:           status.Port,
+	}
+}
+
+/*
+Tables converts the report into a table for display.
+*/
+func (r *StartReport) Tables() []output.Table {
+	var readerEndpoint any
+
+	if r.ReaderEndpoint != "" {
+		readerEndpoint = r.ReaderEndpoint
+	}
+
+	return []output.Table{
+		{
+			Headers: []string{"id", "type", "status", "endpoint", "reader-endpoint", "port"},
+			Rows: [][]any{
+				{r.DBIdentifier, r.DBType, r.Status, r.Endpoint, readerEndpoint, r.Port},
+			},
+		},
+	}
+}
@@ -0,0 +1,192 @@
+package ktnh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+	apprds "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
+)
+
+func Test_Start(t *testing.T) {
+	clusterOutput := func(status string) *rds.DescribeDBClustersOutput {
+		return &rds.DescribeDBClustersOutput{
+			DBClusters: []rdstypes.DBCluster{
+				{
+					DBClusterIdentifier: aws.String("db1"),
+					Engine:              aws.String("aurora-postgresql"),
+					Status:              aws.String(status),
+					Endpoint:            aws.String("db1.cluster-xxx.rds.amazonaws.com"),
+					ReaderEndpoint:      aws.String("db1.cluster-ro-xxx.rds.amazonaws.com"),
+					Port:                aws.Int32(5432),
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		timeout   time.Duration
+		mockSetup func(*appmock.MockRDSClient)
+		expected  *StartReport
+		wantErr   bool
+	}{
+		{
+			name:    "Start and wait",
+			timeout: time.Minute,
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("DescribeDBClusters", mock.Anything, mock.Anything, mock.Anything).
+					Return(clusterOutput("stopped"), nil).
+					Twice()
+
+				c.On("StartDBCluster", mock.Anything, &rds.StartDBClusterInput{DBClusterIdentifier: aws.String("db1")}, mock.Anything).
+					Return(&rds.StartDBClusterOutput{}, nil).
+					Once()
+
+				c.On("DescribeDBClusters", mock.Anything, mock.Anything, mock.Anything).
+					Return(clusterOutput("available"), nil).
+					Twice()
+			},
+			expected: &StartReport{
+				DBIdentifier:   "db1",
+				DBType:         "aurora",
+				Status:         "available",
+				Endpoint:       "db1.cluster-xxx.rds.amazonaws.com",
+				ReaderEndpoint: "db1.cluster-ro-xxx.rds.amazonaws.com",
+				Port:           5432,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "No wait",
+			timeout: 0,
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("DescribeDBClusters", mock.Anything, mock.Anything, mock.Anything).
+					Return(clusterOutput("stopped"), nil).
+					Twice()
+
+				c.On("StartDBCluster", mock.Anything, mock.Anything, mock.Anything).
+					Return(&rds.StartDBClusterOutput{}, nil).
+					Once()
+			},
+			expected: &StartReport{
+				DBIdentifier:   "db1",
+				DBType:         "aurora",
+				Status:         "starting",
+				Endpoint:       "db1.cluster-xxx.rds.amazonaws.com",
+				ReaderEndpoint: "db1.cluster-ro-xxx.rds.amazonaws.com",
+				Port:           5432,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Already available",
+			timeout: time.Minute,
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("DescribeDBClusters", mock.Anything, mock.Anything, mock.Anything).
+					Return(clusterOutput("available"), nil).
+					Times(4)
+			},
+			expected: &StartReport{
+				DBIdentifier:   "db1",
+				DBType:         "aurora",
+				Status:         "available",
+				Endpoint:       "db1.cluster-xxx.rds.amazonaws.com",
+				ReaderEndpoint: "db1.cluster-ro-xxx.rds.amazonaws.com",
+				Port:           5432,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Wait for stopping DB and start",
+			timeout: time.Minute,
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("DescribeDBClusters", mock.Anything, mock.Anything, mock.Anything).
+					Return(clusterOutput("stopping"), nil).
+					Twice()
+
+				c.On("DescribeDBClusters", mock.Anything, mock.Anything, mock.Anything).
+					Return(clusterOutput("stopped"), nil).
+					Once()
+
+				c.On("StartDBCluster", mock.Anything, &rds.StartDBClusterInput{DBClusterIdentifier: aws.String("db1")}, mock.Anything).
+					Return(&rds.StartDBClusterOutput{}, nil).
+					Once()
+
+				c.On("DescribeDBClusters", mock.Anything, mock.Anything, mock.Anything).
+					Return(clusterOutput("available"), nil).
+					Twice()
+			},
+			expected: &StartReport{
+				DBIdentifier:   "db1",
+				DBType:         "aurora",
+				Status:         "available",
+				Endpoint:       "db1.cluster-xxx.rds.amazonaws.com",
+				ReaderEndpoint: "db1.cluster-ro-xxx.rds.amazonaws.com",
+				Port:           5432,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Stopping DB without wait",
+			timeout: 0,
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("DescribeDBClusters", mock.Anything, mock.Anything, mock.Anything).
+					Return(clusterOutput("stopping"), nil).
+					Twice()
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name:    "Start error",
+			timeout: time.Minute,
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("DescribeDBClusters", mock.Anything, mock.Anything, mock.Anything).
+					Return(clusterOutput("stopped"), nil).
+					Twice()
+
+				c.On("StartDBCluster", mock.Anything, mock.Anything, mock.Anything).
+					Return(&rds.StartDBClusterOutput{}, assert.AnError).
+					Once()
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockRDSFactory)
+			mockClient := new(appmock.MockRDSClient)
+
+			mockFactory.On("GetClient").
+				Return(mockClient)
+
+			tc.mockSetup(mockClient)
+
+			k := &ktnh{
+				dbIdentifier: "db1",
+				rds:          apprds.NewRDS(mockFactory),
+			}
+
+			got, err := k.Start(tc.timeout)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Start report does not match expected value")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
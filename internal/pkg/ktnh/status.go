@@ -0,0 +1,358 @@
+package ktnh
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+)
+
+/*
+StatusReport holds detailed information about a single database and its ktnh stack.
+*/
+type StatusReport struct {
+	DBIdentifier       string             `json:"id"`                 // DB cluster/instance identifier
+	DBType             string             `json:"type"`               // type of the DB (see `internal/pkg/rds`)
+	DBStatus           string             `json:"dbStatus"`           // current DB status
+	Members            []MemberStatus     `json:"members,omitempty"`  // member instances (Aurora clusters only)
+	Maintenance        string             `json:"maintenance"`        // pending maintenance status
+	MaintenanceActions []PendingAction    `json:"maintenanceActions"` // pending maintenance actions (nil if unknown)
+	Protected          bool               `json:"protected"`          // whether the DB is effectively kept stopped
+	Stack              *StackStatusReport `json:"stack"`              // ktnh stack information (nil if not frozen)
+}
+
+/*
+MemberStatus holds the status of a member instance of an Aurora cluster.
+*/
+type MemberStatus struct {
+	DBIdentifier string `json:"id"`       // DB instance identifier
+	Status       string `json:"status"`   // DB instance status
+	IsWriter     bool   `json:"isWriter"` // whether the instance is the writer of the cluster
+}
+
+/*
+StackStatusReport holds information about the ktnh stack of a database.
+*/
+type StackStatusReport struct {
+	Name             string            `json:"name"`             // stack name
+	Status           string            `json:"status"`           // stack status
+	CreationTime     time.Time         `json:"creationTime"`     // time when the stack was created
+	RuleState        string            `json:"ruleState"`        // state of the auto-start event rule
+	ScheduleState    string            `json:"scheduleState"`    // state of the periodic stop schedule
+	NextScheduledRun *time.Time        `json:"nextScheduledRun"` // estimated next run of the schedule (nil if unknown)
+	Executions       []ExecutionStatus `json:"executions"`       // recent state machine executions
+	Resources        []ResourceStatus  `json:"resources"`        // physical resources of the stack
+}
+
+/*
+ExecutionStatus holds the outcome of a state machine execution.
+*/
+type ExecutionStatus struct {
+	Name      string     `json:"name"`      // execution name
+	Status    string     `json:"status"`    // execution status
+	StartDate time.Time  `json:"startDate"` // time when the execution started
+	StopDate  *time.Time `json:"stopDate"`  // time when the execution stopped (nil if still running)
+}
+
+/*
+ResourceStatus holds information about a physical resource of the stack.
+*/
+type ResourceStatus struct {
+	LogicalID  string `json:"logicalId"`  // logical ID of the resource
+	PhysicalID string `json:"physicalId"` // physical ID of the resource
+	Type       string `json:"type"`       // resource type
+	Status     string `json:"status"`     // resource status
+}
+
+const (
+	unknownValue = "(unknown)" // value displayed when information could not be retrieved
+	enabledState = "ENABLED"   // state of an enabled rule or schedule
+)
+
+/*
+healthyStackStatuses lists stack statuses in which the stack resources are considered to be in place.
+*/
+var healthyStackStatuses = []string{
+	"CREATE_COMPLETE",
+	"UPDATE_COMPLETE",
+	"UPDATE_ROLLBACK_COMPLETE",
+	"IMPORT_COMPLETE",
+}
+
+/*
+Status returns detailed information about the database and its ktnh stack.
+Failures to retrieve non-essential information are logged and reported as "(unknown)".
+*/
+func (k *ktnh) Status(executionCount int) (*StatusReport, error) {
+	dbType, err := k.rds.DetermineDBType(k.dbIdentifier)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine DB type: %w", err)
+	}
+
+	dbStatus, err := k.rds.DescribeDBStatus(k.dbIdentifier, string(dbType))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe DB status: %w", err)
+	}
+
+	report := &StatusReport{
+		DBIdentifier: k.dbIdentifier,
+		DBType:       string(dbType),
+		DBStatus:     dbStatus.Status,
+		Maintenance:  unknownValue,
+	}
+
+	actions, err := k.pendingActions([]displayDBInfo{
+		{
+			dbIdentifier: k.dbIdentifier,
+			dbType:       string(dbType),
+		},
+	})
+
+	if err != nil {
+		slog.Warn("Failed to retrieve pending maintenance actions", "error", err)
+	} else {
+		report.Maintenance = summarizePendingActions(actions)
+		report.MaintenanceActions = append([]PendingAction{}, actions...)
+	}
+
+	for _, member := range dbStatus.Members {
+		report.Members = append(report.Members, MemberStatus{
+			DBIdentifier: member.Identifier,
+			Status:       member.Status,
+			IsWriter:     member.IsWriter,
+		})
+	}
+
+	stackName, found, err := k.findMatchingStackByType(string(dbType))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find matching stack: %w", err)
+	}
+
+	if !found {
+		slog.Debug("No stack found for DB identifier")
+
+		return report, nil
+	}
+
+	stackReport, err := k.stackStatus(stackName, executionCount)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve stack status: %w", err)
+	}
+
+	report.Stack = stackReport
+
+	report.Protected = isProtected(stackReport)
+
+	return report, nil
+}
+
+/*
+stackStatus collects information about the stack and the resources it contains.
+*/
+func (k *ktnh) stackStatus(stackName string, executionCount int) (*StackStatusReport, error) {
+	stack, err := k.cfn.DescribeStack(stackName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe stack: %w", err)
+	}
+
+	resources, err := k.cfn.ListStackResources(stackName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stack resources: %w", err)
+	}
+
+	report := &StackStatusReport{
+		Name:          stack.Name,
+		Status:        stack.Status,
+		CreationTime:  stack.CreationTime,
+		RuleState:     unknownValue,
+		ScheduleState: unknownValue,
+		Executions:    []ExecutionStatus{},
+		Resources:     make([]ResourceStatus, len(resources)),
+	}
+
+	for i, resource := range resources {
+		report.Resources[i] = ResourceStatus{
+			LogicalID:  resource.LogicalID,
+			PhysicalID: resource.PhysicalID,
+			Type:       resource.Type,
+			Status:     resource.Status,
+		}
+	}
+
+	if ruleName, ok := cfn.FindPhysicalID(resources, cfn.LogicalIDAutoStartEventRule); ok {
+		state, err := k.events.GetRuleState(ruleName)
+
+		if err != nil {
+			slog.Warn("Failed to retrieve event rule state", "error", err)
+		} else {
+			report.RuleState = state
+		}
+	}
+
+	if scheduleName, ok := cfn.FindPhysicalID(resources, cfn.LogicalIDPeriodicStopSchedule); ok {
+		schedule, err := k.scheduler.GetSchedule(scheduleName)
+
+		if err != nil {
+			slog.Warn("Failed to retrieve schedule", "error", err)
+		} else {
+			report.ScheduleState = schedule.State
+
+			if nextRun, ok := schedule.NextRun(time.Now()); ok {
+				report.NextScheduledRun = &nextRun
+			}
+		}
+	}
+
+	if stateMachineArn, ok := cfn.FindPhysicalID(resources, cfn.LogicalIDStateMachine); ok && 0 < executionCount {
+		executions, err := k.sfn.ListRecentExecutions(stateMachineArn, executionCount)
+
+		if err != nil {
+			slog.Warn("Failed to list state machine executions", "error", err)
+		} else {
+			for _, execution := range executions {
+				executionStatus := ExecutionStatus{
+					Name:      execution.Name,
+					Status:    execution.Status,
+					StartDate: execution.StartDate,
+				}
+
+				if !execution.StopDate.IsZero() {
+					executionStatus.StopDate = &execution.StopDate
+				}
+
+				report.Executions = append(report.Executions, executionStatus)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+/*
+isProtected determines whether the database is effectively kept stopped,
+i.e. the stack is healthy and both the event rule and the schedule are enabled.
+*/
+func isProtected(stack *StackStatusReport) bool {
+	if stack == nil {
+		return false
+	}
+
+	return slices.Contains(healthyStackStatuses, stack.Status) &&
+		(stack.RuleState == enabledState) &&
+		(stack.ScheduleState == enabledState)
+}
+
+/*
+Tables converts the report into titled tables for display.
+*/
+func (r *StatusReport) Tables() []output.Table {
+	tables := []output.Table{
+		{
+			Title:   "Database",
+			Headers: []string{"id", "type", "status", "maintenance", "protected"},
+			Rows: [][]any{
+				{r.DBIdentifier, r.DBType, r.DBStatus, r.Maintenance, r.Protected},
+			},
+		},
+	}
+
+	if 0 < len(r.Members) {
+		table := output.Table{
+			Title:   "Cluster members",
+			Headers: []string{"id", "status", "role"},
+		}
+
+		for _, member := range r.Members {
+			role := "reader"
+
+			if member.IsWriter {
+				role = "writer"
+			}
+
+			table.Rows = append(table.Rows, []any{member.DBIdentifier, member.Status, role})
+		}
+
+		tables = append(tables, table)
+	}
+
+	if 0 < len(r.MaintenanceActions) {
+		table := output.Table{
+			Title:   "Pending maintenance actions",
+			Headers: []string{"resource", "action", "category", "auto-applied-after", "forced-apply", "opt-in", "description"},
+		}
+
+		for _, action := range r.MaintenanceActions {
+			var optIn any
+
+			if action.OptInStatus != "" {
+				optIn = action.OptInStatus
+			}
+
+			table.Rows = append(table.Rows, []any{
+				action.ResourceType + ":" + action.Resource,
+				action.Action,
+				action.Category,
+				action.AutoAppliedAfter,
+				action.ForcedApplyDate,
+				optIn,
+				action.Description,
+			})
+		}
+
+		tables = append(tables, table)
+	}
+
+	if r.Stack == nil {
+		return tables
+	}
+
+	var nextRun any = unknownValue
+
+	if r.Stack.NextScheduledRun != nil {
+		nextRun = *r.Stack.NextScheduledRun
+	}
+
+	tables = append(tables, output.Table{
+		Title:   "Stack",
+		Headers: []string{"name", "status", "created", "rule", "schedule", "next-run"},
+		Rows: [][]any{
+			{r.Stack.Name, r.Stack.Status, r.Stack.CreationTime, r.Stack.RuleState, r.Stack.ScheduleState, nextRun},
+		},
+	})
+
+	executions := output.Table{
+		Title:   "Recent executions",
+		Headers: []string{"name", "status", "start", "stop"},
+	}
+
+	for _, execution := range r.Stack.Executions {
+		executions.Rows = append(executions.Rows, []any{execution.Name, execution.Status, execution.StartDate, execution.StopDate})
+	}
+
+	resources := output.Table{
+		Title:   "Resources",
+		Headers: []string{"logical-id", "type", "status", "physical-id"},
+	}
+
+	for _, resource := range r.Stack.Resources {
+		resources.Rows = append(resources.Rows, []any{resource.LogicalID, resource.Type, resource.Status, resource.PhysicalID})
+	}
+
+	return append(tables, executions, resources)
+}
+
+/*
+formatTime formats a time for display.
+*/
+func formatTime(t time.Time) string {
+	return t.Local().Format(time.RFC3339)
+}
@@ -0,0 +1,355 @@
+package ktnh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	ebtypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/scheduler"
+	schedtypes "github.com/aws/aws-sdk-go-v2/service/scheduler/types"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appcfn "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	appevents "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/events"
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+	appscheduler "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/scheduler"
+	appsfn "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/sfn"
+)
+
+func Test_stackStatus(t *testing.T) {
+	creationTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		stackName          string
+		executionCount     int
+		mockCfnSetup       func(*appmock.MockCloudFormationFactory, *appmock.MockCloudFormationClient)
+		mockEventsSetup    func(*appmock.MockEventBridgeFactory, *appmock.MockEventBridgeClient)
+		mockSchedulerSetup func(*appmock.MockSchedulerFactory, *appmock.MockSchedulerClient)
+		mockSfnSetup       func(*appmock.MockSFNFactory, *appmock.MockSFNClient)
+		expectedRule       string
+		expectedSchedule   string
+		expectedExecutions int
+		wantErr            bool
+	}{
+		{
+			name:           "All resources available",
+			stackName:      "A-db1-abcdef",
+			executionCount: 3,
+			mockCfnSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.DescribeStacksOutput{
+						Stacks: []cfntypes.Stack{
+							{
+								StackName:    aws.String("A-db1-abcdef"),
+								StackStatus:  cfntypes.StackStatusCreateComplete,
+								CreationTime: aws.Time(creationTime),
+							},
+						},
+					}, nil)
+
+				c.On("DescribeStackResources", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.DescribeStackResourcesOutput{
+						StackResources: []cfntypes.StackResource{
+							{
+								LogicalResourceId:  aws.String("StateMachine"),
+								PhysicalResourceId: aws.String("arn:sm"),
+							},
+							{
+								LogicalResourceId:  aws.String("RDSAutoStartEventRule"),
+								PhysicalResourceId: aws.String("rule"),
+							},
+							{
+								LogicalResourceId:  aws.String("PeriodicStopSchedule"),
+								PhysicalResourceId: aws.String("schedule"),
+							},
+						},
+					}, nil)
+			},
+			mockEventsSetup: func(f *appmock.MockEventBridgeFactory, c *appmock.MockEventBridgeClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeRule", mock.Anything, mock.Anything, mock.Anything).
+					Return(&eventbridge.DescribeRuleOutput{State: ebtypes.RuleStateEnabled}, nil)
+			},
+			mockSchedulerSetup: func(f *appmock.MockSchedulerFactory, c *appmock.MockSchedulerClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("GetSchedule", mock.Anything, mock.Anything, mock.Anything).
+					Return(&scheduler.GetScheduleOutput{
+						State:              schedtypes.ScheduleStateEnabled,
+						ScheduleExpression: aws.String("rate(6 hours)"),
+						CreationDate:       aws.Time(creationTime),
+					}, nil)
+			},
+			mockSfnSetup: func(f *appmock.MockSFNFactory, c *appmock.MockSFNClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &sfn.ListExecutionsInput{
+					StateMachineArn: aws.String("arn:sm"),
+					MaxResults:      3,
+				}
+
+				c.On("ListExecutions", mock.Anything, params, mock.Anything).
+					Return(&sfn.ListExecutionsOutput{
+						Executions: []sfntypes.ExecutionListItem{
+							{
+								Name:      aws.String("exec-1"),
+								Status:    sfntypes.ExecutionStatusSucceeded,
+								StartDate: aws.Time(creationTime),
+								StopDate:  aws.Time(creationTime.Add(time.Minute)),
+							},
+						},
+					}, nil)
+			},
+			expectedRule:       "ENABLED",
+			expectedSchedule:   "ENABLED",
+			expectedExecutions: 1,
+			wantErr:            false,
+		},
+		{
+			name:           "Rule and schedule lookup errors are tolerated",
+			stackName:      "B-db2-ghijkl",
+			executionCount: 0,
+			mockCfnSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.DescribeStacksOutput{
+						Stacks: []cfntypes.Stack{
+							{
+								StackName:   aws.String("B-db2-ghijkl"),
+								StackStatus: cfntypes.StackStatusCreateComplete,
+							},
+						},
+					}, nil)
+
+				c.On("DescribeStackResources", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.DescribeStackResourcesOutput{
+						StackResources: []cfntypes.StackResource{
+							{
+								LogicalResourceId:  aws.String("RDSAutoStartEventRule"),
+								PhysicalResourceId: aws.String("rule"),
+							},
+							{
+								LogicalResourceId:  aws.String("PeriodicStopSchedule"),
+								PhysicalResourceId: aws.String("schedule"),
+							},
+						},
+					}, nil)
+			},
+			mockEventsSetup: func(f *appmock.MockEventBridgeFactory, c *appmock.MockEventBridgeClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeRule", mock.Anything, mock.Anything, mock.Anything).
+					Return(&eventbridge.DescribeRuleOutput{}, assert.AnError)
+			},
+			mockSchedulerSetup: func(f *appmock.MockSchedulerFactory, c *appmock.MockSchedulerClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("GetSchedule", mock.Anything, mock.Anything, mock.Anything).
+					Return(&scheduler.GetScheduleOutput{}, assert.AnError)
+			},
+			mockSfnSetup:       func(f *appmock.MockSFNFactory, c *appmock.MockSFNClient) {},
+			expectedRule:       "(unknown)",
+			expectedSchedule:   "(unknown)",
+			expectedExecutions: 0,
+			wantErr:            false,
+		},
+		{
+			name:           "Error during describing stack",
+			stackName:      "C-db3-mnopqr",
+			executionCount: 5,
+			mockCfnSetup: func(f *appmock.MockCloudFormationFactory, c *appmock.MockCloudFormationClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).
+					Return(&cloudformation.DescribeStacksOutput{}, assert.AnError)
+			},
+			mockEventsSetup:    func(f *appmock.MockEventBridgeFactory, c *appmock.MockEventBridgeClient) {},
+			mockSchedulerSetup: func(f *appmock.MockSchedulerFactory, c *appmock.MockSchedulerClient) {},
+			mockSfnSetup:       func(f *appmock.MockSFNFactory, c *appmock.MockSFNClient) {},
+			wantErr:            true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactoryCloudFormation := new(appmock.MockCloudFormationFactory)
+			mockClientCloudFormation := new(appmock.MockCloudFormationClient)
+			mockFactoryEventBridge := new(appmock.MockEventBridgeFactory)
+			mockClientEventBridge := new(appmock.MockEventBridgeClient)
+			mockFactoryScheduler := new(appmock.MockSchedulerFactory)
+			mockClientScheduler := new(appmock.MockSchedulerClient)
+			mockFactorySFN := new(appmock.MockSFNFactory)
+			mockClientSFN := new(appmock.MockSFNClient)
+
+			tc.mockCfnSetup(mockFactoryCloudFormation, mockClientCloudFormation)
+			tc.mockEventsSetup(mockFactoryEventBridge, mockClientEventBridge)
+			tc.mockSchedulerSetup(mockFactoryScheduler, mockClientScheduler)
+			tc.mockSfnSetup(mockFactorySFN, mockClientSFN)
+
+			k := &ktnh{
+				cfn:       appcfn.NewCloudFormation(mockFactoryCloudFormation),
+				events:    appevents.NewEventBridge(mockFactoryEventBridge),
+				scheduler: appscheduler.NewScheduler(mockFactoryScheduler),
+				sfn:       appsfn.NewStepFunctions(mockFactorySFN),
+			}
+
+			got, err := k.stackStatus(tc.stackName, tc.executionCount)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.stackName, got.Name, "Stack name does not match")
+				assert.Equal(t, tc.expectedRule, got.RuleState, "Rule state does not match")
+				assert.Equal(t, tc.expectedSchedule, got.ScheduleState, "Schedule state does not match")
+				assert.Len(t, got.Executions, tc.expectedExecutions, "Number of executions does not match")
+			}
+
+			mockFactoryCloudFormation.AssertExpectations(t)
+			mockClientCloudFormation.AssertExpectations(t)
+			mockFactoryEventBridge.AssertExpectations(t)
+			mockClientEventBridge.AssertExpectations(t)
+			mockFactoryScheduler.AssertExpectations(t)
+			mockClientScheduler.AssertExpectations(t)
+			mockFactorySFN.AssertExpectations(t)
+			mockClientSFN.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_isProtected(t *testing.T) {
+	testCases := []struct {
+		name     string
+		stack    *StackStatusReport
+		expected bool
+	}{
+		{
+			name:     "Not frozen",
+			stack:    nil,
+			expected: false,
+		},
+		{
+			name: "Healthy and enabled",
+			stack: &StackStatusReport{
+				Status:        "CREATE_COMPLETE",
+				RuleState:     "ENABLED",
+				ScheduleState: "ENABLED",
+			},
+			expected: true,
+		},
+		{
+			name: "Rolled back",
+			stack: &StackStatusReport{
+				Status:        "ROLLBACK_COMPLETE",
+				RuleState:     "ENABLED",
+				ScheduleState: "ENABLED",
+			},
+			expected: false,
+		},
+		{
+			name: "Rule disabled",
+			stack: &StackStatusReport{
+				Status:        "UPDATE_COMPLETE",
+				RuleState:     "DISABLED",
+				ScheduleState: "ENABLED",
+			},
+			expected: false,
+		},
+		{
+			name: "Schedule unknown",
+			stack: &StackStatusReport{
+				Status:        "CREATE_COMPLETE",
+				RuleState:     "ENABLED",
+				ScheduleState: "(unknown)",
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isProtected(tc.stack), "Protection status does not match")
+		})
+	}
+}
+
+func Test_Tables(t *testing.T) {
+	t.Run("Not frozen RDS instance", func(t *testing.T) {
+		report := &StatusReport{
+			DBIdentifier: "db1",
+			DBType:       "rds",
+			DBStatus:     "stopped",
+			Maintenance:  "none",
+		}
+
+		tables := report.Tables()
+
+		assert.Len(t, tables, 1, "Only the database table should be displayed")
+		assert.Equal(t, [][]any{{"db1", "rds", "stopped", "none", false}}, tables[0].Rows, "Database table does not match")
+	})
+
+	t.Run("Frozen Aurora cluster", func(t *testing.T) {
+		report := &StatusReport{
+			DBIdentifier: "db2",
+			DBType:       "aurora",
+			DBStatus:     "stopped",
+			Members: []MemberStatus{
+				{DBIdentifier: "db2-1", Status: "stopped", IsWriter: true},
+			},
+			Maintenance: "available",
+			MaintenanceActions: []PendingAction{
+				{
+					DBIdentifier: "db2",
+					DBType:       "aurora",
+					ResourceType: "db",
+					Resource:     "db2-1",
+					Action:       "system-update",
+					Category:     "available",
+					OptInStatus:  "next-maintenance",
+					Description:  "New Operating System update is available",
+				},
+			},
+			Protected: true,
+			Stack: &StackStatusReport{
+				Name:          "ktnh-db2-abcdef",
+				Status:        "CREATE_COMPLETE",
+				RuleState:     "ENABLED",
+				ScheduleState: "ENABLED",
+			},
+		}
+
+		tables := report.Tables()
+
+		titles := make([]string, len(tables))
+
+		for i, table := range tables {
+			titles[i] = table.Title
+		}
+
+		assert.Equal(t, []string{"Database", "Cluster members", "Pending maintenance actions", "Stack", "Recent executions", "Resources"}, titles, "Table titles do not match")
+		assert.Equal(t, [][]any{{"db2-1", "stopped", "writer"}}, tables[1].Rows, "Cluster members table does not match")
+		assert.Equal(t, [][]any{{"db:db2-1", "system-update", "available", (*time.Time)(nil), (*time.Time)(nil), "next-maintenance", "New Operating System update is available"}}, tables[2].Rows, "Pending maintenance actions table does not match")
+		assert.Equal(t, "(unknown)", tables[3].Rows[0][5], "Next run should be unknown")
+	})
+}
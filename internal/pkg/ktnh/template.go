@@ -3,8 +3,13 @@ package ktnh
 import (
 	"fmt"
 	"log/slog"
+	"regexp"
+	"slices"
 
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/diagram"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
 	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/utils"
 )
 
@@ -14,6 +19,25 @@ A qualifier is a unique identifier for each CloudFormation stack.
 */
 const qualifierLength = 6
 
+const (
+	TemplateFormatYAML      = "yaml"      // CloudFormation template in YAML, as deployed by `freeze`
+	TemplateFormatJSON      = "json"      // CloudFormation template in JSON
+	TemplateFormatTerraform = "terraform" // Terraform configuration with the same resources as the CloudFormation template
+)
+
+/*
+qualifierPattern matches the qualifiers that can be given by the user.
+They are part of the stack name and the names of the stack resources.
+*/
+var qualifierPattern = regexp.MustCompile(fmt.Sprintf("^[A-Za-z0-9]{1,%d}$", qualifierLength))
+
+/*
+TemplateValidationReport holds the problems found in a CloudFormation template.
+*/
+type TemplateValidationReport struct {
+	Problems []cfn.TemplateProblem `json:"problems"` // problems found, empty if the template is valid
+}
+
 /*
 generateQualifier generates a unique qualifier for the CloudFormation stack.
 */
@@ -35,13 +59,168 @@ func (k *ktnh) Template() (templateBody string, qualifier string, err error) {
 		return "", "", fmt.Errorf("failed to determine DB type: %w", err)
 	}
 
-	qualifier = generateQualifier()
+	return GenerateTemplate(k.stackNamePrefix, k.dbIdentifier, string(dbType), "", TemplateFormatYAML)
+}
 
-	templateBody, err = cfn.GenerateTemplateBody(k.dbIdentifier, k.dbIdentifierShort, string(dbType), qualifier)
+/*
+GenerateTemplate generates a CloudFormation template, or the equivalent Terraform configuration, without accessing AWS,
+in the same way as `freeze` but with the DB type given instead of looked up.
+The resources are named after the prefix with the naming template (see `cfn.SetNameTemplate`).
+The DB identifier is converted into lowercase, and a qualifier is generated if not given.
+Returns the template and the qualifier.
+*/
+func GenerateTemplate(stackNamePrefix string, dbIdentifier string, dbType string, qualifier string, format string) (string, string, error) {
+	parsedType, err := rds.ParseDBType(dbType)
 
 	if err != nil {
-		return "", "", fmt.Errorf("failed to generate CloudFormation template: %w", err)
+		return "", "", err
+	}
+
+	if qualifier == "" {
+		qualifier = generateQualifier()
+	} else if !qualifierPattern.MatchString(qualifier) {
+		return "", "", fmt.Errorf("qualifier must be 1 to %d alphanumeric characters", qualifierLength)
+	}
+
+	dbIdentifier = normalizeIdentifier(dbIdentifier)
+
+	dbIdentifierShort := shortenIdentifier(dbIdentifier)
+
+	switch format {
+	case TemplateFormatYAML, TemplateFormatJSON:
+		templateBody, err := cfn.GenerateTemplateBody(stackNamePrefix, dbIdentifier, dbIdentifierShort, string(parsedType), qualifier)
+
+		if err != nil {
+			return "", "", fmt.Errorf("failed to generate CloudFormation template: %w", err)
+		}
+
+		if format == TemplateFormatYAML {
+			return templateBody, qualifier, nil
+		}
+
+		templateBody, err = cfn.ConvertTemplateToJSON(templateBody)
+
+		if err != nil {
+			return "", "", fmt.Errorf("failed to convert CloudFormation template into JSON: %w", err)
+		}
+
+		return templateBody, qualifier, nil
+	case TemplateFormatTerraform:
+		configuration, err := cfn.GenerateTerraform(stackNamePrefix, dbIdentifier, dbIdentifierShort, string(parsedType), qualifier)
+
+		if err != nil {
+			return "", "", fmt.Errorf("failed to generate Terraform configuration: %w", err)
+		}
+
+		return configuration, qualifier, nil
+	default:
+		return "", "", fmt.Errorf("unknown template format '%s' (must be '%s', '%s' or '%s')", format, TemplateFormatYAML, TemplateFormatJSON, TemplateFormatTerraform)
+	}
+}
+
+/*
+GenerateGenericTemplate generates the CloudFormation template that is not bound to a specific DB,
+whose DB identifier, DB type, schedule and log retention are given by the stack parameters.
+Terraform is not supported, since the generic template is meant to be deployed as is (e.g., via Service Catalog).
+*/
+func GenerateGenericTemplate(stackNamePrefix string, format string) (string, error) {
+	switch format {
+	case TemplateFormatYAML, TemplateFormatJSON:
+		templateBody, err := cfn.GenerateGenericTemplateBody(stackNamePrefix)
+
+		if err != nil {
+			return "", fmt.Errorf("failed to generate CloudFormation template: %w", err)
+		}
+
+		if format == TemplateFormatYAML {
+			return templateBody, nil
+		}
+
+		templateBody, err = cfn.ConvertTemplateToJSON(templateBody)
+
+		if err != nil {
+			return "", fmt.Errorf("failed to convert CloudFormation template into JSON: %w", err)
+		}
+
+		return templateBody, nil
+	default:
+		return "", fmt.Errorf("unsupported format '%s' for the generic template (must be '%s' or '%s')", format, TemplateFormatYAML, TemplateFormatJSON)
+	}
+}
+
+/*
+GenerateDiagram renders the state machine deployed for the DB as a state diagram, without accessing AWS.
+The diagram is drawn from the generated template, so that it reflects the overrides and the patches of the template.
+*/
+func GenerateDiagram(stackNamePrefix string, dbIdentifier string, dbType string, format string) (string, error) {
+	templateBody, _, err := GenerateTemplate(stackNamePrefix, dbIdentifier, dbType, "", TemplateFormatYAML)
+
+	if err != nil {
+		return "", err
+	}
+
+	definition, err := cfn.StateMachineDefinition(templateBody)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to extract state machine definition: %w", err)
+	}
+
+	return diagram.Render(definition, format)
+}
+
+/*
+NewTemplateValidationReport creates a report of the problems found by the local checks
+(see `cfn.CheckTemplate`), which are run whenever a CloudFormation template is generated.
+*/
+func NewTemplateValidationReport(err *cfn.TemplateError) *TemplateValidationReport {
+	return &TemplateValidationReport{
+		Problems: slices.Clone(err.Problems),
+	}
+}
+
+/*
+ValidateTemplate validates the generated CloudFormation template with the ValidateTemplate API,
+which checks the template in the same way as the stack creation does.
+*/
+func (k *ktnh) ValidateTemplate(templateBody string) *TemplateValidationReport {
+	report := &TemplateValidationReport{
+		Problems: []cfn.TemplateProblem{},
+	}
+
+	if err := k.cfn.ValidateTemplate(templateBody); err != nil {
+		report.Problems = append(report.Problems, cfn.TemplateProblem{
+			Check:   cfn.CheckAPI,
+			Message: err.Error(),
+		})
+	}
+
+	slog.Debug("Validated CloudFormation template", "problems", len(report.Problems))
+
+	return report
+}
+
+/*
+Add adds the problems to the report, skipping those already in it (the same message by the same check).
+*/
+func (r *TemplateValidationReport) Add(problems ...cfn.TemplateProblem) {
+	for _, problem := range problems {
+		if !slices.Contains(r.Problems, problem) {
+			r.Problems = append(r.Problems, problem)
+		}
+	}
+}
+
+/*
+Tables converts the report into a table for display.
+*/
+func (r *TemplateValidationReport) Tables() []output.Table {
+	table := output.Table{
+		Headers: []string{"check", "problem"},
+	}
+
+	for _, problem := range r.Problems {
+		table.Rows = append(table.Rows, []any{problem.Check, problem.Message})
 	}
 
-	return templateBody, qualifier, nil
+	return []output.Table{table}
 }
@@ -1,17 +1,21 @@
 package ktnh
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gopkg.in/yaml.v3"
 
+	appcfn "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
 	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
 	apprds "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/rds"
 )
 
@@ -134,3 +138,261 @@ func Test_Template(t *testing.T) {
 		})
 	}
 }
+
+func Test_GenerateTemplate(t *testing.T) {
+	testCases := []struct {
+		name              string
+		dbType            string
+		qualifier         string
+		format            string
+		expectedQualifier string
+		wantErr           bool
+	}{
+		{
+			name:              "YAML with qualifier",
+			dbType:            "aurora",
+			qualifier:         "abc123",
+			format:            "yaml",
+			expectedQualifier: "^abc123$",
+			wantErr:           false,
+		},
+		{
+			name:              "JSON with generated qualifier",
+			dbType:            "rds",
+			qualifier:         "",
+			format:            "json",
+			expectedQualifier: "^[A-Za-z0-9]{6}$",
+			wantErr:           false,
+		},
+		{
+			name:              "Terraform",
+			dbType:            "aurora",
+			qualifier:         "xyz",
+			format:            "terraform",
+			expectedQualifier: "^xyz$",
+			wantErr:           false,
+		},
+		{
+			name:      "Unknown DB type",
+			dbType:    "mysql",
+			qualifier: "",
+			format:    "yaml",
+			wantErr:   true,
+		},
+		{
+			name:      "Invalid qualifier",
+			dbType:    "rds",
+			qualifier: "abc-12",
+			format:    "yaml",
+			wantErr:   true,
+		},
+		{
+			name:      "Unknown format",
+			dbType:    "rds",
+			qualifier: "",
+			format:    "toml",
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			templateBody, qualifier, err := GenerateTemplate("ktnh", "db-identifier-1", tc.dbType, tc.qualifier, tc.format)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Regexp(t, tc.expectedQualifier, qualifier, "Qualifier does not match expected value")
+
+				assert.Contains(t, templateBody, "ktnh-sfn-db-identif-"+qualifier, "Template should contain the shortened identifier and the qualifier")
+
+				if tc.format == "json" {
+					assert.True(t, json.Valid([]byte(templateBody)), "Template should be valid JSON")
+				}
+			}
+		})
+	}
+}
+
+func Test_GenerateGenericTemplate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		format  string
+		wantErr bool
+	}{
+		{
+			name:    "YAML",
+			format:  "yaml",
+			wantErr: false,
+		},
+		{
+			name:    "JSON",
+			format:  "json",
+			wantErr: false,
+		},
+		{
+			name:    "Terraform",
+			format:  "terraform",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			templateBody, err := GenerateGenericTemplate("ktnh", tc.format)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Contains(t, templateBody, "${DBIdentifier}", "Template should refer to the DB identifier parameter")
+
+				if tc.format == "json" {
+					assert.True(t, json.Valid([]byte(templateBody)), "Template should be valid JSON")
+				}
+			}
+		})
+	}
+}
+
+func Test_GenerateDiagram(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dbType   string
+		format   string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Aurora in Mermaid",
+			dbType:   "aurora",
+			format:   "mermaid",
+			expected: "StopDB: rds:stopDBCluster",
+			wantErr:  false,
+		},
+		{
+			name:     "RDS in DOT",
+			dbType:   "rds",
+			format:   "dot",
+			expected: `"StopDB" [label="StopDB\nrds:stopDBInstance"];`,
+			wantErr:  false,
+		},
+		{
+			name:    "Unknown format",
+			dbType:  "rds",
+			format:  "svg",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := GenerateDiagram("ktnh", "db-1", tc.dbType, tc.format)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Contains(t, got, tc.expected, "Diagram should contain the state stopping the DB")
+			}
+		})
+	}
+}
+
+func Test_ValidateTemplate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{
+			name:     "Valid",
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name:     "Rejected by API",
+			err:      assert.AnError,
+			expected: []string{appcfn.CheckAPI},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudFormationFactory)
+			mockClient := new(appmock.MockCloudFormationClient)
+
+			mockFactory.On("GetClient").
+				Return(mockClient)
+
+			mockClient.On("ValidateTemplate", mock.Anything, &cloudformation.ValidateTemplateInput{
+				TemplateBody: aws.String("template-body"),
+			}, mock.Anything).
+				Return(&cloudformation.ValidateTemplateOutput{}, tc.err)
+
+			k := &ktnh{
+				cfn: appcfn.NewCloudFormation(mockFactory),
+			}
+
+			report := k.ValidateTemplate("template-body")
+
+			var checks []string
+
+			for _, problem := range report.Problems {
+				checks = append(checks, problem.Check)
+			}
+
+			assert.Equal(t, tc.expected, checks, "Problems do not match expected value")
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_TemplateValidationReport_Add(t *testing.T) {
+	report := NewTemplateValidationReport(&appcfn.TemplateError{
+		Problems: []appcfn.TemplateProblem{
+			{Check: appcfn.CheckResources, Message: "resource 'StateMachine' is missing"},
+		},
+	})
+
+	report.Add(
+		appcfn.TemplateProblem{Check: appcfn.CheckResources, Message: "resource 'StateMachine' is missing"},
+		appcfn.TemplateProblem{Check: appcfn.CheckAPI, Message: "resource 'StateMachine' is missing"},
+		appcfn.TemplateProblem{Check: appcfn.CheckAPI, Message: "template format error"},
+	)
+
+	expected := []appcfn.TemplateProblem{
+		{Check: appcfn.CheckResources, Message: "resource 'StateMachine' is missing"},
+		{Check: appcfn.CheckAPI, Message: "resource 'StateMachine' is missing"},
+		{Check: appcfn.CheckAPI, Message: "template format error"},
+	}
+
+	assert.Equal(t, expected, report.Problems, "Problems do not match expected value")
+}
+
+func Test_TemplateValidationReport_Tables(t *testing.T) {
+	report := NewTemplateValidationReport(&appcfn.TemplateError{
+		Problems: []appcfn.TemplateProblem{
+			{Check: appcfn.CheckResources, Message: "resource 'StateMachine' is missing"},
+			{Check: appcfn.CheckIAM, Message: "role name is too long"},
+		},
+	})
+
+	expected := []output.Table{
+		{
+			Headers: []string{"check", "problem"},
+			Rows: [][]any{
+				{"resources", "resource 'StateMachine' is missing"},
+				{"iam", "role name is too long"},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, report.Tables(), "Tables do not match expected value")
+}
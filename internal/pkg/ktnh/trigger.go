@@ -0,0 +1,134 @@
+package ktnh
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/output"
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/sfn"
+)
+
+/*
+TriggerReport holds the outcome of a manually started state machine execution.
+*/
+type TriggerReport struct {
+	Name      string     `json:"name"`      // execution name
+	ARN       string     `json:"arn"`       // execution ARN
+	Status    string     `json:"status"`    // execution status ("RUNNING" if not waited for)
+	StartDate *time.Time `json:"startDate"` // time when the execution started (nil if not waited for)
+	StopDate  *time.Time `json:"stopDate"`  // time when the execution stopped (nil if still running)
+	Error     string     `json:"error"`     // error code if the execution did not succeed
+	Cause     string     `json:"cause"`     // cause of the error
+}
+
+const (
+	manualTriggerSource = "ktnh.manual" // value of `source` in the input of manually started executions
+
+	executionSucceeded = "SUCCEEDED" // status of a successful execution
+)
+
+/*
+Trigger starts an execution of the state machine of the database, and waits for it
+to reach a terminal state unless timeout is 0.
+An error is returned along with the report if the execution does not succeed.
+*/
+func (k *ktnh) Trigger(timeout time.Duration) (*TriggerReport, error) {
+	stateMachineArn, err := k.findStackResource(cfn.LogicalIDStateMachine)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find state machine: %w", err)
+	}
+
+	input, err := json.Marshal(map[string]string{
+		"source": manualTriggerSource,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build execution input: %w", err)
+	}
+
+	slog.Info("Starting state machine execution", "stateMachineArn", stateMachineArn)
+
+	executionArn, err := k.sfn.StartExecution(stateMachineArn, string(input))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to start execution: %w", err)
+	}
+
+	report := &TriggerReport{
+		Name:   executionArn[strings.LastIndex(executionArn, ":")+1:],
+		ARN:    executionArn,
+		Status: "RUNNING",
+	}
+
+	if timeout == 0 {
+		slog.Info("Skipped wait for execution")
+
+		return report, nil
+	}
+
+	slog.Info("Waiting for execution to finish", "timeout", timeout.Seconds())
+
+	execution, err := k.sfn.WaitForExecution(executionArn, timeout)
+
+	if err != nil {
+		return report, fmt.Errorf("failed while waiting for execution: %w", err)
+	}
+
+	report.Status = execution.Status
+	report.StartDate = &execution.StartDate
+	report.Error = execution.Error
+	report.Cause = execution.Cause
+
+	if !execution.StopDate.IsZero() {
+		report.StopDate = &execution.StopDate
+	}
+
+	if execution.Status != executionSucceeded {
+		return report, executionError(execution)
+	}
+
+	return report, nil
+}
+
+/*
+executionError builds an error describing why the execution did not succeed.
+Permission errors are called out since they indicate a problem with the execution role
+that prevents the state machine from describing or stopping the DB.
+*/
+func executionError(execution *sfn.Execution) error {
+	if isPermissionError(execution.Error) || isPermissionError(execution.Cause) {
+		return fmt.Errorf("execution '%s' %s: the execution role is not allowed to describe or stop the DB (error: %s, cause: %s)",
+			execution.Name, strings.ToLower(execution.Status), execution.Error, execution.Cause,
+		)
+	}
+
+	return fmt.Errorf("execution '%s' %s (error: %s, cause: %s)",
+		execution.Name, strings.ToLower(execution.Status), execution.Error, execution.Cause,
+	)
+}
+
+/*
+isPermissionError determines whether the error code or message indicates missing IAM permissions.
+*/
+func isPermissionError(message string) bool {
+	return strings.Contains(message, "AccessDenied") || strings.Contains(message, "not authorized")
+}
+
+/*
+Tables converts the report into a table for display.
+*/
+func (r *TriggerReport) Tables() []output.Table {
+	return []output.Table{
+		{
+			Headers: []string{"name", "status", "start", "stop", "error"},
+			Rows: [][]any{
+				{r.Name, r.Status, r.StartDate, r.StopDate, r.Error},
+			},
+		},
+	}
+}
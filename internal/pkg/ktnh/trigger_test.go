@@ -0,0 +1,87 @@
+package ktnh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/sfn"
+)
+
+func Test_executionError(t *testing.T) {
+	testCases := []struct {
+		name      string
+		execution *sfn.Execution
+		expected  string
+	}{
+		{
+			name: "Permission error",
+			execution: &sfn.Execution{
+				Name:   "exec-1",
+				Status: "FAILED",
+				Error:  "Rds.RdsException",
+				Cause:  "User: arn:aws:sts::123456789012:assumed-role/ktnh-sfn-db-1 is not authorized to perform: rds:StopDBCluster",
+			},
+			expected: "execution 'exec-1' failed: the execution role is not allowed to describe or stop the DB",
+		},
+		{
+			name: "Other error",
+			execution: &sfn.Execution{
+				Name:   "exec-2",
+				Status: "TIMED_OUT",
+				Error:  "States.Timeout",
+			},
+			expected: "execution 'exec-2' timed_out (error: States.Timeout, cause: )",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := executionError(tc.execution)
+
+			assert.ErrorContains(t, err, tc.expected, "Error message does not match")
+		})
+	}
+}
+
+func Test_TriggerReport_Rows(t *testing.T) {
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	stopDate := startDate.Add(time.Minute)
+
+	testCases := []struct {
+		name     string
+		report   *TriggerReport
+		expected []any
+	}{
+		{
+			name: "Not waited for",
+			report: &TriggerReport{
+				Name:   "exec-1",
+				Status: "RUNNING",
+			},
+			expected: []any{"exec-1", "RUNNING", (*time.Time)(nil), (*time.Time)(nil), ""},
+		},
+		{
+			name: "Failed",
+			report: &TriggerReport{
+				Name:      "exec-2",
+				Status:    "FAILED",
+				StartDate: &startDate,
+				StopDate:  &stopDate,
+				Error:     "Rds.RdsException",
+			},
+			expected: []any{"exec-2", "FAILED", &startDate, &stopDate, "Rds.RdsException"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tables := tc.report.Tables()
+
+			assert.Len(t, tables, 1, "A single table should be returned")
+			assert.Equal(t, []string{"name", "status", "start", "stop", "error"}, tables[0].Headers, "Headers do not match")
+			assert.Equal(t, [][]any{tc.expected}, tables[0].Rows, "Body does not match")
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Package logs provides functionality for interacting with Amazon CloudWatch Logs.
+
+It offers utilities to read the log events written by the state machine that ktnh
+deploys to keep Aurora clusters and RDS instances stopped.
+*/
+package logs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/awsfactory"
+)
+
+/*
+CloudWatchLogs handles interactions with the Amazon CloudWatch Logs service.
+*/
+type CloudWatchLogs struct {
+	factory awsfactory.CloudWatchLogsFactory // Interface instead of concrete client
+}
+
+/*
+LogEvent holds a single log event.
+*/
+type LogEvent struct {
+	ID        string    // event ID
+	Timestamp time.Time // time when the event occurred
+	Message   string    // raw log message
+}
+
+/*
+NewCloudWatchLogs creates and returns a new instance of CloudWatchLogs.
+*/
+func NewCloudWatchLogs(factory awsfactory.CloudWatchLogsFactory) *CloudWatchLogs {
+	return &CloudWatchLogs{
+		factory: factory,
+	}
+}
+
+/*
+FilterLogEvents returns the log events in the log group that occurred at or after the given time,
+in chronological order.
+*/
+func (c *CloudWatchLogs) FilterLogEvents(logGroupName string, since time.Time) ([]LogEvent, error) {
+	slog.Debug("Filtering log events",
+		"logGroupName", logGroupName,
+		"since", since,
+	)
+
+	paginator, err := c.factory.NewFilterLogEventsPaginator(&cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(logGroupName),
+		StartTime:    aws.Int64(since.UnixMilli()),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create FilterLogEvents paginator: %w", err)
+	}
+
+	var events []LogEvent
+
+	ctx := context.Background()
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute FilterLogEvents API for log group '%s': %w", logGroupName, err)
+		}
+
+		for _, event := range output.Events {
+			events = append(events, LogEvent{
+				ID:        aws.ToString(event.EventId),
+				Timestamp: time.UnixMilli(aws.ToInt64(event.Timestamp)),
+				Message:   aws.ToString(event.Message),
+			})
+		}
+	}
+
+	slog.Debug("Filtered log events", "count", len(events))
+
+	return events, nil
+}
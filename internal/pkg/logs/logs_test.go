@@ -0,0 +1,141 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_FilterLogEvents(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		mockSetup func(*appmock.MockCloudWatchLogsFactory, *appmock.MockFilterLogEventsPaginator)
+		expected  []LogEvent
+		wantErr   bool
+	}{
+		{
+			name: "Success",
+			mockSetup: func(f *appmock.MockCloudWatchLogsFactory, p *appmock.MockFilterLogEventsPaginator) {
+				params := &cloudwatchlogs.FilterLogEventsInput{
+					LogGroupName: aws.String("lg-1"),
+					StartTime:    aws.Int64(since.UnixMilli()),
+				}
+
+				f.On("NewFilterLogEventsPaginator", params).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Twice()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&cloudwatchlogs.FilterLogEventsOutput{
+						Events: []types.FilteredLogEvent{
+							{
+								EventId:   aws.String("e-1"),
+								Timestamp: aws.Int64(since.UnixMilli()),
+								Message:   aws.String("message-1"),
+							},
+						},
+					}, nil).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&cloudwatchlogs.FilterLogEventsOutput{
+						Events: []types.FilteredLogEvent{
+							{
+								EventId:   aws.String("e-2"),
+								Timestamp: aws.Int64(since.Add(time.Second).UnixMilli()),
+								Message:   aws.String("message-2"),
+							},
+						},
+					}, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			expected: []LogEvent{
+				{
+					ID:        "e-1",
+					Timestamp: since,
+					Message:   "message-1",
+				},
+				{
+					ID:        "e-2",
+					Timestamp: since.Add(time.Second),
+					Message:   "message-2",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Paginator error",
+			mockSetup: func(f *appmock.MockCloudWatchLogsFactory, p *appmock.MockFilterLogEventsPaginator) {
+				f.On("NewFilterLogEventsPaginator", mock.Anything).
+					Return(nil, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "API error",
+			mockSetup: func(f *appmock.MockCloudWatchLogsFactory, p *appmock.MockFilterLogEventsPaginator) {
+				f.On("NewFilterLogEventsPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&cloudwatchlogs.FilterLogEventsOutput{}, assert.AnError).
+					Once()
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockCloudWatchLogsFactory)
+			mockPaginator := new(appmock.MockFilterLogEventsPaginator)
+
+			tc.mockSetup(mockFactory, mockPaginator)
+
+			c := &CloudWatchLogs{
+				factory: mockFactory,
+			}
+
+			got, err := c.FilterLogEvents("lg-1", since)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				for i := range got {
+					assert.True(t, tc.expected[i].Timestamp.Equal(got[i].Timestamp), "Timestamp does not match")
+
+					got[i].Timestamp = tc.expected[i].Timestamp
+				}
+
+				assert.Equal(t, tc.expected, got, "Log events do not match")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockPaginator.AssertExpectations(t)
+		})
+	}
+}
@@ -24,6 +24,13 @@ type MockCloudFormationClient struct {
 	mock.Mock
 }
 
+/*
+MockDescribeStacksPaginator is a mock implementation of the `DescribeStacksPaginator` (internal/pkg/awsfactory) interface.
+*/
+type MockDescribeStacksPaginator struct {
+	mock.Mock
+}
+
 /*
 MockListStacksPaginator is a mock implementation of the `ListStacksPaginator` (internal/pkg/awsfactory) interface.
 */
@@ -45,12 +52,29 @@ type MockStackDeleteCompleteWaiter struct {
 	mock.Mock
 }
 
+/*
+MockStackUpdateCompleteWaiter is a mock implementation of the `StackUpdateCompleteWaiter` (internal/pkg/awsfactory) interface.
+*/
+type MockStackUpdateCompleteWaiter struct {
+	mock.Mock
+}
+
 func (m *MockCloudFormationFactory) GetClient() awsfactory.CloudFormationClient {
 	args := m.Called()
 
 	return args.Get(0).(*MockCloudFormationClient)
 }
 
+func (m *MockCloudFormationFactory) NewDescribeStacksPaginator(params *cloudformation.DescribeStacksInput) (awsfactory.DescribeStacksPaginator, error) {
+	args := m.Called(params)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*MockDescribeStacksPaginator), args.Error(1)
+}
+
 func (m *MockCloudFormationFactory) NewListStacksPaginator(params *cloudformation.ListStacksInput) (awsfactory.ListStacksPaginator, error) {
 	args := m.Called(params)
 
@@ -81,6 +105,16 @@ func (m *MockCloudFormationFactory) NewStackDeleteCompleteWaiter() (awsfactory.S
 	return args.Get(0).(*MockStackDeleteCompleteWaiter), args.Error(1)
 }
 
+func (m *MockCloudFormationFactory) NewStackUpdateCompleteWaiter() (awsfactory.StackUpdateCompleteWaiter, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*MockStackUpdateCompleteWaiter), args.Error(1)
+}
+
 func (m *MockCloudFormationClient) CreateStack(ctx context.Context, params *cloudformation.CreateStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.CreateStackOutput, error) {
 	args := m.Called(ctx, params, optFns)
 
@@ -93,6 +127,12 @@ func (m *MockCloudFormationClient) DeleteStack(ctx context.Context, params *clou
 	return args.Get(0).(*cloudformation.DeleteStackOutput), args.Error(1)
 }
 
+func (m *MockCloudFormationClient) DescribeStackResources(ctx context.Context, params *cloudformation.DescribeStackResourcesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackResourcesOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*cloudformation.DescribeStackResourcesOutput), args.Error(1)
+}
+
 func (m *MockCloudFormationClient) DescribeStacks(ctx context.Context, params *cloudformation.DescribeStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
 	args := m.Called(ctx, params, optFns)
 
@@ -111,6 +151,30 @@ func (m *MockCloudFormationClient) ListStacks(ctx context.Context, params *cloud
 	return args.Get(0).(*cloudformation.ListStacksOutput), args.Error(1)
 }
 
+func (m *MockCloudFormationClient) UpdateStack(ctx context.Context, params *cloudformation.UpdateStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.UpdateStackOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*cloudformation.UpdateStackOutput), args.Error(1)
+}
+
+func (m *MockCloudFormationClient) ValidateTemplate(ctx context.Context, params *cloudformation.ValidateTemplateInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ValidateTemplateOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*cloudformation.ValidateTemplateOutput), args.Error(1)
+}
+
+func (m *MockDescribeStacksPaginator) HasMorePages() bool {
+	args := m.Called()
+
+	return args.Bool(0)
+}
+
+func (m *MockDescribeStacksPaginator) NextPage(ctx context.Context, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
+	args := m.Called(ctx, optFns)
+
+	return args.Get(0).(*cloudformation.DescribeStacksOutput), args.Error(1)
+}
+
 func (m *MockListStacksPaginator) HasMorePages() bool {
 	args := m.Called()
 
@@ -134,3 +198,9 @@ func (m *MockStackDeleteCompleteWaiter) Wait(ctx context.Context, params *cloudf
 
 	return args.Error(0)
 }
+
+func (m *MockStackUpdateCompleteWaiter) Wait(ctx context.Context, params *cloudformation.DescribeStacksInput, maxWaitDur time.Duration, optFns ...func(*cloudformation.StackUpdateCompleteWaiterOptions)) error {
+	args := m.Called(ctx, params, maxWaitDur, optFns)
+
+	return args.Error(0)
+}
@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/awsfactory"
+)
+
+/*
+MockEventBridgeFactory is a mock implementation of the `EventBridgeFactory` (internal/pkg/awsfactory) interface.
+*/
+type MockEventBridgeFactory struct {
+	mock.Mock
+}
+
+/*
+MockEventBridgeClient is a mock implementation of the `EventBridgeClient` (internal/pkg/awsfactory) interface.
+*/
+type MockEventBridgeClient struct {
+	mock.Mock
+}
+
+func (m *MockEventBridgeFactory) GetClient() awsfactory.EventBridgeClient {
+	args := m.Called()
+
+	return args.Get(0).(*MockEventBridgeClient)
+}
+
+func (m *MockEventBridgeClient) DescribeRule(ctx context.Context, params *eventbridge.DescribeRuleInput, optFns ...func(*eventbridge.Options)) (*eventbridge.DescribeRuleOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*eventbridge.DescribeRuleOutput), args.Error(1)
+}
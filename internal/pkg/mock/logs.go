@@ -0,0 +1,65 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/awsfactory"
+)
+
+/*
+MockCloudWatchLogsFactory is a mock implementation of the `CloudWatchLogsFactory` (internal/pkg/awsfactory) interface.
+*/
+type MockCloudWatchLogsFactory struct {
+	mock.Mock
+}
+
+/*
+MockCloudWatchLogsClient is a mock implementation of the `CloudWatchLogsClient` (internal/pkg/awsfactory) interface.
+*/
+type MockCloudWatchLogsClient struct {
+	mock.Mock
+}
+
+/*
+MockFilterLogEventsPaginator is a mock implementation of the `FilterLogEventsPaginator` (internal/pkg/awsfactory) interface.
+*/
+type MockFilterLogEventsPaginator struct {
+	mock.Mock
+}
+
+func (m *MockCloudWatchLogsFactory) GetClient() awsfactory.CloudWatchLogsClient {
+	args := m.Called()
+
+	return args.Get(0).(*MockCloudWatchLogsClient)
+}
+
+func (m *MockCloudWatchLogsFactory) NewFilterLogEventsPaginator(params *cloudwatchlogs.FilterLogEventsInput) (awsfactory.FilterLogEventsPaginator, error) {
+	args := m.Called(params)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*MockFilterLogEventsPaginator), args.Error(1)
+}
+
+func (m *MockCloudWatchLogsClient) FilterLogEvents(ctx context.Context, params *cloudwatchlogs.FilterLogEventsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*cloudwatchlogs.FilterLogEventsOutput), args.Error(1)
+}
+
+func (m *MockFilterLogEventsPaginator) HasMorePages() bool {
+	args := m.Called()
+
+	return args.Bool(0)
+}
+
+func (m *MockFilterLogEventsPaginator) NextPage(ctx context.Context, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	args := m.Called(ctx, optFns)
+
+	return args.Get(0).(*cloudwatchlogs.FilterLogEventsOutput), args.Error(1)
+}
@@ -30,6 +30,20 @@ type MockDescribeDBClustersPaginator struct {
 	mock.Mock
 }
 
+/*
+MockDescribeDBInstancesPaginator is a mock implementation of the `DescribeDBInstancesPaginator` (internal/pkg/awsfactory) interface.
+*/
+type MockDescribeDBInstancesPaginator struct {
+	mock.Mock
+}
+
+/*
+MockDescribeEventsPaginator is a mock implementation of the `DescribeEventsPaginator` (internal/pkg/awsfactory) interface.
+*/
+type MockDescribeEventsPaginator struct {
+	mock.Mock
+}
+
 /*
 MockDescribePendingMaintenanceActionsPaginator is a mock implementation of the `DescribePendingMaintenanceActionsPaginator` (internal/pkg/awsfactory) interface.
 */
@@ -53,6 +67,26 @@ func (m *MockRDSFactory) NewDescribeDBClustersPaginator(params *rds.DescribeDBCl
 	return args.Get(0).(*MockDescribeDBClustersPaginator), args.Error(1)
 }
 
+func (m *MockRDSFactory) NewDescribeDBInstancesPaginator(params *rds.DescribeDBInstancesInput) (awsfactory.DescribeDBInstancesPaginator, error) {
+	args := m.Called(params)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*MockDescribeDBInstancesPaginator), args.Error(1)
+}
+
+func (m *MockRDSFactory) NewDescribeEventsPaginator(params *rds.DescribeEventsInput) (awsfactory.DescribeEventsPaginator, error) {
+	args := m.Called(params)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*MockDescribeEventsPaginator), args.Error(1)
+}
+
 func (m *MockRDSFactory) NewDescribePendingMaintenanceActionsPaginator(params *rds.DescribePendingMaintenanceActionsInput) (awsfactory.DescribePendingMaintenanceActionsPaginator, error) {
 	args := m.Called(params)
 
@@ -63,6 +97,12 @@ func (m *MockRDSFactory) NewDescribePendingMaintenanceActionsPaginator(params *r
 	return args.Get(0).(*MockDescribePendingMaintenanceActionsPaginator), args.Error(1)
 }
 
+func (m *MockRDSClient) ApplyPendingMaintenanceAction(ctx context.Context, params *rds.ApplyPendingMaintenanceActionInput, optFns ...func(*rds.Options)) (*rds.ApplyPendingMaintenanceActionOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*rds.ApplyPendingMaintenanceActionOutput), args.Error(1)
+}
+
 func (m *MockRDSClient) DescribeDBClusters(ctx context.Context, params *rds.DescribeDBClustersInput, optFns ...func(*rds.Options)) (*rds.DescribeDBClustersOutput, error) {
 	args := m.Called(ctx, params, optFns)
 
@@ -81,6 +121,30 @@ func (m *MockRDSClient) DescribePendingMaintenanceActions(ctx context.Context, p
 	return args.Get(0).(*rds.DescribePendingMaintenanceActionsOutput), args.Error(1)
 }
 
+func (m *MockRDSClient) StartDBCluster(ctx context.Context, params *rds.StartDBClusterInput, optFns ...func(*rds.Options)) (*rds.StartDBClusterOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*rds.StartDBClusterOutput), args.Error(1)
+}
+
+func (m *MockRDSClient) StartDBInstance(ctx context.Context, params *rds.StartDBInstanceInput, optFns ...func(*rds.Options)) (*rds.StartDBInstanceOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*rds.StartDBInstanceOutput), args.Error(1)
+}
+
+func (m *MockRDSClient) StopDBCluster(ctx context.Context, params *rds.StopDBClusterInput, optFns ...func(*rds.Options)) (*rds.StopDBClusterOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*rds.StopDBClusterOutput), args.Error(1)
+}
+
+func (m *MockRDSClient) StopDBInstance(ctx context.Context, params *rds.StopDBInstanceInput, optFns ...func(*rds.Options)) (*rds.StopDBInstanceOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*rds.StopDBInstanceOutput), args.Error(1)
+}
+
 func (m *MockDescribeDBClustersPaginator) HasMorePages() bool {
 	args := m.Called()
 
@@ -93,6 +157,30 @@ func (m *MockDescribeDBClustersPaginator) NextPage(ctx context.Context, optFns .
 	return args.Get(0).(*rds.DescribeDBClustersOutput), args.Error(1)
 }
 
+func (m *MockDescribeDBInstancesPaginator) HasMorePages() bool {
+	args := m.Called()
+
+	return args.Bool(0)
+}
+
+func (m *MockDescribeDBInstancesPaginator) NextPage(ctx context.Context, optFns ...func(*rds.Options)) (*rds.DescribeDBInstancesOutput, error) {
+	args := m.Called(ctx, optFns)
+
+	return args.Get(0).(*rds.DescribeDBInstancesOutput), args.Error(1)
+}
+
+func (m *MockDescribeEventsPaginator) HasMorePages() bool {
+	args := m.Called()
+
+	return args.Bool(0)
+}
+
+func (m *MockDescribeEventsPaginator) NextPage(ctx context.Context, optFns ...func(*rds.Options)) (*rds.DescribeEventsOutput, error) {
+	args := m.Called(ctx, optFns)
+
+	return args.Get(0).(*rds.DescribeEventsOutput), args.Error(1)
+}
+
 func (m *MockDescribePendingMaintenanceActionsPaginator) HasMorePages() bool {
 	args := m.Called()
 
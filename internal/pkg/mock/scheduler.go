@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/scheduler"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/awsfactory"
+)
+
+/*
+MockSchedulerFactory is a mock implementation of the `SchedulerFactory` (internal/pkg/awsfactory) interface.
+*/
+type MockSchedulerFactory struct {
+	mock.Mock
+}
+
+/*
+MockSchedulerClient is a mock implementation of the `SchedulerClient` (internal/pkg/awsfactory) interface.
+*/
+type MockSchedulerClient struct {
+	mock.Mock
+}
+
+func (m *MockSchedulerFactory) GetClient() awsfactory.SchedulerClient {
+	args := m.Called()
+
+	return args.Get(0).(*MockSchedulerClient)
+}
+
+func (m *MockSchedulerClient) GetSchedule(ctx context.Context, params *scheduler.GetScheduleInput, optFns ...func(*scheduler.Options)) (*scheduler.GetScheduleOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*scheduler.GetScheduleOutput), args.Error(1)
+}
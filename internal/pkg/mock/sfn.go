@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/awsfactory"
+)
+
+/*
+MockSFNFactory is a mock implementation of the `SFNFactory` (internal/pkg/awsfactory) interface.
+*/
+type MockSFNFactory struct {
+	mock.Mock
+}
+
+/*
+MockSFNClient is a mock implementation of the `SFNClient` (internal/pkg/awsfactory) interface.
+*/
+type MockSFNClient struct {
+	mock.Mock
+}
+
+/*
+MockListExecutionsPaginator is a mock implementation of the `ListExecutionsPaginator` (internal/pkg/awsfactory) interface.
+*/
+type MockListExecutionsPaginator struct {
+	mock.Mock
+}
+
+/*
+MockGetExecutionHistoryPaginator is a mock implementation of the `GetExecutionHistoryPaginator` (internal/pkg/awsfactory) interface.
+*/
+type MockGetExecutionHistoryPaginator struct {
+	mock.Mock
+}
+
+func (m *MockSFNFactory) GetClient() awsfactory.SFNClient {
+	args := m.Called()
+
+	return args.Get(0).(*MockSFNClient)
+}
+
+func (m *MockSFNFactory) NewListExecutionsPaginator(params *sfn.ListExecutionsInput) (awsfactory.ListExecutionsPaginator, error) {
+	args := m.Called(params)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*MockListExecutionsPaginator), args.Error(1)
+}
+
+func (m *MockSFNFactory) NewGetExecutionHistoryPaginator(params *sfn.GetExecutionHistoryInput) (awsfactory.GetExecutionHistoryPaginator, error) {
+	args := m.Called(params)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*MockGetExecutionHistoryPaginator), args.Error(1)
+}
+
+func (m *MockSFNClient) DescribeExecution(ctx context.Context, params *sfn.DescribeExecutionInput, optFns ...func(*sfn.Options)) (*sfn.DescribeExecutionOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*sfn.DescribeExecutionOutput), args.Error(1)
+}
+
+func (m *MockSFNClient) GetExecutionHistory(ctx context.Context, params *sfn.GetExecutionHistoryInput, optFns ...func(*sfn.Options)) (*sfn.GetExecutionHistoryOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*sfn.GetExecutionHistoryOutput), args.Error(1)
+}
+
+func (m *MockSFNClient) ListExecutions(ctx context.Context, params *sfn.ListExecutionsInput, optFns ...func(*sfn.Options)) (*sfn.ListExecutionsOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*sfn.ListExecutionsOutput), args.Error(1)
+}
+
+func (m *MockSFNClient) StartExecution(ctx context.Context, params *sfn.StartExecutionInput, optFns ...func(*sfn.Options)) (*sfn.StartExecutionOutput, error) {
+	args := m.Called(ctx, params, optFns)
+
+	return args.Get(0).(*sfn.StartExecutionOutput), args.Error(1)
+}
+
+func (m *MockListExecutionsPaginator) HasMorePages() bool {
+	args := m.Called()
+
+	return args.Bool(0)
+}
+
+func (m *MockListExecutionsPaginator) NextPage(ctx context.Context, optFns ...func(*sfn.Options)) (*sfn.ListExecutionsOutput, error) {
+	args := m.Called(ctx, optFns)
+
+	return args.Get(0).(*sfn.ListExecutionsOutput), args.Error(1)
+}
+
+func (m *MockGetExecutionHistoryPaginator) HasMorePages() bool {
+	args := m.Called()
+
+	return args.Bool(0)
+}
+
+func (m *MockGetExecutionHistoryPaginator) NextPage(ctx context.Context, optFns ...func(*sfn.Options)) (*sfn.GetExecutionHistoryOutput, error) {
+	args := m.Called(ctx, optFns)
+
+	return args.Get(0).(*sfn.GetExecutionHistoryOutput), args.Error(1)
+}
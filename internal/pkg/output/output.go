@@ -0,0 +1,136 @@
+/*
+Package output provides functionality for rendering command results in various data formats.
+
+Results are exposed as titled tables of typed values (strings, booleans, numbers and timestamps).
+Tabular formats (table, csv and markdown) render the tables, while structured formats
+(json, yaml and go-template) render the JSON representation of the result itself.
+*/
+package output
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+/*
+Result is implemented by values that can be rendered by Printer.
+*/
+type Result interface {
+	Tables() []Table // tables used by tabular formats
+}
+
+/*
+Printer renders results in the format selected by the user.
+*/
+type Printer struct {
+	format    string             // output format name
+	template  *template.Template // parsed template (go-template only)
+	noHeaders bool               // whether to omit titles and header rows
+}
+
+/*
+renderer converts a result into a string representation.
+*/
+type renderer func(p *Printer, result Result) (string, error)
+
+const (
+	FormatTable      = "table"       // aligned plain text table
+	FormatJSON       = "json"        // indented JSON
+	FormatYAML       = "yaml"        // YAML
+	FormatCSV        = "csv"         // comma-separated values
+	FormatMarkdown   = "markdown"    // Markdown table
+	FormatGoTemplate = "go-template" // Go text/template applied to the JSON representation
+)
+
+/*
+renderers maps each output format to its renderer.
+*/
+var renderers = map[string]renderer{
+	FormatTable:      renderTable,
+	FormatJSON:       renderJSON,
+	FormatYAML:       renderYAML,
+	FormatCSV:        renderCSV,
+	FormatMarkdown:   renderMarkdown,
+	FormatGoTemplate: renderTemplate,
+}
+
+/*
+Formats returns the names of the available output formats.
+*/
+func Formats() []string {
+	formats := make([]string, 0, len(renderers))
+
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+
+	slices.Sort(formats)
+
+	return formats
+}
+
+/*
+NewPrinter creates a new Printer from the value of the `--output` option.
+The go-template format takes the template text after "=" (e.g., "go-template={{range .}}{{.id}}{{end}}").
+*/
+func NewPrinter(spec string, noHeaders bool) (*Printer, error) {
+	format, text, hasText := strings.Cut(spec, "=")
+
+	if _, ok := renderers[format]; !ok {
+		return nil, fmt.Errorf("unknown output format '%s' (available: %s)", format, strings.Join(Formats(), ", "))
+	}
+
+	p := &Printer{
+		format:    format,
+		noHeaders: noHeaders,
+	}
+
+	if format != FormatGoTemplate {
+		if hasText {
+			return nil, fmt.Errorf("output format '%s' does not take a value", format)
+		}
+
+		return p, nil
+	}
+
+	if text == "" {
+		return nil, fmt.Errorf("output format '%s' requires a template (e.g., go-template={{range .}}{{.id}}{{end}})", format)
+	}
+
+	tmpl, err := template.New(FormatGoTemplate).Parse(text)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	p.template = tmpl
+
+	return p, nil
+}
+
+/*
+Format returns the name of the output format.
+*/
+func (p *Printer) Format() string {
+	return p.format
+}
+
+/*
+Render converts the result into a string representation in the selected format.
+*/
+func (p *Printer) Render(result Result) (string, error) {
+	slog.Debug("Rendering result", "format", p.format)
+
+	output, err := renderers[p.format](p, result)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to render result as %s: %w", p.format, err)
+	}
+
+	slog.Debug("Result rendered successfully")
+
+	return output, nil
+}
@@ -0,0 +1,281 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+testReport is a Result with multiple titled tables and a structured JSON representation.
+*/
+type testReport struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Count   int    `json:"count"`
+}
+
+func (r *testReport) Tables() []Table {
+	return []Table{
+		{
+			Title:   "Summary",
+			Headers: []string{"name", "enabled"},
+			Rows:    [][]any{{r.Name, r.Enabled}},
+		},
+		{
+			Title:   "Counts",
+			Headers: []string{"count"},
+			Rows:    [][]any{{r.Count}},
+		},
+	}
+}
+
+func Test_NewPrinter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Table",
+			spec:     "table",
+			expected: FormatTable,
+			wantErr:  false,
+		},
+		{
+			name:     "Go template",
+			spec:     "go-template={{len .}}",
+			expected: FormatGoTemplate,
+			wantErr:  false,
+		},
+		{
+			name:     "Unknown format",
+			spec:     "xml",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Value for format without template",
+			spec:     "json=x",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Go template without template",
+			spec:     "go-template",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid template",
+			spec:     "go-template={{range .}",
+			expected: "",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewPrinter(tc.spec, false)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got.Format(), "Output format does not match expected format")
+			}
+		})
+	}
+}
+
+func Test_Render(t *testing.T) {
+	stopDate := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	table := &Table{
+		Headers: []string{"id", "enabled", "count", "stopped"},
+		Rows: [][]any{
+			{"db1", true, 2, &stopDate},
+			{"db|2", false, 0, (*time.Time)(nil)},
+		},
+	}
+
+	localStopDate := stopDate.Local().Format(time.RFC3339)
+
+	testCases := []struct {
+		name      string
+		spec      string
+		noHeaders bool
+		result    Result
+		expected  string
+	}{
+		{
+			name:   "Table",
+			spec:   "table",
+			result: table,
+			expected: strings.Join([]string{
+				"ID     ENABLED   COUNT   " + padRight("STOPPED", len(localStopDate)),
+				"db1    true      2       " + localStopDate,
+				"db|2   false     0       " + padRight("-", len(localStopDate)),
+			}, "\n"),
+		},
+		{
+			name:      "Table without headers",
+			spec:      "table",
+			noHeaders: true,
+			result: &Table{
+				Headers: []string{"id", "type"},
+				Rows:    [][]any{{"db1", "aurora"}},
+			},
+			expected: "db1   aurora",
+		},
+		{
+			name:   "Titled tables",
+			spec:   "table",
+			result: &testReport{Name: "x", Enabled: true, Count: 3},
+			expected: strings.Join([]string{
+				"[Summary]",
+				"NAME   ENABLED",
+				"x      true   ",
+				"",
+				"[Counts]",
+				"COUNT",
+				"3    ",
+			}, "\n"),
+		},
+		{
+			name:   "JSON",
+			spec:   "json",
+			result: table,
+			expected: strings.Join([]string{
+				`[`,
+				`  {`,
+				`    "id": "db1",`,
+				`    "enabled": true,`,
+				`    "count": 2,`,
+				`    "stopped": "2025-01-02T03:04:05Z"`,
+				`  },`,
+				`  {`,
+				`    "id": "db|2",`,
+				`    "enabled": false,`,
+				`    "count": 0,`,
+				`    "stopped": null`,
+				`  }`,
+				`]`,
+			}, "\n"),
+		},
+		{
+			name:   "YAML",
+			spec:   "yaml",
+			result: table,
+			expected: strings.Join([]string{
+				`- id: db1`,
+				`  enabled: true`,
+				`  count: 2`,
+				`  stopped: "2025-01-02T03:04:05Z"`,
+				`- id: db|2`,
+				`  enabled: false`,
+				`  count: 0`,
+				`  stopped: null`,
+			}, "\n"),
+		},
+		{
+			name:   "CSV",
+			spec:   "csv",
+			result: &testReport{Name: "a,b", Enabled: true, Count: 3},
+			expected: strings.Join([]string{
+				"# Summary",
+				"name,enabled",
+				`"a,b",true`,
+				"",
+				"# Counts",
+				"count",
+				"3",
+			}, "\n"),
+		},
+		{
+			name:      "CSV without headers",
+			spec:      "csv",
+			noHeaders: true,
+			result:    &testReport{Name: "x", Enabled: false, Count: 3},
+			expected: strings.Join([]string{
+				"x,false",
+				"",
+				"3",
+			}, "\n"),
+		},
+		{
+			name: "Markdown",
+			spec: "markdown",
+			result: &Table{
+				Headers: []string{"id", "type"},
+				Rows:    [][]any{{"db|1", "aurora"}},
+			},
+			expected: strings.Join([]string{
+				"| id | type |",
+				"| --- | --- |",
+				`| db\|1 | aurora |`,
+			}, "\n"),
+		},
+		{
+			name:     "Go template",
+			spec:     `go-template={{range .}}{{.id}}:{{.count}}{{"\n"}}{{end}}`,
+			result:   table,
+			expected: "db1:2\ndb|2:0",
+		},
+		{
+			name:     "Go template on structured result",
+			spec:     "go-template={{.name}} {{.enabled}}",
+			result:   &testReport{Name: "x", Enabled: true, Count: 3},
+			expected: "x true",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewPrinter(tc.spec, tc.noHeaders)
+
+			assert.NoError(t, err, "Unexpected error occurred")
+
+			got, err := p.Render(tc.result)
+
+			assert.NoError(t, err, "Unexpected error occurred")
+
+			assert.Equal(t, tc.expected, got, "Rendered output does not match expected output")
+		})
+	}
+}
+
+func Test_FormatValue(t *testing.T) {
+	date := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"Nil", nil, "-"},
+		{"String", "text", "text"},
+		{"Boolean", true, "true"},
+		{"Integer", 42, "42"},
+		{"Time", date, date.Local().Format(time.RFC3339)},
+		{"Time pointer", &date, date.Local().Format(time.RFC3339)},
+		{"Nil time pointer", (*time.Time)(nil), "-"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FormatValue(tc.value), "Formatted value does not match expected value")
+		})
+	}
+}
+
+/*
+padRight pads the text with spaces to the given width.
+*/
+func padRight(text string, width int) string {
+	return text + strings.Repeat(" ", width-len(text))
+}
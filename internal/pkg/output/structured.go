@@ -0,0 +1,103 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+/*
+renderJSON renders the result as indented JSON.
+*/
+func renderJSON(_ *Printer, result Result) (string, error) {
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal result to JSON: %w", err)
+	}
+
+	return string(jsonBytes), nil
+}
+
+/*
+renderYAML renders the result as YAML.
+The result is converted through its JSON representation so that field names and key order
+are the same as the json format.
+*/
+func renderYAML(_ *Printer, result Result) (string, error) {
+	jsonBytes, err := json.Marshal(result)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal result to JSON: %w", err)
+	}
+
+	var node yaml.Node
+
+	// NOTE: JSON is a subset of YAML, so it can be parsed into a node tree while keeping the key order.
+	if err := yaml.Unmarshal(jsonBytes, &node); err != nil {
+		return "", fmt.Errorf("failed to parse JSON as YAML: %w", err)
+	}
+
+	resetStyle(&node)
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&node); err != nil {
+		return "", fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+/*
+resetStyle clears the flow and quoting styles inherited from JSON, so that the node tree
+is encoded in block style. Strings are still quoted where required to keep their type.
+*/
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+/*
+renderTemplate applies the Go template to the JSON representation of the result.
+The fields are therefore referenced by their JSON names (e.g., `{{range .}}{{.id}}{{"\n"}}{{end}}`).
+*/
+func renderTemplate(p *Printer, result Result) (string, error) {
+	jsonBytes, err := json.Marshal(result)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal result to JSON: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+
+	decoder.UseNumber()
+
+	var data any
+
+	if err := decoder.Decode(&data); err != nil {
+		return "", fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	var sb strings.Builder
+
+	if err := p.template.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
@@ -0,0 +1,132 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+/*
+Table is a titled table of typed values.
+Cells may be strings, booleans, numbers, `time.Time` or `*time.Time`; nil represents the absence of a value.
+A single Table is also a Result, rendered as an array of objects in structured formats.
+*/
+type Table struct {
+	Title   string   // table title (may be empty)
+	Headers []string // column names
+	Rows    [][]any  // rows of typed values
+}
+
+/*
+noValue is the text representation of nil cells.
+*/
+const noValue = "-"
+
+/*
+Tables returns the table itself.
+*/
+func (t *Table) Tables() []Table {
+	return []Table{*t}
+}
+
+/*
+MarshalJSON converts the table into an array of objects keyed by the column names.
+Unlike maps, the keys are kept in the order of the columns.
+*/
+func (t Table) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('[')
+
+	for i, row := range t.Rows {
+		if 0 < i {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteByte('{')
+
+		for j, header := range t.Headers {
+			if 0 < j {
+				buf.WriteByte(',')
+			}
+
+			key, err := json.Marshal(header)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal column name '%s': %w", header, err)
+			}
+
+			var cell any
+
+			if j < len(row) {
+				cell = row[j]
+			}
+
+			value, err := json.Marshal(cell)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal value of column '%s': %w", header, err)
+			}
+
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+
+		buf.WriteByte('}')
+	}
+
+	buf.WriteByte(']')
+
+	return buf.Bytes(), nil
+}
+
+/*
+stringRows converts the typed values into strings for tabular formats.
+*/
+func (t *Table) stringRows() [][]string {
+	rows := make([][]string, len(t.Rows))
+
+	for i, row := range t.Rows {
+		rows[i] = make([]string, len(t.Headers))
+
+		for j := range t.Headers {
+			if j < len(row) {
+				rows[i][j] = FormatValue(row[j])
+			} else {
+				rows[i][j] = noValue
+			}
+		}
+	}
+
+	return rows
+}
+
+/*
+FormatValue converts a typed value into its text representation.
+Timestamps are displayed in local time in RFC 3339 format.
+*/
+func FormatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return noValue
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		return v.Local().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return noValue
+		}
+
+		return v.Local().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,161 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+/*
+renderTable renders the tables as aligned plain text.
+Each titled table is preceded by its title in brackets, and tables are separated by a blank line.
+*/
+func renderTable(p *Printer, result Result) (string, error) {
+	tables := result.Tables()
+
+	blocks := make([]string, len(tables))
+
+	for i, table := range tables {
+		text := formatAlignedTable(table.Headers, table.stringRows(), !p.noHeaders)
+
+		if table.Title != "" && !p.noHeaders {
+			text = fmt.Sprintf("[%s]\n%s", table.Title, text)
+		}
+
+		blocks[i] = text
+	}
+
+	return strings.Join(blocks, "\n\n"), nil
+}
+
+/*
+formatAlignedTable converts data into a formatted table string representation.
+It takes a slice of headers and a 2D slice of body content, then formats them
+into a well-aligned table structure with proper spacing.
+*/
+func formatAlignedTable(headers []string, body [][]string, withHeaders bool) string {
+	widths := make([]int, len(headers))
+
+	if withHeaders {
+		for i, header := range headers {
+			widths[i] = len(header)
+		}
+	}
+
+	for _, cols := range body {
+		for i, col := range cols {
+			if widths[i] < len(col) {
+				widths[i] = len(col)
+			}
+		}
+	}
+
+	var cells [][]string
+
+	if withHeaders {
+		cells = append(cells, make([]string, len(headers)))
+
+		for i, col := range headers {
+			cells[0][i] = strings.ToUpper(col)
+		}
+	}
+
+	cells = append(cells, body...)
+
+	rows := make([]string, len(cells))
+
+	for i, cols := range cells {
+		padded := make([]string, len(cols))
+
+		// NOTE: Pad each cell to the column width for left-aligned display
+		for j, col := range cols {
+			padded[j] = fmt.Sprintf("%-*s", widths[j], col)
+		}
+
+		rows[i] = strings.Join(padded, "   ")
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+/*
+renderCSV renders the tables as comma-separated values.
+Each titled table is preceded by a "# title" line, and tables are separated by a blank line.
+*/
+func renderCSV(p *Printer, result Result) (string, error) {
+	tables := result.Tables()
+
+	blocks := make([]string, len(tables))
+
+	for i, table := range tables {
+		var sb strings.Builder
+
+		if table.Title != "" && !p.noHeaders {
+			fmt.Fprintf(&sb, "# %s\n", table.Title)
+		}
+
+		writer := csv.NewWriter(&sb)
+
+		if !p.noHeaders {
+			if err := writer.Write(table.Headers); err != nil {
+				return "", fmt.Errorf("failed to write CSV header: %w", err)
+			}
+		}
+
+		if err := writer.WriteAll(table.stringRows()); err != nil {
+			return "", fmt.Errorf("failed to write CSV rows: %w", err)
+		}
+
+		blocks[i] = strings.TrimSuffix(sb.String(), "\n")
+	}
+
+	return strings.Join(blocks, "\n\n"), nil
+}
+
+/*
+renderMarkdown renders the tables as Markdown tables.
+Each titled table is preceded by a heading. Since a Markdown table requires a header row,
+only the headings are omitted with `--no-headers`.
+*/
+func renderMarkdown(p *Printer, result Result) (string, error) {
+	tables := result.Tables()
+
+	blocks := make([]string, len(tables))
+
+	for i, table := range tables {
+		var lines []string
+
+		if table.Title != "" && !p.noHeaders {
+			lines = append(lines, "### "+table.Title, "")
+		}
+
+		separators := make([]string, len(table.Headers))
+
+		for j := range separators {
+			separators[j] = "---"
+		}
+
+		lines = append(lines, markdownRow(table.Headers), markdownRow(separators))
+
+		for _, row := range table.stringRows() {
+			lines = append(lines, markdownRow(row))
+		}
+
+		blocks[i] = strings.Join(lines, "\n")
+	}
+
+	return strings.Join(blocks, "\n\n"), nil
+}
+
+/*
+markdownRow formats cells as a row of a Markdown table, escaping pipe characters.
+*/
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
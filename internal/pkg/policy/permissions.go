@@ -0,0 +1,554 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/cfn"
+)
+
+/*
+commandPermissions maps each ktnh command to the permissions it requires.
+Permissions sharing the same Sid are merged into a single statement when the policy is generated.
+*/
+var commandPermissions = map[string][]permission{
+	"freeze": slices.Concat(createStacks, []permission{
+		// NOTE: The following are required only for `--verify` and `--stop-now`.
+		{
+			sid:       "ReadStacks",
+			actions:   []string{"cloudformation:DescribeStackResources"},
+			resources: anyStackResources,
+		},
+		startExecutions,
+		describeExecutions,
+	}, tagResources), // NOTE: `tagResources` is required only for `--stack-tag`.
+	"defrost": slices.Concat(deleteStacks, []permission{
+		// NOTE: The following is required only for `--start`.
+		{
+			sid: "OperateDBs",
+			actions: []string{
+				"rds:StartDBCluster",
+				"rds:StartDBInstance",
+			},
+			resources: anyResource,
+		},
+	}),
+	"prune": deleteStacks,
+	// NOTE: `template` accesses AWS only for `--validate`.
+	"template": {validateTemplates},
+	"scan": slices.Concat([]permission{
+		discoverStacks,
+		readStacks,
+		describeDBs,
+		{
+			sid:       "DescribeDBs",
+			actions:   []string{"rds:DescribeEvents"},
+			resources: anyResource,
+		},
+	}, createStacks, tagResources), // NOTE: `createStacks` is required only for `--freeze`, and `tagResources` also for `--stack-tag`.
+	"status": {
+		discoverStacks,
+		readStacks,
+		describeDBs,
+		{
+			sid:       "ReadStacks",
+			actions:   []string{"cloudformation:DescribeStackResources"},
+			resources: anyStackResources,
+		},
+		{
+			sid:       "DescribeDBs",
+			actions:   []string{"rds:DescribePendingMaintenanceActions"},
+			resources: anyResource,
+		},
+		{
+			sid:       "ReadEventRules",
+			actions:   []string{"events:DescribeRule"},
+			resources: eventRuleResources,
+		},
+		{
+			sid:       "ReadSchedules",
+			actions:   []string{"scheduler:GetSchedule"},
+			resources: scheduleResources,
+		},
+		{
+			sid:       "ReadExecutions",
+			actions:   []string{"states:ListExecutions"},
+			resources: stateMachineResources,
+		},
+	},
+	"history": {
+		discoverStacks,
+		readStacks,
+		describeDBs,
+		{
+			sid:       "ReadStacks",
+			actions:   []string{"cloudformation:DescribeStackResources"},
+			resources: anyStackResources,
+		},
+		{
+			sid:       "ReadExecutions",
+			actions:   []string{"states:ListExecutions"},
+			resources: stateMachineResources,
+		},
+		{
+			sid:       "ReadExecutionHistory",
+			actions:   []string{"states:GetExecutionHistory"},
+			resources: executionResources,
+		},
+	},
+	"logs": {
+		discoverStacks,
+		readStacks,
+		describeDBs,
+		{
+			sid:       "ReadStacks",
+			actions:   []string{"cloudformation:DescribeStackResources"},
+			resources: anyStackResources,
+		},
+		{
+			sid:       "ReadLogEvents",
+			actions:   []string{"logs:FilterLogEvents"},
+			resources: logGroupResources,
+		},
+	},
+	"trigger": {
+		discoverStacks,
+		readStacks,
+		describeDBs,
+		{
+			sid:       "ReadStacks",
+			actions:   []string{"cloudformation:DescribeStackResources"},
+			resources: anyStackResources,
+		},
+		startExecutions,
+		describeExecutions,
+	},
+	"maintenance": slices.Concat([]permission{
+		discoverStacks,
+		readStacks,
+		describeDBs,
+		{
+			sid:       "DescribeDBs",
+			actions:   []string{"rds:DescribePendingMaintenanceActions"},
+			resources: anyResource,
+		},
+		// NOTE: The following are required only for `maintenance apply`.
+		{
+			sid: "OperateDBs",
+			actions: []string{
+				"rds:ApplyPendingMaintenanceAction",
+				"rds:StartDBCluster",
+				"rds:StartDBInstance",
+				"rds:StopDBCluster",
+				"rds:StopDBInstance",
+			},
+			resources: anyResource,
+		},
+	}, toggleProtection),
+	"plan":   reconcileStacks,
+	"export": reconcileStacks,
+	"apply":  slices.Concat(reconcileStacks, createStacks, deleteStacks, toggleProtection, tagResources),
+	"pause":  toggleProtection,
+	"resume": toggleProtection,
+	"list": {
+		discoverStacks,
+		readStacks,
+		{
+			sid:       "DiscoverStacks",
+			actions:   []string{"cloudformation:DescribeStacks"},
+			resources: anyResource,
+		},
+		{
+			sid: "DescribeDBs",
+			actions: []string{
+				"rds:DescribeDBClusters",
+				"rds:DescribeDBInstances",
+				"rds:DescribeEvents",
+				"rds:DescribePendingMaintenanceActions",
+			},
+			resources: anyResource,
+		},
+	},
+}
+
+/*
+validateTemplates is the permission required to validate templates before creating stacks.
+ValidateTemplate does not support resource-level permissions.
+*/
+var validateTemplates = permission{
+	sid:       "ValidateTemplates",
+	actions:   []string{"cloudformation:ValidateTemplate"},
+	resources: anyResource,
+}
+
+/*
+createStacks lists the permissions required to create ktnh stacks and the resources they contain.
+*/
+var createStacks = []permission{
+	discoverStacks,
+	readStacks,
+	describeDBs,
+	validateTemplates,
+	{
+		sid:       "ManageStacks",
+		actions:   []string{"cloudformation:CreateStack"},
+		resources: stackResources,
+	},
+	{
+		sid: "ManageRoles",
+		actions: []string{
+			"iam:CreateRole",
+			"iam:GetRole",
+			"iam:GetRolePolicy",
+			"iam:PutRolePolicy",
+		},
+		resources: roleResources,
+	},
+	{
+		sid:       "PassRoles",
+		actions:   []string{"iam:PassRole"},
+		resources: roleResources,
+	},
+	{
+		sid: "ManageLogGroups",
+		actions: []string{
+			"logs:CreateLogGroup",
+			"logs:PutRetentionPolicy",
+		},
+		resources: logGroupResources,
+	},
+	logDelivery,
+	{
+		sid: "ManageStateMachines",
+		actions: []string{
+			"states:CreateStateMachine",
+			"states:DescribeStateMachine",
+		},
+		resources: stateMachineResources,
+	},
+	{
+		sid: "ManageEventRules",
+		actions: []string{
+			"events:DescribeRule",
+			"events:PutRule",
+			"events:PutTargets",
+		},
+		resources: eventRuleResources,
+	},
+	{
+		sid: "ManageSchedules",
+		actions: []string{
+			"scheduler:CreateSchedule",
+			"scheduler:GetSchedule",
+		},
+		resources: scheduleResources,
+	},
+}
+
+/*
+deleteStacks lists the permissions required to delete ktnh stacks and the resources they contain.
+*/
+var deleteStacks = []permission{
+	discoverStacks,
+	readStacks,
+	describeDBs,
+	{
+		sid:       "ManageStacks",
+		actions:   []string{"cloudformation:DeleteStack"},
+		resources: stackResources,
+	},
+	{
+		sid: "ManageRoles",
+		actions: []string{
+			"iam:DeleteRole",
+			"iam:DeleteRolePolicy",
+			"iam:GetRole",
+			"iam:ListAttachedRolePolicies",
+			"iam:ListRolePolicies",
+		},
+		resources: roleResources,
+	},
+	{
+		sid:       "ManageLogGroups",
+		actions:   []string{"logs:DeleteLogGroup"},
+		resources: logGroupResources,
+	},
+	logDelivery,
+	{
+		sid: "ManageStateMachines",
+		actions: []string{
+			"states:DeleteStateMachine",
+			"states:DescribeStateMachine",
+		},
+		resources: stateMachineResources,
+	},
+	{
+		sid: "ManageEventRules",
+		actions: []string{
+			"events:DeleteRule",
+			"events:DescribeRule",
+			"events:ListTargetsByRule",
+			"events:RemoveTargets",
+		},
+		resources: eventRuleResources,
+	},
+	{
+		sid: "ManageSchedules",
+		actions: []string{
+			"scheduler:DeleteSchedule",
+			"scheduler:GetSchedule",
+		},
+		resources: scheduleResources,
+	},
+}
+
+/*
+reconcileStacks lists the permissions required to compare the ktnh stacks with a manifest.
+*/
+var reconcileStacks = []permission{
+	discoverStacks,
+	readStacks,
+	describeDBs,
+	{
+		sid:       "DiscoverStacks",
+		actions:   []string{"cloudformation:DescribeStacks"},
+		resources: anyResource,
+	},
+}
+
+/*
+toggleProtection lists the permissions required to enable or disable the event rule and the schedule
+through a stack update.
+*/
+var toggleProtection = []permission{
+	discoverStacks,
+	readStacks,
+	describeDBs,
+	{
+		sid:       "ManageStacks",
+		actions:   []string{"cloudformation:UpdateStack"},
+		resources: stackResources,
+	},
+	{
+		sid:       "PassRoles",
+		actions:   []string{"iam:PassRole"},
+		resources: roleResources,
+	},
+	{
+		sid: "ManageEventRules",
+		actions: []string{
+			"events:DescribeRule",
+			"events:PutRule",
+			"events:PutTargets",
+		},
+		resources: eventRuleResources,
+	},
+	{
+		sid: "ManageSchedules",
+		actions: []string{
+			"scheduler:GetSchedule",
+			"scheduler:UpdateSchedule",
+		},
+		resources: scheduleResources,
+	},
+}
+
+/*
+tagResources lists the permissions required to change the tags of ktnh stacks,
+which CloudFormation propagates to the stack resources that support tagging.
+*/
+var tagResources = []permission{
+	{
+		sid: "ManageStacks",
+		actions: []string{
+			"cloudformation:TagResource",
+			"cloudformation:UntagResource",
+		},
+		resources: stackResources,
+	},
+	{
+		sid: "ManageRoles",
+		actions: []string{
+			"iam:TagRole",
+			"iam:UntagRole",
+		},
+		resources: roleResources,
+	},
+	{
+		sid: "ManageLogGroups",
+		actions: []string{
+			"logs:TagResource",
+			"logs:UntagResource",
+		},
+		resources: logGroupResources,
+	},
+	{
+		sid: "ManageStateMachines",
+		actions: []string{
+			"states:TagResource",
+			"states:UntagResource",
+		},
+		resources: stateMachineResources,
+	},
+	{
+		sid: "ManageEventRules",
+		actions: []string{
+			"events:TagResource",
+			"events:UntagResource",
+		},
+		resources: eventRuleResources,
+	},
+}
+
+var (
+	// discoverStacks allows listing stacks, which does not support resource-level permissions
+	discoverStacks = permission{
+		sid:       "DiscoverStacks",
+		actions:   []string{"cloudformation:ListStacks"},
+		resources: anyResource,
+	}
+
+	// readStacks allows reading templates and statuses of ktnh stacks, including those not named after the prefix
+	// (e.g., created by Service Catalog), which are found by the `Metadata.KTNH` sections of their templates
+	readStacks = permission{
+		sid: "ReadStacks",
+		actions: []string{
+			"cloudformation:DescribeStacks",
+			"cloudformation:GetTemplate",
+		},
+		resources: anyStackResources,
+	}
+
+	// describeDBs allows determining the type of the target DB
+	describeDBs = permission{
+		sid: "DescribeDBs",
+		actions: []string{
+			"rds:DescribeDBClusters",
+			"rds:DescribeDBInstances",
+		},
+		resources: anyResource,
+	}
+
+	// logDelivery allows configuring state machine logging, which does not support resource-level permissions
+	logDelivery = permission{
+		sid: "ConfigureLogDelivery",
+		actions: []string{
+			"logs:CreateLogDelivery",
+			"logs:DeleteLogDelivery",
+			"logs:DescribeLogGroups",
+			"logs:DescribeResourcePolicies",
+			"logs:GetLogDelivery",
+			"logs:ListLogDeliveries",
+			"logs:PutResourcePolicy",
+			"logs:UpdateLogDelivery",
+		},
+		resources: anyResource,
+	}
+
+	// startExecutions allows starting executions of state machines manually
+	startExecutions = permission{
+		sid:       "StartExecutions",
+		actions:   []string{"states:StartExecution"},
+		resources: stateMachineResources,
+	}
+
+	// describeExecutions allows waiting for executions to finish
+	describeExecutions = permission{
+		sid:       "DescribeExecutions",
+		actions:   []string{"states:DescribeExecution"},
+		resources: executionResources,
+	}
+)
+
+/*
+arnPrefix is the beginning of the resource ARNs up to the partition, which is a wildcard
+so that the policy can be used in any partition (e.g., `aws-us-gov` or `aws-cn`).
+*/
+const arnPrefix = "arn:*:"
+
+/*
+anyResource returns a wildcard resource for actions that do not support resource-level permissions.
+*/
+func anyResource(_ *Scope) []string {
+	return []string{"*"}
+}
+
+/*
+stackResources returns the ARN patterns of CloudFormation stacks created with the given prefix,
+and of the stacks given by their names.
+*/
+func stackResources(scope *Scope) []string {
+	resources := []string{
+		fmt.Sprintf("%scloudformation:*:*:stack/%s-*/*", arnPrefix, scope.StackNamePrefix),
+	}
+
+	for _, stackName := range scope.StackNames {
+		resources = append(resources, fmt.Sprintf("%scloudformation:*:*:stack/%s/*", arnPrefix, stackName))
+	}
+
+	return resources
+}
+
+/*
+anyStackResources returns the ARN pattern of all CloudFormation stacks.
+*/
+func anyStackResources(_ *Scope) []string {
+	return []string{
+		arnPrefix + "cloudformation:*:*:stack/*/*",
+	}
+}
+
+/*
+roleResources returns the ARN patterns of IAM roles defined in the generated template.
+*/
+func roleResources(scope *Scope) []string {
+	return []string{
+		arnPrefix + "iam::*:role/" + cfn.ResourceNamePattern(scope.StackNamePrefix, cfn.LogicalIDEventsRole),
+		arnPrefix + "iam::*:role/" + cfn.ResourceNamePattern(scope.StackNamePrefix, cfn.LogicalIDStateMachineExecutionRole),
+	}
+}
+
+/*
+logGroupResources returns the ARN pattern of log groups defined in the generated template.
+*/
+func logGroupResources(scope *Scope) []string {
+	return []string{
+		arnPrefix + "logs:*:*:log-group:" + cfn.ResourceNamePattern(scope.StackNamePrefix, cfn.LogicalIDStateMachineLogGroup),
+	}
+}
+
+/*
+stateMachineResources returns the ARN pattern of state machines defined in the generated template.
+*/
+func stateMachineResources(scope *Scope) []string {
+	return []string{
+		arnPrefix + "states:*:*:stateMachine:" + cfn.ResourceNamePattern(scope.StackNamePrefix, cfn.LogicalIDStateMachine),
+	}
+}
+
+/*
+executionResources returns the ARN pattern of executions of state machines defined in the generated template.
+*/
+func executionResources(scope *Scope) []string {
+	return []string{
+		arnPrefix + "states:*:*:execution:" + cfn.ResourceNamePattern(scope.StackNamePrefix, cfn.LogicalIDStateMachine) + ":*",
+	}
+}
+
+/*
+eventRuleResources returns the ARN pattern of EventBridge rules defined in the generated template.
+*/
+func eventRuleResources(scope *Scope) []string {
+	return []string{
+		arnPrefix + "events:*:*:rule/" + cfn.ResourceNamePattern(scope.StackNamePrefix, cfn.LogicalIDAutoStartEventRule),
+	}
+}
+
+/*
+scheduleResources returns the ARN pattern of EventBridge Scheduler schedules defined in the generated template.
+*/
+func scheduleResources(scope *Scope) []string {
+	return []string{
+		arnPrefix + "scheduler:*:*:schedule/default/" + cfn.ResourceNamePattern(scope.StackNamePrefix, cfn.LogicalIDPeriodicStopSchedule),
+	}
+}
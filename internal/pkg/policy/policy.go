@@ -0,0 +1,151 @@
+/*
+Package policy provides functionality for generating IAM policies for ktnh users.
+
+It builds least-privilege IAM policy documents that cover the AWS API calls made
+by each ktnh command, as well as the calls made by CloudFormation on behalf of the
+user when it creates or deletes the resources defined in the generated template.
+*/
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+/*
+Document represents an IAM policy document.
+*/
+type Document struct {
+	Version   string      `json:"Version"`   // policy language version
+	Statement []Statement `json:"Statement"` // list of policy statements
+}
+
+/*
+Statement represents a single statement in an IAM policy document.
+*/
+type Statement struct {
+	Sid      string   `json:"Sid"`      // statement identifier
+	Effect   string   `json:"Effect"`   // "Allow" or "Deny"
+	Action   []string `json:"Action"`   // list of API actions
+	Resource []string `json:"Resource"` // list of resource ARNs
+}
+
+/*
+Scope defines the names the resources of the policy are scoped to.
+*/
+type Scope struct {
+	StackNamePrefix string   // prefix of the stack names and the resource names
+	StackNames      []string // exact names of the stacks not named after the prefix (e.g., adopted with `--stack-name`)
+}
+
+/*
+permission defines a set of actions allowed on a set of resources.
+The resources are built from the scope at generation time.
+*/
+type permission struct {
+	sid       string                      // statement identifier
+	actions   []string                    // list of API actions
+	resources func(scope *Scope) []string // function that builds resource ARNs
+}
+
+const policyVersion = "2012-10-17"
+
+/*
+Commands returns the names of commands for which a policy can be generated.
+*/
+func Commands() []string {
+	commands := make([]string, 0, len(commandPermissions))
+
+	for command := range commandPermissions {
+		commands = append(commands, command)
+	}
+
+	slices.Sort(commands)
+
+	return commands
+}
+
+/*
+Generate builds the IAM policy document for the given commands.
+Statements with the same Sid are merged, and actions and resources are deduplicated and sorted
+so that the output is stable.
+*/
+func Generate(scope *Scope, commands []string) (*Document, error) {
+	slog.Debug("Generating IAM policy",
+		"stackNamePrefix", scope.StackNamePrefix,
+		"stackNames", scope.StackNames,
+		"commands", commands,
+	)
+
+	if len(commands) == 0 {
+		return nil, fmt.Errorf("no commands specified")
+	}
+
+	statements := map[string]*Statement{}
+
+	var sids []string
+
+	for _, command := range commands {
+		permissions, ok := commandPermissions[command]
+
+		if !ok {
+			return nil, fmt.Errorf("unknown command '%s'", command)
+		}
+
+		for _, p := range permissions {
+			statement, found := statements[p.sid]
+
+			if !found {
+				statement = &Statement{
+					Sid:    p.sid,
+					Effect: "Allow",
+				}
+
+				statements[p.sid] = statement
+
+				sids = append(sids, p.sid)
+			}
+
+			statement.Action = append(statement.Action, p.actions...)
+			statement.Resource = append(statement.Resource, p.resources(scope)...)
+		}
+	}
+
+	slices.Sort(sids)
+
+	document := &Document{
+		Version:   policyVersion,
+		Statement: make([]Statement, len(sids)),
+	}
+
+	for i, sid := range sids {
+		statement := statements[sid]
+
+		slices.Sort(statement.Action)
+		slices.Sort(statement.Resource)
+
+		statement.Action = slices.Compact(statement.Action)
+		statement.Resource = slices.Compact(statement.Resource)
+
+		document.Statement[i] = *statement
+	}
+
+	slog.Debug("IAM policy generated", "statements", len(document.Statement))
+
+	return document, nil
+}
+
+/*
+JSON returns the policy document as an indented JSON string.
+*/
+func (d *Document) JSON() (string, error) {
+	jsonBytes, err := json.MarshalIndent(d, "", "  ")
+
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal policy document to JSON: %w", err)
+	}
+
+	return string(jsonBytes), nil
+}
@@ -0,0 +1,161 @@
+package policy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Commands(t *testing.T) {
+	assert.Equal(t, []string{"apply", "defrost", "export", "freeze", "history", "list", "logs", "maintenance", "pause", "plan", "prune", "resume", "scan", "status", "template", "trigger"}, Commands(), "Commands should be returned in sorted order")
+}
+
+func Test_Generate(t *testing.T) {
+	testCases := []struct {
+		name            string
+		stackNamePrefix string
+		commands        []string
+		expected        *Document
+		wantErr         bool
+	}{
+		{
+			name:            "list only",
+			stackNamePrefix: "A",
+			commands:        []string{"list"},
+			expected: &Document{
+				Version: "2012-10-17",
+				Statement: []Statement{
+					{
+						Sid:    "DescribeDBs",
+						Effect: "Allow",
+						Action: []string{
+							"rds:DescribeDBClusters",
+							"rds:DescribeDBInstances",
+							"rds:DescribeEvents",
+							"rds:DescribePendingMaintenanceActions",
+						},
+						Resource: []string{"*"},
+					},
+					{
+						Sid:    "DiscoverStacks",
+						Effect: "Allow",
+						Action: []string{
+							"cloudformation:DescribeStacks",
+							"cloudformation:ListStacks",
+						},
+						Resource: []string{"*"},
+					},
+					{
+						Sid:    "ReadStacks",
+						Effect: "Allow",
+						Action: []string{
+							"cloudformation:DescribeStacks",
+							"cloudformation:GetTemplate",
+						},
+						Resource: []string{"arn:*:cloudformation:*:*:stack/*/*"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:            "Unknown command",
+			stackNamePrefix: "B",
+			commands:        []string{"list", "unknown"},
+			expected:        nil,
+			wantErr:         true,
+		},
+		{
+			name:            "No commands",
+			stackNamePrefix: "C",
+			commands:        []string{},
+			expected:        nil,
+			wantErr:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Generate(&Scope{StackNamePrefix: tc.stackNamePrefix}, tc.commands)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Generated policy does not match expected policy")
+			}
+		})
+	}
+
+	t.Run("Resources are partition-agnostic", func(t *testing.T) {
+		got, err := Generate(&Scope{StackNamePrefix: "E"}, Commands())
+
+		assert.NoError(t, err, "Unexpected error occurred")
+
+		for _, statement := range got.Statement {
+			for _, resource := range statement.Resource {
+				assert.True(t, (resource == "*") || strings.HasPrefix(resource, "arn:*:"), "Resource '%s' of '%s' should not be bound to a partition", resource, statement.Sid)
+			}
+		}
+	})
+
+	t.Run("Adopted stacks are included", func(t *testing.T) {
+		got, err := Generate(&Scope{StackNamePrefix: "F", StackNames: []string{"corp-my-db"}}, []string{"defrost"})
+
+		assert.NoError(t, err, "Unexpected error occurred")
+
+		for _, statement := range got.Statement {
+			if statement.Sid == "ManageStacks" {
+				assert.Equal(t, []string{"arn:*:cloudformation:*:*:stack/F-*/*", "arn:*:cloudformation:*:*:stack/corp-my-db/*"}, statement.Resource, "Adopted stacks should be allowed to be managed")
+			}
+		}
+	})
+
+	t.Run("Statements are merged", func(t *testing.T) {
+		got, err := Generate(&Scope{StackNamePrefix: "D"}, []string{"freeze", "defrost", "list"})
+
+		assert.NoError(t, err, "Unexpected error occurred")
+
+		sids := map[string]int{}
+
+		for _, statement := range got.Statement {
+			sids[statement.Sid]++
+
+			if statement.Sid == "ManageStacks" {
+				assert.Equal(t, []string{"cloudformation:CreateStack", "cloudformation:DeleteStack", "cloudformation:TagResource", "cloudformation:UntagResource"}, statement.Action, "Actions of merged statement do not match")
+				assert.Equal(t, []string{"arn:*:cloudformation:*:*:stack/D-*/*"}, statement.Resource, "Resources of merged statement do not match")
+			}
+
+			if statement.Sid == "DescribeDBs" {
+				assert.Equal(t, []string{
+					"rds:DescribeDBClusters",
+					"rds:DescribeDBInstances",
+					"rds:DescribeEvents",
+					"rds:DescribePendingMaintenanceActions",
+				}, statement.Action, "Actions should be deduplicated")
+			}
+		}
+
+		for sid, count := range sids {
+			assert.Equal(t, 1, count, "Statement '%s' should appear only once", sid)
+		}
+	})
+}
+
+func Test_JSON(t *testing.T) {
+	document, err := Generate(&Scope{StackNamePrefix: "E"}, []string{"freeze"})
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	got, err := document.JSON()
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	var parsed map[string]any
+
+	assert.NoError(t, json.Unmarshal([]byte(got), &parsed), "Output should be valid JSON")
+	assert.Equal(t, "2012-10-17", parsed["Version"], "Version does not match")
+}
@@ -0,0 +1,84 @@
+package rds
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+const (
+	resourceTypeCluster = "cluster" // resource type of Aurora clusters
+	resourceTypeDB      = "db"      // resource type of RDS instances (including members of Aurora clusters)
+)
+
+var (
+	// partitionPattern matches the AWS partitions (e.g., "aws", "aws-cn", "aws-us-gov")
+	partitionPattern = regexp.MustCompile(`^aws(-[a-z]+)*$`)
+
+	// regionPattern matches the AWS regions (e.g., "ap-northeast-1", "us-gov-west-1", "cn-north-1")
+	regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+	// accountIDPattern matches the AWS account IDs
+	accountIDPattern = regexp.MustCompile(`^[0-9]{12}$`)
+)
+
+/*
+dbARN holds the parts of the ARN of an Aurora cluster or an RDS instance
+(e.g., "arn:aws-us-gov:rds:us-gov-west-1:123456789012:cluster:my-cluster").
+*/
+type dbARN struct {
+	Partition    string // partition (e.g., "aws", "aws-cn", "aws-us-gov")
+	Region       string // region (e.g., "us-gov-west-1")
+	AccountID    string // account ID
+	ResourceType string // type of the resource ("cluster" or "db")
+	DBIdentifier string // identifier of the cluster or the instance
+}
+
+/*
+parseDBARN parses the ARN of an Aurora cluster or an RDS instance in any partition.
+The partition, the service, the region, the account ID and the resource type are validated.
+*/
+func parseDBARN(s string) (*dbARN, error) {
+	parsed, err := arn.Parse(s)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARN '%s': %w", s, err)
+	}
+
+	if !partitionPattern.MatchString(parsed.Partition) {
+		return nil, fmt.Errorf("invalid ARN '%s': unknown partition '%s'", s, parsed.Partition)
+	}
+
+	if parsed.Service != "rds" {
+		return nil, fmt.Errorf("invalid ARN '%s': service must be 'rds', not '%s'", s, parsed.Service)
+	}
+
+	if !regionPattern.MatchString(parsed.Region) {
+		return nil, fmt.Errorf("invalid ARN '%s': invalid region '%s'", s, parsed.Region)
+	}
+
+	if !accountIDPattern.MatchString(parsed.AccountID) {
+		return nil, fmt.Errorf("invalid ARN '%s': invalid account ID '%s'", s, parsed.AccountID)
+	}
+
+	resourceType, dbIdentifier, found := strings.Cut(parsed.Resource, ":")
+
+	if !found || !slices.Contains([]string{resourceTypeCluster, resourceTypeDB}, resourceType) {
+		return nil, fmt.Errorf("invalid ARN '%s': resource must be 'cluster:<id>' or 'db:<id>'", s)
+	}
+
+	if (dbIdentifier == "") || strings.Contains(dbIdentifier, ":") {
+		return nil, fmt.Errorf("invalid ARN '%s': invalid DB identifier '%s'", s, dbIdentifier)
+	}
+
+	return &dbARN{
+		Partition:    parsed.Partition,
+		Region:       parsed.Region,
+		AccountID:    parsed.AccountID,
+		ResourceType: resourceType,
+		DBIdentifier: dbIdentifier,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package rds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseDBARN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		arn      string
+		expected *dbARN
+		wantErr  bool
+	}{
+		{
+			name: "Aurora cluster",
+			arn:  "arn:aws:rds:ap-northeast-1:123456789012:cluster:my-aurora-cluster",
+			expected: &dbARN{
+				Partition:    "aws",
+				Region:       "ap-northeast-1",
+				AccountID:    "123456789012",
+				ResourceType: "cluster",
+				DBIdentifier: "my-aurora-cluster",
+			},
+			wantErr: false,
+		},
+		{
+			name: "RDS instance in GovCloud",
+			arn:  "arn:aws-us-gov:rds:us-gov-west-1:123456789012:db:my-rds-instance",
+			expected: &dbARN{
+				Partition:    "aws-us-gov",
+				Region:       "us-gov-west-1",
+				AccountID:    "123456789012",
+				ResourceType: "db",
+				DBIdentifier: "my-rds-instance",
+			},
+			wantErr: false,
+		},
+		{
+			name: "RDS instance in China",
+			arn:  "arn:aws-cn:rds:cn-north-1:123456789012:db:my-rds-instance",
+			expected: &dbARN{
+				Partition:    "aws-cn",
+				Region:       "cn-north-1",
+				AccountID:    "123456789012",
+				ResourceType: "db",
+				DBIdentifier: "my-rds-instance",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Not an ARN",
+			arn:     "my-rds-instance",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown partition",
+			arn:     "arn:gcp:rds:ap-northeast-1:123456789012:db:my-rds-instance",
+			wantErr: true,
+		},
+		{
+			name:    "Other service",
+			arn:     "arn:aws:ec2:ap-northeast-1:123456789012:instance/i-0123456789abcdef0",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid region",
+			arn:     "arn:aws:rds::123456789012:db:my-rds-instance",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid account ID",
+			arn:     "arn:aws:rds:ap-northeast-1:1234:db:my-rds-instance",
+			wantErr: true,
+		},
+		{
+			name:    "Other resource type",
+			arn:     "arn:aws:rds:ap-northeast-1:123456789012:snapshot:my-snapshot",
+			wantErr: true,
+		},
+		{
+			name:    "Missing DB identifier",
+			arn:     "arn:aws:rds:ap-northeast-1:123456789012:db:",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseDBARN(tc.arn)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Parsed ARN does not match expected value")
+			}
+		})
+	}
+}
@@ -20,6 +20,18 @@ const (
 	dbTypeRDS    dbType = "rds"    // RDS instance
 )
 
+/*
+ParseDBType parses a DB type given by the user, for operations that cannot look up the DB.
+*/
+func ParseDBType(s string) (dbType, error) {
+	switch t := dbType(s); t {
+	case dbTypeAurora, dbTypeRDS:
+		return t, nil
+	default:
+		return "", fmt.Errorf("unknown DB type '%s' (must be '%s' or '%s')", s, dbTypeAurora, dbTypeRDS)
+	}
+}
+
 /*
 isAuroraEngine checks if the engine is an Aurora engine.
 */
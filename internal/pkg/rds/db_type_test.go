@@ -181,3 +181,45 @@ func Test_DetermineDBType(t *testing.T) {
 		})
 	}
 }
+
+func Test_ParseDBType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected dbType
+		wantErr  bool
+	}{
+		{
+			name:     "Aurora",
+			input:    "aurora",
+			expected: dbTypeAurora,
+			wantErr:  false,
+		},
+		{
+			name:     "RDS",
+			input:    "rds",
+			expected: dbTypeRDS,
+			wantErr:  false,
+		},
+		{
+			name:     "Unknown",
+			input:    "mysql",
+			expected: "",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseDBType(tc.input)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "DB type does not match expected value")
+			}
+		})
+	}
+}
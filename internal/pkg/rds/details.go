@@ -0,0 +1,122 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+/*
+DBDetails holds the attributes of an Aurora cluster or RDS instance used for display.
+*/
+type DBDetails struct {
+	Status        string            // DB cluster/instance status (e.g., "available", "stopped")
+	Engine        string            // engine name (e.g., "aurora-postgresql")
+	EngineVersion string            // engine version
+	InstanceClass string            // instance class (RDS instances only)
+	MemberCount   int               // number of member instances (Aurora clusters only)
+	Tags          map[string]string // tags of the DB cluster/instance
+}
+
+/*
+DescribeDBDetails retrieves the attributes of the given Aurora clusters and RDS instances in bulk.
+It returns a map where the key is in the format "${dbType}:${dbIdentifier}" (e.g., "cluster:my-cluster" or "db:my-instance"),
+in the same way as GetPendingMaintenanceActions.
+*/
+func (r *RDS) DescribeDBDetails(clusters []string, instances []string) (map[string]DBDetails, error) {
+	result := map[string]DBDetails{}
+
+	slog.Debug("Describing DB details",
+		"clusters", len(clusters),
+		"instances", len(instances),
+	)
+
+	ctx := context.Background()
+
+	if 0 < len(clusters) {
+		paginator, err := r.factory.NewDescribeDBClustersPaginator(&rds.DescribeDBClustersInput{
+			Filters: []types.Filter{
+				{
+					Name:   aws.String("db-cluster-id"),
+					Values: clusters,
+				},
+			},
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to create DescribeDBClusters paginator: %w", err)
+		}
+
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute DescribeDBClusters API: %w", err)
+			}
+
+			for _, cluster := range output.DBClusters {
+				result["cluster:"+aws.ToString(cluster.DBClusterIdentifier)] = DBDetails{
+					Status:        aws.ToString(cluster.Status),
+					Engine:        aws.ToString(cluster.Engine),
+					EngineVersion: aws.ToString(cluster.EngineVersion),
+					MemberCount:   len(cluster.DBClusterMembers),
+					Tags:          tagsToMap(cluster.TagList),
+				}
+			}
+		}
+	}
+
+	if 0 < len(instances) {
+		paginator, err := r.factory.NewDescribeDBInstancesPaginator(&rds.DescribeDBInstancesInput{
+			Filters: []types.Filter{
+				{
+					Name:   aws.String("db-instance-id"),
+					Values: instances,
+				},
+			},
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to create DescribeDBInstances paginator: %w", err)
+		}
+
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute DescribeDBInstances API: %w", err)
+			}
+
+			for _, instance := range output.DBInstances {
+				result["db:"+aws.ToString(instance.DBInstanceIdentifier)] = DBDetails{
+					Status:        aws.ToString(instance.DBInstanceStatus),
+					Engine:        aws.ToString(instance.Engine),
+					EngineVersion: aws.ToString(instance.EngineVersion),
+					InstanceClass: aws.ToString(instance.DBInstanceClass),
+					Tags:          tagsToMap(instance.TagList),
+				}
+			}
+		}
+	}
+
+	slog.Debug("Described DB details", "count", len(result))
+
+	return result, nil
+}
+
+/*
+tagsToMap converts RDS tags into a map of tag keys to values.
+*/
+func tagsToMap(tags []types.Tag) map[string]string {
+	result := make(map[string]string, len(tags))
+
+	for _, tag := range tags {
+		result[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	return result
+}
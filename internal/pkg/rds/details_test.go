@@ -0,0 +1,180 @@
+package rds
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_DescribeDBDetails(t *testing.T) {
+	testCases := []struct {
+		name               string
+		clusters           []string
+		instances          []string
+		mockClustersSetup  func(*appmock.MockRDSFactory, *appmock.MockDescribeDBClustersPaginator)
+		mockInstancesSetup func(*appmock.MockRDSFactory, *appmock.MockDescribeDBInstancesPaginator)
+		expected           map[string]DBDetails
+		wantErr            bool
+	}{
+		{
+			name:      "Clusters and instances",
+			clusters:  []string{"cluster-1"},
+			instances: []string{"instance-1"},
+			mockClustersSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBClustersPaginator) {
+				params := &rds.DescribeDBClustersInput{
+					Filters: []types.Filter{
+						{
+							Name:   aws.String("db-cluster-id"),
+							Values: []string{"cluster-1"},
+						},
+					},
+				}
+
+				f.On("NewDescribeDBClustersPaginator", params).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&rds.DescribeDBClustersOutput{
+						DBClusters: []types.DBCluster{
+							{
+								DBClusterIdentifier: aws.String("cluster-1"),
+								Status:              aws.String("stopped"),
+								Engine:              aws.String("aurora-postgresql"),
+								EngineVersion:       aws.String("16.4"),
+								TagList: []types.Tag{
+									{Key: aws.String("env"), Value: aws.String("dev")},
+								},
+								DBClusterMembers: []types.DBClusterMember{
+									{DBInstanceIdentifier: aws.String("member-1")},
+									{DBInstanceIdentifier: aws.String("member-2")},
+								},
+							},
+						},
+					}, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			mockInstancesSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBInstancesPaginator) {
+				params := &rds.DescribeDBInstancesInput{
+					Filters: []types.Filter{
+						{
+							Name:   aws.String("db-instance-id"),
+							Values: []string{"instance-1"},
+						},
+					},
+				}
+
+				f.On("NewDescribeDBInstancesPaginator", params).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&rds.DescribeDBInstancesOutput{
+						DBInstances: []types.DBInstance{
+							{
+								DBInstanceIdentifier: aws.String("instance-1"),
+								DBInstanceStatus:     aws.String("available"),
+								Engine:               aws.String("mysql"),
+								EngineVersion:        aws.String("8.0.39"),
+								DBInstanceClass:      aws.String("db.t4g.micro"),
+							},
+						},
+					}, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			expected: map[string]DBDetails{
+				"cluster:cluster-1": {
+					Status:        "stopped",
+					Engine:        "aurora-postgresql",
+					EngineVersion: "16.4",
+					MemberCount:   2,
+					Tags:          map[string]string{"env": "dev"},
+				},
+				"db:instance-1": {
+					Status:        "available",
+					Engine:        "mysql",
+					EngineVersion: "8.0.39",
+					InstanceClass: "db.t4g.micro",
+					Tags:          map[string]string{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:               "No DBs",
+			clusters:           []string{},
+			instances:          []string{},
+			mockClustersSetup:  func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBClustersPaginator) {},
+			mockInstancesSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBInstancesPaginator) {},
+			expected:           map[string]DBDetails{},
+			wantErr:            false,
+		},
+		{
+			name:              "API error",
+			clusters:          []string{},
+			instances:         []string{"instance-1"},
+			mockClustersSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBClustersPaginator) {},
+			mockInstancesSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBInstancesPaginator) {
+				f.On("NewDescribeDBInstancesPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&rds.DescribeDBInstancesOutput{}, assert.AnError).
+					Once()
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockRDSFactory)
+			mockClustersPaginator := new(appmock.MockDescribeDBClustersPaginator)
+			mockInstancesPaginator := new(appmock.MockDescribeDBInstancesPaginator)
+
+			tc.mockClustersSetup(mockFactory, mockClustersPaginator)
+			tc.mockInstancesSetup(mockFactory, mockInstancesPaginator)
+
+			r := NewRDS(mockFactory)
+
+			got, err := r.DescribeDBDetails(tc.clusters, tc.instances)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "DB details do not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClustersPaginator.AssertExpectations(t)
+			mockInstancesPaginator.AssertExpectations(t)
+		})
+	}
+}
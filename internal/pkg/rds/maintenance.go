@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
-	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
@@ -13,18 +13,34 @@ import (
 )
 
 /*
-parseARN extracts the DB identifier and type from an ARN.
-Returns the DB identifier and type ("cluster" or "db").
+MaintenanceAction holds a pending maintenance action of an Aurora cluster, its member instance or an RDS instance.
 */
-func parseARN(arn string) (dbIdentifier string, dbType string) {
-	parts := strings.Split(arn, ":")
+type MaintenanceAction struct {
+	ResourceType     string     // type of the resource the action applies to ("cluster" or "db")
+	ResourceID       string     // identifier of the resource the action applies to
+	ResourceARN      string     // ARN of the resource the action applies to
+	Action           string     // type of the action (e.g., "system-update", "db-upgrade")
+	Description      string     // description of the action
+	AutoAppliedAfter *time.Time // date after which the action is applied in the maintenance window (nil if not scheduled)
+	ForcedApplyDate  *time.Time // date on which the action is applied regardless of the maintenance window (nil if not scheduled)
+	OptInStatus      string     // opt-in request of the action (e.g., "next-maintenance"; empty if not opted in)
+}
 
-	lastIndex := len(parts) - 1
+const (
+	MaintenanceRequired  = "required"  // the action is scheduled to be applied automatically
+	MaintenanceAvailable = "available" // the action is applied only on request
+)
 
-	dbIdentifier = parts[lastIndex]
-	dbType = parts[lastIndex-1]
+/*
+Category returns "required" if the action has been scheduled by AWS to be applied automatically,
+or "available" otherwise, in the same way as the AWS Management Console.
+*/
+func (a MaintenanceAction) Category() string {
+	if (a.AutoAppliedAfter != nil) || (a.ForcedApplyDate != nil) {
+		return MaintenanceRequired
+	}
 
-	return
+	return MaintenanceAvailable
 }
 
 /*
@@ -38,7 +54,29 @@ It returns a map where the key is in the format "${dbType}:${dbIdentifier}" (e.g
 and the value is a boolean indicating whether there are any pending maintenance actions.
 */
 func (r *RDS) GetPendingMaintenanceActions(clusters []string, instances []string, clusterMembers map[string][]string) (map[string]bool, error) {
-	result := map[string]bool{}
+	actions, err := r.DescribePendingMaintenanceActions(clusters, instances, clusterMembers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(actions))
+
+	for key := range actions {
+		result[key] = true
+	}
+
+	return result, nil
+}
+
+/*
+DescribePendingMaintenanceActions retrieves the pending maintenance actions of Aurora clusters and RDS instances.
+It accepts the same parameters as GetPendingMaintenanceActions, and returns a map keyed in the same format
+whose values are the actions of the DB. Actions of cluster members are included in those of the cluster.
+DBs without pending maintenance actions are omitted.
+*/
+func (r *RDS) DescribePendingMaintenanceActions(clusters []string, instances []string, clusterMembers map[string][]string) (map[string][]MaintenanceAction, error) {
+	result := map[string][]MaintenanceAction{}
 
 	if len(clusters)+len(instances) == 0 {
 		return result, nil
@@ -88,22 +126,43 @@ func (r *RDS) GetPendingMaintenanceActions(clusters []string, instances []string
 			return nil, fmt.Errorf("failed to execute DescribePendingMaintenanceActions API: %w", err)
 		}
 
-		for _, action := range output.PendingMaintenanceActions {
-			dbIdentifier, dbType := parseARN(
-				aws.ToString(action.ResourceIdentifier),
-			)
-
-			if dbType == "cluster" {
-				result["cluster:"+dbIdentifier] = true
-			} else {
-				clusterId, isClusterMember := instanceToCluster[dbIdentifier]
-
-				if isClusterMember {
-					result["cluster:"+clusterId] = true
-				} else {
-					result["db:"+dbIdentifier] = true
-				}
+		for _, resource := range output.PendingMaintenanceActions {
+			resourceARN := aws.ToString(resource.ResourceIdentifier)
+
+			parsed, err := parseDBARN(resourceARN)
+
+			if err != nil {
+				slog.Warn("Skipping pending maintenance actions of unknown resource", "error", err)
+
+				continue
 			}
+
+			dbIdentifier, dbType := parsed.DBIdentifier, parsed.ResourceType
+
+			key := "db:" + dbIdentifier
+
+			if dbType == resourceTypeCluster {
+				key = "cluster:" + dbIdentifier
+			} else if clusterId, isClusterMember := instanceToCluster[dbIdentifier]; isClusterMember {
+				key = "cluster:" + clusterId
+			}
+
+			actions := result[key]
+
+			for _, detail := range resource.PendingMaintenanceActionDetails {
+				actions = append(actions, MaintenanceAction{
+					ResourceType:     dbType,
+					ResourceID:       dbIdentifier,
+					ResourceARN:      resourceARN,
+					Action:           aws.ToString(detail.Action),
+					Description:      aws.ToString(detail.Description),
+					AutoAppliedAfter: detail.AutoAppliedAfterDate,
+					ForcedApplyDate:  detail.ForcedApplyDate,
+					OptInStatus:      aws.ToString(detail.OptInStatus),
+				})
+			}
+
+			result[key] = actions
 		}
 	}
 
@@ -111,3 +170,27 @@ func (r *RDS) GetPendingMaintenanceActions(clusters []string, instances []string
 
 	return result, nil
 }
+
+/*
+ApplyPendingMaintenanceAction applies the pending maintenance action to the resource immediately.
+*/
+func (r *RDS) ApplyPendingMaintenanceAction(action MaintenanceAction) error {
+	slog.Debug("Applying pending maintenance action",
+		"resourceARN", action.ResourceARN,
+		"action", action.Action,
+	)
+
+	ctx := context.Background()
+
+	_, err := r.factory.GetClient().ApplyPendingMaintenanceAction(ctx, &rds.ApplyPendingMaintenanceActionInput{
+		ResourceIdentifier: aws.String(action.ResourceARN),
+		ApplyAction:        aws.String(action.Action),
+		OptInType:          aws.String("immediate"),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute ApplyPendingMaintenanceAction API: %w", err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package rds
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
@@ -12,37 +13,6 @@ import (
 	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
 )
 
-func Test_parseARN(t *testing.T) {
-	testCases := []struct {
-		name               string
-		arn                string
-		expectedIdentifier string
-		expectedType       string
-	}{
-		{
-			name:               "Aurora cluster ARN",
-			arn:                "arn:aws:rds:ap-northeast-1:123456789012:cluster:my-aurora-cluster",
-			expectedIdentifier: "my-aurora-cluster",
-			expectedType:       "cluster",
-		},
-		{
-			name:               "RDS instance ARN",
-			arn:                "arn:aws:rds:ap-northeast-1:123456789012:db:my-rds-instance",
-			expectedIdentifier: "my-rds-instance",
-			expectedType:       "db",
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			gotIdentifier, gotType := parseARN(tc.arn)
-
-			assert.Equal(t, tc.expectedIdentifier, gotIdentifier, "Resource identifier should match the expected value")
-			assert.Equal(t, tc.expectedType, gotType, "Resource type should match the expected value")
-		})
-	}
-}
-
 func Test_GetPendingMaintenanceActions(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -274,6 +244,77 @@ func Test_GetPendingMaintenanceActions(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:     "Partitioned ARNs",
+			clusters: []string{"cluster-1"},
+			instances: []string{
+				"instance-1",
+			},
+			clusterMembers: map[string][]string{},
+			mockSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribePendingMaintenanceActionsPaginator) {
+				params := &rds.DescribePendingMaintenanceActionsInput{
+					Filters: []types.Filter{
+						{
+							Name:   aws.String("db-cluster-id"),
+							Values: []string{"cluster-1"},
+						},
+						{
+							Name:   aws.String("db-instance-id"),
+							Values: []string{"instance-1"},
+						},
+					},
+				}
+
+				f.On("NewDescribePendingMaintenanceActionsPaginator", params).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				result1 := &rds.DescribePendingMaintenanceActionsOutput{
+					PendingMaintenanceActions: []types.ResourcePendingMaintenanceActions{
+						{
+							ResourceIdentifier: aws.String("arn:aws-us-gov:rds:us-gov-west-1:123456789012:cluster:cluster-1"),
+							PendingMaintenanceActionDetails: []types.PendingMaintenanceAction{
+								{
+									Action: aws.String("system-update"),
+								},
+							},
+						},
+						{
+							ResourceIdentifier: aws.String("arn:aws-cn:rds:cn-north-1:123456789012:db:instance-1"),
+							PendingMaintenanceActionDetails: []types.PendingMaintenanceAction{
+								{
+									Action: aws.String("db-upgrade"),
+								},
+							},
+						},
+						{
+							ResourceIdentifier: aws.String("arn:aws:rds:us-east-1:123456789012:snapshot:snapshot-1"),
+							PendingMaintenanceActionDetails: []types.PendingMaintenanceAction{
+								{
+									Action: aws.String("system-update"),
+								},
+							},
+						},
+					},
+				}
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(result1, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			expected: map[string]bool{
+				"cluster:cluster-1": true,
+				"db:instance-1":     true,
+			},
+			wantErr: false,
+		},
 		{
 			name:     "Instances only",
 			clusters: []string{},
@@ -444,3 +485,133 @@ func Test_GetPendingMaintenanceActions(t *testing.T) {
 		})
 	}
 }
+
+func Test_DescribePendingMaintenanceActions(t *testing.T) {
+	autoAppliedAfter := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	forcedApplyDate := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	mockFactory := new(appmock.MockRDSFactory)
+	mockPaginator := new(appmock.MockDescribePendingMaintenanceActionsPaginator)
+
+	mockFactory.On("NewDescribePendingMaintenanceActionsPaginator", mock.Anything).
+		Return(mockPaginator, nil)
+
+	mockPaginator.On("HasMorePages").
+		Return(true).
+		Once()
+
+	mockPaginator.On("NextPage", mock.Anything, mock.Anything).
+		Return(&rds.DescribePendingMaintenanceActionsOutput{
+			PendingMaintenanceActions: []types.ResourcePendingMaintenanceActions{
+				{
+					ResourceIdentifier: aws.String("arn:aws:rds:ap-northeast-1:123456789012:cluster:cluster-1"),
+					PendingMaintenanceActionDetails: []types.PendingMaintenanceAction{
+						{
+							Action:               aws.String("system-update"),
+							Description:          aws.String("a"),
+							AutoAppliedAfterDate: aws.Time(autoAppliedAfter),
+							ForcedApplyDate:      aws.Time(forcedApplyDate),
+						},
+					},
+				},
+				{
+					ResourceIdentifier: aws.String("arn:aws:rds:ap-northeast-1:123456789012:db:member-1"),
+					PendingMaintenanceActionDetails: []types.PendingMaintenanceAction{
+						{
+							Action:      aws.String("os-upgrade"),
+							Description: aws.String("b"),
+							OptInStatus: aws.String("next-maintenance"),
+						},
+					},
+				},
+				{
+					ResourceIdentifier: aws.String("arn:aws:rds:ap-northeast-1:123456789012:db:instance-1"),
+					PendingMaintenanceActionDetails: []types.PendingMaintenanceAction{
+						{
+							Action:      aws.String("ca-certificate-rotation"),
+							Description: aws.String("c"),
+						},
+					},
+				},
+			},
+		}, nil).
+		Once()
+
+	mockPaginator.On("HasMorePages").
+		Return(false).
+		Once()
+
+	r := NewRDS(mockFactory)
+
+	got, err := r.DescribePendingMaintenanceActions([]string{"cluster-1"}, []string{"instance-1"}, map[string][]string{
+		"cluster-1": {"member-1"},
+	})
+
+	assert.NoError(t, err, "Unexpected error occurred")
+
+	assert.Equal(t, map[string][]MaintenanceAction{
+		"cluster:cluster-1": {
+			{
+				ResourceType:     "cluster",
+				ResourceID:       "cluster-1",
+				ResourceARN:      "arn:aws:rds:ap-northeast-1:123456789012:cluster:cluster-1",
+				Action:           "system-update",
+				Description:      "a",
+				AutoAppliedAfter: &autoAppliedAfter,
+				ForcedApplyDate:  &forcedApplyDate,
+			},
+			{
+				ResourceType: "db",
+				ResourceID:   "member-1",
+				ResourceARN:  "arn:aws:rds:ap-northeast-1:123456789012:db:member-1",
+				Action:       "os-upgrade",
+				Description:  "b",
+				OptInStatus:  "next-maintenance",
+			},
+		},
+		"db:instance-1": {
+			{
+				ResourceType: "db",
+				ResourceID:   "instance-1",
+				ResourceARN:  "arn:aws:rds:ap-northeast-1:123456789012:db:instance-1",
+				Action:       "ca-certificate-rotation",
+				Description:  "c",
+			},
+		},
+	}, got, "Pending maintenance actions should match expected values")
+
+	mockFactory.AssertExpectations(t)
+	mockPaginator.AssertExpectations(t)
+}
+
+func Test_MaintenanceAction_Category(t *testing.T) {
+	date := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		action   MaintenanceAction
+		expected string
+	}{
+		{
+			name:     "Auto-applied",
+			action:   MaintenanceAction{AutoAppliedAfter: &date},
+			expected: "required",
+		},
+		{
+			name:     "Forced",
+			action:   MaintenanceAction{ForcedApplyDate: &date},
+			expected: "required",
+		},
+		{
+			name:     "Not scheduled",
+			action:   MaintenanceAction{},
+			expected: "available",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.action.Category(), "Category should match the expected value")
+		})
+	}
+}
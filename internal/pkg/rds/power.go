@@ -0,0 +1,170 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+const (
+	StatusAvailable = "available" // status of a running DB cluster or instance
+	StatusStopped   = "stopped"   // status of a stopped DB cluster or instance
+	StatusStopping  = "stopping"  // status of a DB cluster or instance being stopped
+)
+
+/*
+dbStatusPollInterval is the interval between polls of a DB while waiting for it to reach a status.
+*/
+var dbStatusPollInterval = 30 * time.Second
+
+/*
+StartDB starts the given DB.
+dbType must be either "aurora" or "rds".
+*/
+func (r *RDS) StartDB(dbIdentifier string, dbType string) error {
+	slog.Debug("Starting DB",
+		"dbIdentifier", dbIdentifier,
+		"dbType", dbType,
+	)
+
+	ctx := context.Background()
+
+	client := r.factory.GetClient()
+
+	switch dbType {
+	case string(dbTypeAurora):
+		_, err := client.StartDBCluster(ctx, &rds.StartDBClusterInput{
+			DBClusterIdentifier: aws.String(dbIdentifier),
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to execute StartDBCluster API: %w", err)
+		}
+	case string(dbTypeRDS):
+		_, err := client.StartDBInstance(ctx, &rds.StartDBInstanceInput{
+			DBInstanceIdentifier: aws.String(dbIdentifier),
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to execute StartDBInstance API: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown DB type '%s'", dbType)
+	}
+
+	return nil
+}
+
+/*
+StopDB stops the given DB.
+dbType must be either "aurora" or "rds".
+*/
+func (r *RDS) StopDB(dbIdentifier string, dbType string) error {
+	slog.Debug("Stopping DB",
+		"dbIdentifier", dbIdentifier,
+		"dbType", dbType,
+	)
+
+	ctx := context.Background()
+
+	client := r.factory.GetClient()
+
+	switch dbType {
+	case string(dbTypeAurora):
+		_, err := client.StopDBCluster(ctx, &rds.StopDBClusterInput{
+			DBClusterIdentifier: aws.String(dbIdentifier),
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to execute StopDBCluster API: %w", err)
+		}
+	case string(dbTypeRDS):
+		_, err := client.StopDBInstance(ctx, &rds.StopDBInstanceInput{
+			DBInstanceIdentifier: aws.String(dbIdentifier),
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to execute StopDBInstance API: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown DB type '%s'", dbType)
+	}
+
+	return nil
+}
+
+/*
+WaitForDBStatus waits for the given DB to reach the status.
+For Aurora clusters, all member instances must also reach the status.
+Each change of the DB status is logged along the way.
+*/
+func (r *RDS) WaitForDBStatus(dbIdentifier string, dbType string, status string, timeout time.Duration) error {
+	slog.Debug("Waiting for DB status",
+		"dbIdentifier", dbIdentifier,
+		"status", status,
+		"timeout", timeout.Seconds(),
+	)
+
+	startTime := time.Now()
+	deadline := startTime.Add(timeout)
+
+	previous := ""
+
+	for {
+		current, err := r.DescribeDBStatus(dbIdentifier, dbType)
+
+		if err != nil {
+			return fmt.Errorf("failed to describe DB status: %w", err)
+		}
+
+		if current.Status != previous {
+			slog.Info("DB status changed",
+				"dbIdentifier", dbIdentifier,
+				"from", previous,
+				"to", current.Status,
+				"elapsed", time.Since(startTime).Seconds(),
+			)
+
+			previous = current.Status
+		}
+
+		if hasStatus(current, status) {
+			slog.Debug("DB reached status", "status", status)
+
+			return nil
+		}
+
+		if !time.Now().Add(dbStatusPollInterval).Before(deadline) {
+			return fmt.Errorf("timed out after %s waiting for DB '%s' to be %s (current status: %s)", timeout, dbIdentifier, status, current.Status)
+		}
+
+		slog.Debug("Waiting for DB status",
+			"status", current.Status,
+			"expected", status,
+			"elapsed", time.Since(startTime).Seconds(),
+		)
+
+		time.Sleep(dbStatusPollInterval)
+	}
+}
+
+/*
+hasStatus determines whether the DB and all of its member instances are in the status.
+*/
+func hasStatus(current *DBStatus, status string) bool {
+	if current.Status != status {
+		return false
+	}
+
+	for _, member := range current.Members {
+		if member.Status != status {
+			return false
+		}
+	}
+
+	return true
+}
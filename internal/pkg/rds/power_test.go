@@ -0,0 +1,267 @@
+package rds
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_StartDB(t *testing.T) {
+	testCases := []struct {
+		name      string
+		dbType    string
+		mockSetup func(*appmock.MockRDSClient)
+		wantErr   bool
+	}{
+		{
+			name:   "Aurora cluster",
+			dbType: "aurora",
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("StartDBCluster", mock.Anything, &rds.StartDBClusterInput{DBClusterIdentifier: aws.String("db-1")}, mock.Anything).
+					Return(&rds.StartDBClusterOutput{}, nil).
+					Once()
+			},
+			wantErr: false,
+		},
+		{
+			name:   "RDS instance",
+			dbType: "rds",
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("StartDBInstance", mock.Anything, &rds.StartDBInstanceInput{DBInstanceIdentifier: aws.String("db-1")}, mock.Anything).
+					Return(&rds.StartDBInstanceOutput{}, nil).
+					Once()
+			},
+			wantErr: false,
+		},
+		{
+			name:   "API error",
+			dbType: "rds",
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("StartDBInstance", mock.Anything, mock.Anything, mock.Anything).
+					Return(&rds.StartDBInstanceOutput{}, assert.AnError).
+					Once()
+			},
+			wantErr: true,
+		},
+		{
+			name:      "Unknown DB type",
+			dbType:    "unknown",
+			mockSetup: func(c *appmock.MockRDSClient) {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockRDSFactory)
+			mockClient := new(appmock.MockRDSClient)
+
+			mockFactory.On("GetClient").Return(mockClient)
+
+			tc.mockSetup(mockClient)
+
+			r := NewRDS(mockFactory)
+
+			err := r.StartDB("db-1", tc.dbType)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StopDB(t *testing.T) {
+	testCases := []struct {
+		name      string
+		dbType    string
+		mockSetup func(*appmock.MockRDSClient)
+		wantErr   bool
+	}{
+		{
+			name:   "Aurora cluster",
+			dbType: "aurora",
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("StopDBCluster", mock.Anything, &rds.StopDBClusterInput{DBClusterIdentifier: aws.String("db-1")}, mock.Anything).
+					Return(&rds.StopDBClusterOutput{}, nil).
+					Once()
+			},
+			wantErr: false,
+		},
+		{
+			name:   "RDS instance",
+			dbType: "rds",
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("StopDBInstance", mock.Anything, &rds.StopDBInstanceInput{DBInstanceIdentifier: aws.String("db-1")}, mock.Anything).
+					Return(&rds.StopDBInstanceOutput{}, nil).
+					Once()
+			},
+			wantErr: false,
+		},
+		{
+			name:   "API error",
+			dbType: "aurora",
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("StopDBCluster", mock.Anything, mock.Anything, mock.Anything).
+					Return(&rds.StopDBClusterOutput{}, assert.AnError).
+					Once()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockRDSFactory)
+			mockClient := new(appmock.MockRDSClient)
+
+			mockFactory.On("GetClient").Return(mockClient)
+
+			tc.mockSetup(mockClient)
+
+			r := NewRDS(mockFactory)
+
+			err := r.StopDB("db-1", tc.dbType)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_WaitForDBStatus(t *testing.T) {
+	originalInterval := dbStatusPollInterval
+
+	dbStatusPollInterval = time.Millisecond
+
+	defer func() {
+		dbStatusPollInterval = originalInterval
+	}()
+
+	output := func(status string) *rds.DescribeDBInstancesOutput {
+		return &rds.DescribeDBInstancesOutput{
+			DBInstances: []types.DBInstance{
+				{
+					DBInstanceIdentifier: aws.String("db-1"),
+					DBInstanceStatus:     aws.String(status),
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		timeout   time.Duration
+		mockSetup func(*appmock.MockRDSClient)
+		wantErr   bool
+	}{
+		{
+			name:    "Reaches status after polling",
+			timeout: time.Minute,
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("DescribeDBInstances", mock.Anything, mock.Anything, mock.Anything).
+					Return(output("starting"), nil).
+					Twice()
+
+				c.On("DescribeDBInstances", mock.Anything, mock.Anything, mock.Anything).
+					Return(output("available"), nil).
+					Once()
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Timeout",
+			timeout: time.Millisecond,
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("DescribeDBInstances", mock.Anything, mock.Anything, mock.Anything).
+					Return(output("starting"), nil).
+					Once()
+			},
+			wantErr: true,
+		},
+		{
+			name:    "API error",
+			timeout: time.Minute,
+			mockSetup: func(c *appmock.MockRDSClient) {
+				c.On("DescribeDBInstances", mock.Anything, mock.Anything, mock.Anything).
+					Return(&rds.DescribeDBInstancesOutput{}, assert.AnError).
+					Once()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockRDSFactory)
+			mockClient := new(appmock.MockRDSClient)
+
+			mockFactory.On("GetClient").Return(mockClient)
+
+			tc.mockSetup(mockClient)
+
+			r := NewRDS(mockFactory)
+
+			err := r.WaitForDBStatus("db-1", "rds", "available", tc.timeout)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_hasStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		current  *DBStatus
+		expected bool
+	}{
+		{
+			name:     "Instance in status",
+			current:  &DBStatus{Status: "available"},
+			expected: true,
+		},
+		{
+			name:     "Cluster with all members in status",
+			current:  &DBStatus{Status: "available", Members: []DBMemberStatus{{Status: "available"}, {Status: "available"}}},
+			expected: true,
+		},
+		{
+			name:     "Cluster with a member not in status",
+			current:  &DBStatus{Status: "available", Members: []DBMemberStatus{{Status: "available"}, {Status: "starting"}}},
+			expected: false,
+		},
+		{
+			name:     "Not in status",
+			current:  &DBStatus{Status: "starting"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hasStatus(tc.current, "available"), "Result should match the expected value")
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+/*
+DBStatus holds the current status of an Aurora cluster or RDS instance.
+*/
+type DBStatus struct {
+	Identifier     string           // DB cluster/instance identifier
+	Status         string           // DB cluster/instance status (e.g., "available", "stopped")
+	Endpoint       string           // endpoint address (writer endpoint for Aurora clusters)
+	ReaderEndpoint string           // reader endpoint address (Aurora clusters only)
+	Port           int32            // port number
+	Members        []DBMemberStatus // member instances (Aurora clusters only)
+}
+
+/*
+DBMemberStatus holds the current status of a member instance of an Aurora cluster.
+*/
+type DBMemberStatus struct {
+	Identifier string // DB instance identifier
+	Status     string // DB instance status
+	IsWriter   bool   // whether the instance is the writer of the cluster
+}
+
+/*
+DescribeDBStatus returns the current status of the given DB.
+dbType must be either "aurora" or "rds".
+For Aurora clusters, the status of each member instance is also returned.
+*/
+func (r *RDS) DescribeDBStatus(dbIdentifier string, dbType string) (*DBStatus, error) {
+	slog.Debug("Describing DB status",
+		"dbIdentifier", dbIdentifier,
+		"dbType", dbType,
+	)
+
+	switch dbType {
+	case string(dbTypeAurora):
+		return r.describeClusterStatus(dbIdentifier)
+	case string(dbTypeRDS):
+		return r.describeInstanceStatus(dbIdentifier)
+	default:
+		return nil, fmt.Errorf("unknown DB type '%s'", dbType)
+	}
+}
+
+/*
+describeClusterStatus returns the current status of an Aurora cluster and its member instances.
+*/
+func (r *RDS) describeClusterStatus(dbIdentifier string) (*DBStatus, error) {
+	ctx := context.Background()
+
+	client := r.factory.GetClient()
+
+	clusterOutput, err := client.DescribeDBClusters(ctx, &rds.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(dbIdentifier),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute DescribeDBClusters API: %w", err)
+	}
+
+	if len(clusterOutput.DBClusters) == 0 {
+		return nil, fmt.Errorf("DB cluster '%s' not found", dbIdentifier)
+	}
+
+	cluster := clusterOutput.DBClusters[0]
+
+	status := &DBStatus{
+		Identifier:     dbIdentifier,
+		Status:         aws.ToString(cluster.Status),
+		Endpoint:       aws.ToString(cluster.Endpoint),
+		ReaderEndpoint: aws.ToString(cluster.ReaderEndpoint),
+		Port:           aws.ToInt32(cluster.Port),
+		Members:        make([]DBMemberStatus, len(cluster.DBClusterMembers)),
+	}
+
+	if len(cluster.DBClusterMembers) == 0 {
+		slog.Debug("DB cluster has no member instances")
+
+		return status, nil
+	}
+
+	instanceOutput, err := client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("db-cluster-id"),
+				Values: []string{dbIdentifier},
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute DescribeDBInstances API: %w", err)
+	}
+
+	instanceStatuses := make(map[string]string, len(instanceOutput.DBInstances))
+
+	for _, instance := range instanceOutput.DBInstances {
+		instanceStatuses[aws.ToString(instance.DBInstanceIdentifier)] = aws.ToString(instance.DBInstanceStatus)
+	}
+
+	for i, member := range cluster.DBClusterMembers {
+		memberId := aws.ToString(member.DBInstanceIdentifier)
+
+		status.Members[i] = DBMemberStatus{
+			Identifier: memberId,
+			Status:     instanceStatuses[memberId],
+			IsWriter:   aws.ToBool(member.IsClusterWriter),
+		}
+	}
+
+	slog.Debug("Described DB cluster status",
+		"status", status.Status,
+		"memberCount", len(status.Members),
+	)
+
+	return status, nil
+}
+
+/*
+describeInstanceStatus returns the current status of an RDS instance.
+*/
+func (r *RDS) describeInstanceStatus(dbIdentifier string) (*DBStatus, error) {
+	ctx := context.Background()
+
+	output, err := r.factory.GetClient().DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(dbIdentifier),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute DescribeDBInstances API: %w", err)
+	}
+
+	if len(output.DBInstances) == 0 {
+		return nil, fmt.Errorf("DB instance '%s' not found", dbIdentifier)
+	}
+
+	instance := output.DBInstances[0]
+
+	status := &DBStatus{
+		Identifier: dbIdentifier,
+		Status:     aws.ToString(instance.DBInstanceStatus),
+	}
+
+	if instance.Endpoint != nil {
+		status.Endpoint = aws.ToString(instance.Endpoint.Address)
+		status.Port = aws.ToInt32(instance.Endpoint.Port)
+	}
+
+	slog.Debug("Described DB instance status", "status", status.Status)
+
+	return status, nil
+}
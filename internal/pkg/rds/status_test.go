@@ -0,0 +1,194 @@
+package rds
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_DescribeDBStatus(t *testing.T) {
+	testCases := []struct {
+		name         string
+		dbIdentifier string
+		dbType       string
+		mockSetup    func(*appmock.MockRDSFactory, *appmock.MockRDSClient)
+		expected     *DBStatus
+		wantErr      bool
+	}{
+		{
+			name:         "Aurora cluster with members",
+			dbIdentifier: "cluster-1",
+			dbType:       "aurora",
+			mockSetup: func(f *appmock.MockRDSFactory, c *appmock.MockRDSClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params1 := &rds.DescribeDBClustersInput{
+					DBClusterIdentifier: aws.String("cluster-1"),
+				}
+
+				result1 := &rds.DescribeDBClustersOutput{
+					DBClusters: []types.DBCluster{
+						{
+							Status:         aws.String("stopped"),
+							Endpoint:       aws.String("cluster-1.cluster-xxx.ap-northeast-1.rds.amazonaws.com"),
+							ReaderEndpoint: aws.String("cluster-1.cluster-ro-xxx.ap-northeast-1.rds.amazonaws.com"),
+							Port:           aws.Int32(5432),
+							DBClusterMembers: []types.DBClusterMember{
+								{
+									DBInstanceIdentifier: aws.String("instance-1"),
+									IsClusterWriter:      aws.Bool(true),
+								},
+								{
+									DBInstanceIdentifier: aws.String("instance-2"),
+									IsClusterWriter:      aws.Bool(false),
+								},
+							},
+						},
+					},
+				}
+
+				c.On("DescribeDBClusters", mock.Anything, params1, mock.Anything).
+					Return(result1, nil)
+
+				params2 := &rds.DescribeDBInstancesInput{
+					Filters: []types.Filter{
+						{
+							Name:   aws.String("db-cluster-id"),
+							Values: []string{"cluster-1"},
+						},
+					},
+				}
+
+				result2 := &rds.DescribeDBInstancesOutput{
+					DBInstances: []types.DBInstance{
+						{
+							DBInstanceIdentifier: aws.String("instance-1"),
+							DBInstanceStatus:     aws.String("stopped"),
+						},
+						{
+							DBInstanceIdentifier: aws.String("instance-2"),
+							DBInstanceStatus:     aws.String("stopping"),
+						},
+					},
+				}
+
+				c.On("DescribeDBInstances", mock.Anything, params2, mock.Anything).
+					Return(result2, nil)
+			},
+			expected: &DBStatus{
+				Identifier:     "cluster-1",
+				Status:         "stopped",
+				Endpoint:       "cluster-1.cluster-xxx.ap-northeast-1.rds.amazonaws.com",
+				ReaderEndpoint: "cluster-1.cluster-ro-xxx.ap-northeast-1.rds.amazonaws.com",
+				Port:           5432,
+				Members: []DBMemberStatus{
+					{Identifier: "instance-1", Status: "stopped", IsWriter: true},
+					{Identifier: "instance-2", Status: "stopping", IsWriter: false},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:         "RDS instance",
+			dbIdentifier: "instance-3",
+			dbType:       "rds",
+			mockSetup: func(f *appmock.MockRDSFactory, c *appmock.MockRDSClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &rds.DescribeDBInstancesInput{
+					DBInstanceIdentifier: aws.String("instance-3"),
+				}
+
+				result := &rds.DescribeDBInstancesOutput{
+					DBInstances: []types.DBInstance{
+						{
+							DBInstanceStatus: aws.String("available"),
+							Endpoint: &types.Endpoint{
+								Address: aws.String("instance-3.xxx.ap-northeast-1.rds.amazonaws.com"),
+								Port:    aws.Int32(3306),
+							},
+						},
+					},
+				}
+
+				c.On("DescribeDBInstances", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			expected: &DBStatus{
+				Identifier: "instance-3",
+				Status:     "available",
+				Endpoint:   "instance-3.xxx.ap-northeast-1.rds.amazonaws.com",
+				Port:       3306,
+			},
+			wantErr: false,
+		},
+		{
+			name:         "Cluster not found",
+			dbIdentifier: "cluster-4",
+			dbType:       "aurora",
+			mockSetup: func(f *appmock.MockRDSFactory, c *appmock.MockRDSClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeDBClusters", mock.Anything, mock.Anything, mock.Anything).
+					Return(&rds.DescribeDBClustersOutput{}, nil)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name:         "API error",
+			dbIdentifier: "instance-5",
+			dbType:       "rds",
+			mockSetup: func(f *appmock.MockRDSFactory, c *appmock.MockRDSClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeDBInstances", mock.Anything, mock.Anything, mock.Anything).
+					Return(&rds.DescribeDBInstancesOutput{}, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name:         "Unknown DB type",
+			dbIdentifier: "db-6",
+			dbType:       "unknown",
+			mockSetup:    func(f *appmock.MockRDSFactory, c *appmock.MockRDSClient) {},
+			expected:     nil,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockRDSFactory)
+			mockClient := new(appmock.MockRDSClient)
+
+			tc.mockSetup(mockFactory, mockClient)
+
+			r := NewRDS(mockFactory)
+
+			got, err := r.DescribeDBStatus(tc.dbIdentifier, tc.dbType)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "DB status does not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+/*
+eventRetention is the period for which RDS keeps events.
+*/
+const eventRetention = 14 * 24 * time.Hour
+
+/*
+stopEventMessages maps each source type to the message of the event emitted when the DB is stopped.
+*/
+var stopEventMessages = map[types.SourceType]string{
+	types.SourceTypeDbCluster:  "db cluster stopped",  // RDS-EVENT-0150
+	types.SourceTypeDbInstance: "db instance stopped", // RDS-EVENT-0087
+}
+
+/*
+GetLastStopTimes retrieves the time when each of the given Aurora clusters and RDS instances was last stopped.
+Events of all DBs are fetched at once for each source type, and only those within the retention period
+of RDS events (14 days) are considered.
+It returns a map keyed in the same format as GetPendingMaintenanceActions; DBs not stopped within the period are omitted.
+*/
+func (r *RDS) GetLastStopTimes(clusters []string, instances []string) (map[string]time.Time, error) {
+	result := map[string]time.Time{}
+
+	slog.Debug("Retrieving last stop times",
+		"clusters", len(clusters),
+		"instances", len(instances),
+	)
+
+	targets := []struct {
+		sourceType  types.SourceType
+		prefix      string
+		identifiers []string
+	}{
+		{types.SourceTypeDbCluster, "cluster:", clusters},
+		{types.SourceTypeDbInstance, "db:", instances},
+	}
+
+	startTime := time.Now().Add(-eventRetention)
+
+	ctx := context.Background()
+
+	for _, target := range targets {
+		if len(target.identifiers) == 0 {
+			continue
+		}
+
+		wanted := map[string]bool{}
+
+		for _, identifier := range target.identifiers {
+			wanted[identifier] = true
+		}
+
+		paginator, err := r.factory.NewDescribeEventsPaginator(&rds.DescribeEventsInput{
+			SourceType:      target.sourceType,
+			StartTime:       aws.Time(startTime),
+			EventCategories: []string{"notification"},
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to create DescribeEvents paginator: %w", err)
+		}
+
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute DescribeEvents API: %w", err)
+			}
+
+			for _, event := range output.Events {
+				identifier := aws.ToString(event.SourceIdentifier)
+
+				if !wanted[identifier] || !isStopEvent(target.sourceType, aws.ToString(event.Message)) {
+					continue
+				}
+
+				key := target.prefix + identifier
+
+				if date := aws.ToTime(event.Date); date.After(result[key]) {
+					result[key] = date
+				}
+			}
+		}
+	}
+
+	slog.Debug("Retrieved last stop times", "count", len(result))
+
+	return result, nil
+}
+
+/*
+isStopEvent determines whether the event message indicates that the DB has been stopped.
+*/
+func isStopEvent(sourceType types.SourceType, message string) bool {
+	return strings.HasPrefix(strings.ToLower(message), stopEventMessages[sourceType])
+}
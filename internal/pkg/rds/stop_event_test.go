@@ -0,0 +1,114 @@
+package rds
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_GetLastStopTimes(t *testing.T) {
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	event := func(identifier string, message string, date time.Time) types.Event {
+		return types.Event{
+			SourceIdentifier: aws.String(identifier),
+			Message:          aws.String(message),
+			Date:             aws.Time(date),
+		}
+	}
+
+	setupEvents := func(f *appmock.MockRDSFactory, sourceType types.SourceType, events ...types.Event) {
+		p := new(appmock.MockDescribeEventsPaginator)
+
+		f.On("NewDescribeEventsPaginator", mock.MatchedBy(func(params *rds.DescribeEventsInput) bool {
+			return params.SourceType == sourceType
+		})).
+			Return(p, nil).
+			Once()
+
+		p.On("HasMorePages").
+			Return(true).
+			Once()
+
+		p.On("NextPage", mock.Anything, mock.Anything).
+			Return(&rds.DescribeEventsOutput{Events: events}, nil).
+			Once()
+
+		p.On("HasMorePages").
+			Return(false).
+			Once()
+	}
+
+	testCases := []struct {
+		name      string
+		clusters  []string
+		instances []string
+		mockSetup func(*appmock.MockRDSFactory)
+		expected  map[string]time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "Latest stop event of each DB",
+			clusters:  []string{"cluster-1"},
+			instances: []string{"instance-1", "instance-2"},
+			mockSetup: func(f *appmock.MockRDSFactory) {
+				setupEvents(f, types.SourceTypeDbCluster,
+					event("cluster-1", "DB cluster stopped", date),
+					event("cluster-1", "DB cluster is being started due to it exceeding the maximum allowed time being stopped.", date.Add(time.Hour)),
+					event("cluster-1", "DB cluster stopped", date.Add(2*time.Hour)),
+					event("cluster-9", "DB cluster stopped", date.Add(3*time.Hour)),
+				)
+
+				setupEvents(f, types.SourceTypeDbInstance,
+					event("instance-1", "DB instance stopped", date),
+					event("instance-2", "DB instance started", date),
+				)
+			},
+			expected: map[string]time.Time{
+				"cluster:cluster-1": date.Add(2 * time.Hour),
+				"db:instance-1":     date,
+			},
+			wantErr: false,
+		},
+		{
+			name:      "API error",
+			clusters:  []string{"cluster-1"},
+			instances: []string{},
+			mockSetup: func(f *appmock.MockRDSFactory) {
+				f.On("NewDescribeEventsPaginator", mock.Anything).
+					Return(nil, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockRDSFactory)
+
+			tc.mockSetup(mockFactory)
+
+			r := NewRDS(mockFactory)
+
+			got, err := r.GetLastStopTimes(tc.clusters, tc.instances)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Last stop times do not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+		})
+	}
+}
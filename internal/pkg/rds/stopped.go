@@ -0,0 +1,93 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+/*
+StoppedDB identifies a stopped Aurora cluster or RDS instance.
+*/
+type StoppedDB struct {
+	DBIdentifier string // DB cluster/instance identifier
+	DBType       string // type of the DB ("aurora" or "rds")
+}
+
+/*
+ListStoppedDBs retrieves all stopped Aurora clusters and RDS instances in the region.
+Aurora cluster members are not listed individually since they are stopped together with the cluster,
+and DB clusters of other engines are ignored as they are not supported by ktnh.
+*/
+func (r *RDS) ListStoppedDBs() ([]StoppedDB, error) {
+	var result []StoppedDB
+
+	slog.Debug("Listing stopped DBs")
+
+	ctx := context.Background()
+
+	clusterPaginator, err := r.factory.NewDescribeDBClustersPaginator(&rds.DescribeDBClustersInput{})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DescribeDBClusters paginator: %w", err)
+	}
+
+	for clusterPaginator.HasMorePages() {
+		output, err := clusterPaginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute DescribeDBClusters API: %w", err)
+		}
+
+		for _, cluster := range output.DBClusters {
+			if isAurora, _ := isAuroraEngine(aws.ToString(cluster.Engine)); !isAurora {
+				continue
+			}
+
+			if aws.ToString(cluster.Status) != StatusStopped {
+				continue
+			}
+
+			result = append(result, StoppedDB{
+				DBIdentifier: aws.ToString(cluster.DBClusterIdentifier),
+				DBType:       string(dbTypeAurora),
+			})
+		}
+	}
+
+	instancePaginator, err := r.factory.NewDescribeDBInstancesPaginator(&rds.DescribeDBInstancesInput{})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DescribeDBInstances paginator: %w", err)
+	}
+
+	for instancePaginator.HasMorePages() {
+		output, err := instancePaginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute DescribeDBInstances API: %w", err)
+		}
+
+		for _, instance := range output.DBInstances {
+			if instance.DBClusterIdentifier != nil {
+				continue
+			}
+
+			if aws.ToString(instance.DBInstanceStatus) != StatusStopped {
+				continue
+			}
+
+			result = append(result, StoppedDB{
+				DBIdentifier: aws.ToString(instance.DBInstanceIdentifier),
+				DBType:       string(dbTypeRDS),
+			})
+		}
+	}
+
+	slog.Debug("Listed stopped DBs", "count", len(result))
+
+	return result, nil
+}
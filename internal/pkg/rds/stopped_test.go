@@ -0,0 +1,143 @@
+package rds
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_ListStoppedDBs(t *testing.T) {
+	testCases := []struct {
+		name               string
+		mockClustersSetup  func(*appmock.MockRDSFactory, *appmock.MockDescribeDBClustersPaginator)
+		mockInstancesSetup func(*appmock.MockRDSFactory, *appmock.MockDescribeDBInstancesPaginator)
+		expected           []StoppedDB
+		wantErr            bool
+	}{
+		{
+			name: "Stopped clusters and instances",
+			mockClustersSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBClustersPaginator) {
+				f.On("NewDescribeDBClustersPaginator", &rds.DescribeDBClustersInput{}).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&rds.DescribeDBClustersOutput{
+						DBClusters: []types.DBCluster{
+							{
+								DBClusterIdentifier: aws.String("cluster-stopped"),
+								Status:              aws.String("stopped"),
+								Engine:              aws.String("aurora-postgresql"),
+							},
+							{
+								DBClusterIdentifier: aws.String("cluster-available"),
+								Status:              aws.String("available"),
+								Engine:              aws.String("aurora-mysql"),
+							},
+							{
+								DBClusterIdentifier: aws.String("multi-az-cluster"),
+								Status:              aws.String("stopped"),
+								Engine:              aws.String("postgres"),
+							},
+						},
+					}, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			mockInstancesSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBInstancesPaginator) {
+				f.On("NewDescribeDBInstancesPaginator", &rds.DescribeDBInstancesInput{}).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&rds.DescribeDBInstancesOutput{
+						DBInstances: []types.DBInstance{
+							{
+								DBInstanceIdentifier: aws.String("instance-stopped"),
+								DBInstanceStatus:     aws.String("stopped"),
+							},
+							{
+								DBInstanceIdentifier: aws.String("instance-stopping"),
+								DBInstanceStatus:     aws.String("stopping"),
+							},
+							{
+								DBInstanceIdentifier: aws.String("member-1"),
+								DBInstanceStatus:     aws.String("stopped"),
+								DBClusterIdentifier:  aws.String("cluster-stopped"),
+							},
+						},
+					}, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			expected: []StoppedDB{
+				{DBIdentifier: "cluster-stopped", DBType: "aurora"},
+				{DBIdentifier: "instance-stopped", DBType: "rds"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "API error",
+			mockClustersSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBClustersPaginator) {
+				f.On("NewDescribeDBClustersPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&rds.DescribeDBClustersOutput{}, assert.AnError).
+					Once()
+			},
+			mockInstancesSetup: func(f *appmock.MockRDSFactory, p *appmock.MockDescribeDBInstancesPaginator) {},
+			expected:           nil,
+			wantErr:            true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockRDSFactory)
+			mockClustersPaginator := new(appmock.MockDescribeDBClustersPaginator)
+			mockInstancesPaginator := new(appmock.MockDescribeDBInstancesPaginator)
+
+			tc.mockClustersSetup(mockFactory, mockClustersPaginator)
+			tc.mockInstancesSetup(mockFactory, mockInstancesPaginator)
+
+			r := NewRDS(mockFactory)
+
+			got, err := r.ListStoppedDBs()
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Stopped DBs do not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClustersPaginator.AssertExpectations(t)
+			mockInstancesPaginator.AssertExpectations(t)
+		})
+	}
+}
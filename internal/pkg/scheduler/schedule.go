@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/scheduler"
+)
+
+/*
+Schedule holds the attributes of an EventBridge Scheduler schedule.
+*/
+type Schedule struct {
+	Name         string    // schedule name
+	State        string    // schedule state ("ENABLED" or "DISABLED")
+	Expression   string    // schedule expression (e.g., "rate(6 hours)")
+	CreationDate time.Time // time when the schedule was created
+}
+
+/*
+rateExpressionPattern matches rate expressions such as "rate(6 hours)".
+*/
+var rateExpressionPattern = regexp.MustCompile(`^rate\((\d+) (minutes?|hours?|days?)\)$`)
+
+/*
+GetSchedule returns the attributes of a schedule in the default schedule group.
+*/
+func (s *Scheduler) GetSchedule(scheduleName string) (*Schedule, error) {
+	slog.Debug("Retrieving EventBridge Scheduler schedule", "scheduleName", scheduleName)
+
+	ctx := context.Background()
+
+	output, err := s.factory.GetClient().GetSchedule(ctx, &scheduler.GetScheduleInput{
+		Name: aws.String(scheduleName),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute GetSchedule API for schedule '%s': %w", scheduleName, err)
+	}
+
+	slog.Debug("EventBridge Scheduler schedule retrieved", "state", output.State)
+
+	return &Schedule{
+		Name:         aws.ToString(output.Name),
+		State:        string(output.State),
+		Expression:   aws.ToString(output.ScheduleExpression),
+		CreationDate: aws.ToTime(output.CreationDate),
+	}, nil
+}
+
+/*
+NextRun estimates the next time the schedule will run after the given time.
+Only rate expressions are supported, assuming the schedule runs at fixed intervals
+starting from its creation date.
+It returns false if the next run cannot be estimated (e.g., disabled or unsupported expression).
+*/
+func (s *Schedule) NextRun(now time.Time) (time.Time, bool) {
+	if s.State != "ENABLED" || s.CreationDate.IsZero() {
+		return time.Time{}, false
+	}
+
+	interval, err := parseRateExpression(s.Expression)
+
+	if err != nil {
+		slog.Debug("Unable to estimate next run", "expression", s.Expression, "error", err)
+
+		return time.Time{}, false
+	}
+
+	if now.Before(s.CreationDate) {
+		return s.CreationDate, true
+	}
+
+	elapsedIntervals := now.Sub(s.CreationDate) / interval
+
+	return s.CreationDate.Add((elapsedIntervals + 1) * interval), true
+}
+
+/*
+parseRateExpression converts a rate expression into an interval.
+*/
+func parseRateExpression(expression string) (time.Duration, error) {
+	match := rateExpressionPattern.FindStringSubmatch(expression)
+
+	if match == nil {
+		return 0, fmt.Errorf("unsupported schedule expression '%s'", expression)
+	}
+
+	value, err := strconv.Atoi(match[1])
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate value '%s': %w", match[1], err)
+	}
+
+	if value <= 0 {
+		return 0, fmt.Errorf("rate value must be greater than 0")
+	}
+
+	var unit time.Duration
+
+	switch match[2] {
+	case "minute", "minutes":
+		unit = time.Minute
+	case "hour", "hours":
+		unit = time.Hour
+	case "day", "days":
+		unit = 24 * time.Hour
+	}
+
+	return time.Duration(value) * unit, nil
+}
@@ -0,0 +1,213 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/scheduler"
+	"github.com/aws/aws-sdk-go-v2/service/scheduler/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_GetSchedule(t *testing.T) {
+	creationDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		scheduleName string
+		mockSetup    func(*appmock.MockSchedulerFactory, *appmock.MockSchedulerClient)
+		expected     *Schedule
+		wantErr      bool
+	}{
+		{
+			name:         "Success",
+			scheduleName: "schedule-1",
+			mockSetup: func(f *appmock.MockSchedulerFactory, c *appmock.MockSchedulerClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &scheduler.GetScheduleInput{
+					Name: aws.String("schedule-1"),
+				}
+
+				result := &scheduler.GetScheduleOutput{
+					Name:               aws.String("schedule-1"),
+					State:              types.ScheduleStateEnabled,
+					ScheduleExpression: aws.String("rate(6 hours)"),
+					CreationDate:       aws.Time(creationDate),
+				}
+
+				c.On("GetSchedule", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			expected: &Schedule{
+				Name:         "schedule-1",
+				State:        "ENABLED",
+				Expression:   "rate(6 hours)",
+				CreationDate: creationDate,
+			},
+			wantErr: false,
+		},
+		{
+			name:         "API error",
+			scheduleName: "schedule-2",
+			mockSetup: func(f *appmock.MockSchedulerFactory, c *appmock.MockSchedulerClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("GetSchedule", mock.Anything, mock.Anything, mock.Anything).
+					Return(&scheduler.GetScheduleOutput{}, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockSchedulerFactory)
+			mockClient := new(appmock.MockSchedulerClient)
+
+			tc.mockSetup(mockFactory, mockClient)
+
+			s := NewScheduler(mockFactory)
+
+			got, err := s.GetSchedule(tc.scheduleName)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Schedule does not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_NextRun(t *testing.T) {
+	creationDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		schedule   *Schedule
+		now        time.Time
+		expected   time.Time
+		expectedOk bool
+	}{
+		{
+			name: "Between runs",
+			schedule: &Schedule{
+				State:        "ENABLED",
+				Expression:   "rate(6 hours)",
+				CreationDate: creationDate,
+			},
+			now:        creationDate.Add(7 * time.Hour),
+			expected:   creationDate.Add(12 * time.Hour),
+			expectedOk: true,
+		},
+		{
+			name: "Exactly on a run",
+			schedule: &Schedule{
+				State:        "ENABLED",
+				Expression:   "rate(1 day)",
+				CreationDate: creationDate,
+			},
+			now:        creationDate.Add(24 * time.Hour),
+			expected:   creationDate.Add(48 * time.Hour),
+			expectedOk: true,
+		},
+		{
+			name: "Disabled",
+			schedule: &Schedule{
+				State:        "DISABLED",
+				Expression:   "rate(6 hours)",
+				CreationDate: creationDate,
+			},
+			now:        creationDate.Add(time.Hour),
+			expected:   time.Time{},
+			expectedOk: false,
+		},
+		{
+			name: "Cron expression",
+			schedule: &Schedule{
+				State:        "ENABLED",
+				Expression:   "cron(0 */6 * * ? *)",
+				CreationDate: creationDate,
+			},
+			now:        creationDate.Add(time.Hour),
+			expected:   time.Time{},
+			expectedOk: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.schedule.NextRun(tc.now)
+
+			assert.Equal(t, tc.expectedOk, ok, "Whether next run is estimated does not match")
+			assert.Equal(t, tc.expected, got, "Next run does not match expected value")
+		})
+	}
+}
+
+func Test_parseRateExpression(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		expected   time.Duration
+		wantErr    bool
+	}{
+		{
+			name:       "Minutes",
+			expression: "rate(30 minutes)",
+			expected:   30 * time.Minute,
+			wantErr:    false,
+		},
+		{
+			name:       "Singular hour",
+			expression: "rate(1 hour)",
+			expected:   time.Hour,
+			wantErr:    false,
+		},
+		{
+			name:       "Days",
+			expression: "rate(2 days)",
+			expected:   48 * time.Hour,
+			wantErr:    false,
+		},
+		{
+			name:       "Zero",
+			expression: "rate(0 hours)",
+			expected:   0,
+			wantErr:    true,
+		},
+		{
+			name:       "Not a rate expression",
+			expression: "at(2025-01-01T00:00:00)",
+			expected:   0,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseRateExpression(tc.expression)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Interval does not match expected value")
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Package scheduler provides functionality for interacting with Amazon EventBridge Scheduler.
+
+It offers utilities to inspect the schedule that ktnh deploys to periodically
+stop Aurora clusters and RDS instances, and to estimate its next run.
+*/
+package scheduler
+
+import (
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/awsfactory"
+)
+
+/*
+Scheduler handles interactions with the Amazon EventBridge Scheduler service.
+*/
+type Scheduler struct {
+	factory awsfactory.SchedulerFactory // Interface instead of concrete client
+}
+
+/*
+NewScheduler creates and returns a new instance of Scheduler.
+*/
+func NewScheduler(factory awsfactory.SchedulerFactory) *Scheduler {
+	return &Scheduler{
+		factory: factory,
+	}
+}
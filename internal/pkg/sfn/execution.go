@@ -0,0 +1,255 @@
+package sfn
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+)
+
+/*
+Execution holds the attributes of a state machine execution.
+*/
+type Execution struct {
+	ARN       string    // execution ARN
+	Name      string    // execution name
+	Status    string    // execution status (e.g., "SUCCEEDED", "FAILED")
+	StartDate time.Time // time when the execution started
+	StopDate  time.Time // time when the execution stopped (zero if still running)
+	Error     string    // error code if the execution failed (set only by DescribeExecution)
+	Cause     string    // cause of the error (set only by DescribeExecution)
+}
+
+/*
+ExecutionHistory holds the information extracted from the event history of an execution.
+*/
+type ExecutionHistory struct {
+	Input         string   // input passed to the execution
+	EnteredStates []string // names of the states entered, in order
+	Error         string   // error code if the execution failed, timed out or was aborted
+	Cause         string   // cause of the error
+}
+
+/*
+toExecution converts an execution list item into an Execution.
+*/
+func toExecution(item types.ExecutionListItem) Execution {
+	return Execution{
+		ARN:       aws.ToString(item.ExecutionArn),
+		Name:      aws.ToString(item.Name),
+		Status:    string(item.Status),
+		StartDate: aws.ToTime(item.StartDate),
+		StopDate:  aws.ToTime(item.StopDate),
+	}
+}
+
+/*
+StartExecution starts an execution of the state machine with the given input
+and returns the ARN of the execution.
+*/
+func (s *StepFunctions) StartExecution(stateMachineArn string, input string) (string, error) {
+	slog.Debug("Starting state machine execution",
+		"stateMachineArn", stateMachineArn,
+		"input", input,
+	)
+
+	ctx := context.Background()
+
+	output, err := s.factory.GetClient().StartExecution(ctx, &sfn.StartExecutionInput{
+		StateMachineArn: aws.String(stateMachineArn),
+		Input:           aws.String(input),
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failed to execute StartExecution API: %w", err)
+	}
+
+	executionArn := aws.ToString(output.ExecutionArn)
+
+	slog.Debug("State machine execution started", "executionArn", executionArn)
+
+	return executionArn, nil
+}
+
+/*
+DescribeExecution returns the current state of an execution.
+*/
+func (s *StepFunctions) DescribeExecution(executionArn string) (*Execution, error) {
+	slog.Debug("Describing state machine execution", "executionArn", executionArn)
+
+	ctx := context.Background()
+
+	output, err := s.factory.GetClient().DescribeExecution(ctx, &sfn.DescribeExecutionInput{
+		ExecutionArn: aws.String(executionArn),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute DescribeExecution API: %w", err)
+	}
+
+	execution := &Execution{
+		ARN:       aws.ToString(output.ExecutionArn),
+		Name:      aws.ToString(output.Name),
+		Status:    string(output.Status),
+		StartDate: aws.ToTime(output.StartDate),
+		StopDate:  aws.ToTime(output.StopDate),
+		Error:     aws.ToString(output.Error),
+		Cause:     aws.ToString(output.Cause),
+	}
+
+	slog.Debug("State machine execution described", "status", execution.Status)
+
+	return execution, nil
+}
+
+/*
+maxListExecutionsResults is the maximum number of executions returned by a single ListExecutions call.
+*/
+const maxListExecutionsResults = 1000
+
+/*
+ListRecentExecutions returns up to maxResults of the most recent executions of the state machine,
+ordered from newest to oldest. More than 1000 executions are retrieved over multiple calls.
+*/
+func (s *StepFunctions) ListRecentExecutions(stateMachineArn string, maxResults int) ([]Execution, error) {
+	slog.Debug("Listing recent state machine executions",
+		"stateMachineArn", stateMachineArn,
+		"maxResults", maxResults,
+	)
+
+	ctx := context.Background()
+
+	executions := []Execution{}
+
+	var nextToken *string
+
+	for len(executions) < maxResults {
+		output, err := s.factory.GetClient().ListExecutions(ctx, &sfn.ListExecutionsInput{
+			StateMachineArn: aws.String(stateMachineArn),
+			MaxResults:      int32(min(maxResults-len(executions), maxListExecutionsResults)),
+			NextToken:       nextToken,
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute ListExecutions API: %w", err)
+		}
+
+		for _, execution := range output.Executions {
+			executions = append(executions, toExecution(execution))
+		}
+
+		nextToken = output.NextToken
+
+		if nextToken == nil {
+			break
+		}
+	}
+
+	slog.Debug("Listed recent state machine executions", "count", len(executions))
+
+	return executions, nil
+}
+
+/*
+ListExecutionsSince returns the executions of the state machine started at or after the given time,
+ordered from newest to oldest.
+*/
+func (s *StepFunctions) ListExecutionsSince(stateMachineArn string, since time.Time) ([]Execution, error) {
+	slog.Debug("Listing state machine executions",
+		"stateMachineArn", stateMachineArn,
+		"since", since,
+	)
+
+	paginator, err := s.factory.NewListExecutionsPaginator(&sfn.ListExecutionsInput{
+		StateMachineArn: aws.String(stateMachineArn),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ListExecutions paginator: %w", err)
+	}
+
+	var executions []Execution
+
+	ctx := context.Background()
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute ListExecutions API: %w", err)
+		}
+
+		for _, item := range output.Executions {
+			execution := toExecution(item)
+
+			// NOTE: Executions are returned in reverse chronological order,
+			//       so there is no need to look further once an older one is found.
+			if execution.StartDate.Before(since) {
+				slog.Debug("Listed state machine executions", "count", len(executions))
+
+				return executions, nil
+			}
+
+			executions = append(executions, execution)
+		}
+	}
+
+	slog.Debug("Listed state machine executions", "count", len(executions))
+
+	return executions, nil
+}
+
+/*
+GetExecutionHistory retrieves the event history of an execution
+and extracts its input, the states it entered and the error if any.
+*/
+func (s *StepFunctions) GetExecutionHistory(executionArn string) (*ExecutionHistory, error) {
+	slog.Debug("Retrieving execution history", "executionArn", executionArn)
+
+	paginator, err := s.factory.NewGetExecutionHistoryPaginator(&sfn.GetExecutionHistoryInput{
+		ExecutionArn:         aws.String(executionArn),
+		IncludeExecutionData: aws.Bool(true),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetExecutionHistory paginator: %w", err)
+	}
+
+	history := &ExecutionHistory{}
+
+	ctx := context.Background()
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute GetExecutionHistory API: %w", err)
+		}
+
+		for _, event := range output.Events {
+			switch {
+			case event.ExecutionStartedEventDetails != nil:
+				history.Input = aws.ToString(event.ExecutionStartedEventDetails.Input)
+			case event.StateEnteredEventDetails != nil:
+				history.EnteredStates = append(history.EnteredStates, aws.ToString(event.StateEnteredEventDetails.Name))
+			case event.ExecutionFailedEventDetails != nil:
+				history.Error = aws.ToString(event.ExecutionFailedEventDetails.Error)
+				history.Cause = aws.ToString(event.ExecutionFailedEventDetails.Cause)
+			case event.ExecutionTimedOutEventDetails != nil:
+				history.Error = aws.ToString(event.ExecutionTimedOutEventDetails.Error)
+				history.Cause = aws.ToString(event.ExecutionTimedOutEventDetails.Cause)
+			case event.ExecutionAbortedEventDetails != nil:
+				history.Error = aws.ToString(event.ExecutionAbortedEventDetails.Error)
+				history.Cause = aws.ToString(event.ExecutionAbortedEventDetails.Cause)
+			}
+		}
+	}
+
+	slog.Debug("Execution history retrieved", "enteredStates", len(history.EnteredStates))
+
+	return history, nil
+}
@@ -0,0 +1,569 @@
+package sfn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_ListRecentExecutions(t *testing.T) {
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		stateMachineArn string
+		maxResults      int
+		mockSetup       func(*appmock.MockSFNFactory, *appmock.MockSFNClient)
+		expected        []Execution
+		wantErr         bool
+	}{
+		{
+			name:            "Success",
+			stateMachineArn: "arn:aws:states:ap-northeast-1:123456789012:stateMachine:sm-1",
+			maxResults:      2,
+			mockSetup: func(f *appmock.MockSFNFactory, c *appmock.MockSFNClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &sfn.ListExecutionsInput{
+					StateMachineArn: aws.String("arn:aws:states:ap-northeast-1:123456789012:stateMachine:sm-1"),
+					MaxResults:      2,
+				}
+
+				result := &sfn.ListExecutionsOutput{
+					Executions: []types.ExecutionListItem{
+						{
+							ExecutionArn: aws.String("arn:exec-2"),
+							Name:         aws.String("exec-2"),
+							Status:       types.ExecutionStatusRunning,
+							StartDate:    aws.Time(startDate.Add(time.Hour)),
+						},
+						{
+							ExecutionArn: aws.String("arn:exec-1"),
+							Name:         aws.String("exec-1"),
+							Status:       types.ExecutionStatusSucceeded,
+							StartDate:    aws.Time(startDate),
+							StopDate:     aws.Time(startDate.Add(time.Minute)),
+						},
+					},
+				}
+
+				c.On("ListExecutions", mock.Anything, params, mock.Anything).
+					Return(result, nil)
+			},
+			expected: []Execution{
+				{
+					ARN:       "arn:exec-2",
+					Name:      "exec-2",
+					Status:    "RUNNING",
+					StartDate: startDate.Add(time.Hour),
+				},
+				{
+					ARN:       "arn:exec-1",
+					Name:      "exec-1",
+					Status:    "SUCCEEDED",
+					StartDate: startDate,
+					StopDate:  startDate.Add(time.Minute),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:            "More than API maximum",
+			stateMachineArn: "arn:aws:states:ap-northeast-1:123456789012:stateMachine:sm-3",
+			maxResults:      1001,
+			mockSetup: func(f *appmock.MockSFNFactory, c *appmock.MockSFNClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params1 := &sfn.ListExecutionsInput{
+					StateMachineArn: aws.String("arn:aws:states:ap-northeast-1:123456789012:stateMachine:sm-3"),
+					MaxResults:      1000,
+				}
+
+				result1 := &sfn.ListExecutionsOutput{
+					Executions: []types.ExecutionListItem{
+						{
+							ExecutionArn: aws.String("arn:exec-2"),
+							Name:         aws.String("exec-2"),
+							Status:       types.ExecutionStatusSucceeded,
+							StartDate:    aws.Time(startDate.Add(time.Hour)),
+						},
+					},
+					NextToken: aws.String("token"),
+				}
+
+				c.On("ListExecutions", mock.Anything, params1, mock.Anything).
+					Return(result1, nil).
+					Once()
+
+				params2 := &sfn.ListExecutionsInput{
+					StateMachineArn: aws.String("arn:aws:states:ap-northeast-1:123456789012:stateMachine:sm-3"),
+					MaxResults:      1000,
+					NextToken:       aws.String("token"),
+				}
+
+				result2 := &sfn.ListExecutionsOutput{
+					Executions: []types.ExecutionListItem{
+						{
+							ExecutionArn: aws.String("arn:exec-1"),
+							Name:         aws.String("exec-1"),
+							Status:       types.ExecutionStatusSucceeded,
+							StartDate:    aws.Time(startDate),
+						},
+					},
+				}
+
+				c.On("ListExecutions", mock.Anything, params2, mock.Anything).
+					Return(result2, nil).
+					Once()
+			},
+			expected: []Execution{
+				{
+					ARN:       "arn:exec-2",
+					Name:      "exec-2",
+					Status:    "SUCCEEDED",
+					StartDate: startDate.Add(time.Hour),
+				},
+				{
+					ARN:       "arn:exec-1",
+					Name:      "exec-1",
+					Status:    "SUCCEEDED",
+					StartDate: startDate,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:            "API error",
+			stateMachineArn: "arn:aws:states:ap-northeast-1:123456789012:stateMachine:sm-2",
+			maxResults:      5,
+			mockSetup: func(f *appmock.MockSFNFactory, c *appmock.MockSFNClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("ListExecutions", mock.Anything, mock.Anything, mock.Anything).
+					Return(&sfn.ListExecutionsOutput{}, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockSFNFactory)
+			mockClient := new(appmock.MockSFNClient)
+
+			tc.mockSetup(mockFactory, mockClient)
+
+			s := NewStepFunctions(mockFactory)
+
+			got, err := s.ListRecentExecutions(tc.stateMachineArn, tc.maxResults)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Executions do not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_ListExecutionsSince(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		mockSetup func(*appmock.MockSFNFactory, *appmock.MockListExecutionsPaginator)
+		expected  []string
+		wantErr   bool
+	}{
+		{
+			name: "Stops at older execution",
+			mockSetup: func(f *appmock.MockSFNFactory, p *appmock.MockListExecutionsPaginator) {
+				params := &sfn.ListExecutionsInput{
+					StateMachineArn: aws.String("arn:sm"),
+				}
+
+				f.On("NewListExecutionsPaginator", params).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				result := &sfn.ListExecutionsOutput{
+					Executions: []types.ExecutionListItem{
+						{
+							Name:      aws.String("exec-3"),
+							StartDate: aws.Time(since.Add(2 * time.Hour)),
+						},
+						{
+							Name:      aws.String("exec-2"),
+							StartDate: aws.Time(since),
+						},
+						{
+							Name:      aws.String("exec-1"),
+							StartDate: aws.Time(since.Add(-time.Hour)),
+						},
+					},
+				}
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(result, nil).
+					Once()
+			},
+			expected: []string{"exec-3", "exec-2"},
+			wantErr:  false,
+		},
+		{
+			name: "Paginator error",
+			mockSetup: func(f *appmock.MockSFNFactory, p *appmock.MockListExecutionsPaginator) {
+				f.On("NewListExecutionsPaginator", mock.Anything).
+					Return(nil, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+		{
+			name: "API error",
+			mockSetup: func(f *appmock.MockSFNFactory, p *appmock.MockListExecutionsPaginator) {
+				f.On("NewListExecutionsPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&sfn.ListExecutionsOutput{}, assert.AnError).
+					Once()
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockSFNFactory)
+			mockPaginator := new(appmock.MockListExecutionsPaginator)
+
+			tc.mockSetup(mockFactory, mockPaginator)
+
+			s := NewStepFunctions(mockFactory)
+
+			got, err := s.ListExecutionsSince("arn:sm", since)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				names := make([]string, len(got))
+
+				for i, execution := range got {
+					names[i] = execution.Name
+				}
+
+				assert.Equal(t, tc.expected, names, "Executions do not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockPaginator.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetExecutionHistory(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mockSetup func(*appmock.MockSFNFactory, *appmock.MockGetExecutionHistoryPaginator)
+		expected  *ExecutionHistory
+		wantErr   bool
+	}{
+		{
+			name: "Succeeded execution",
+			mockSetup: func(f *appmock.MockSFNFactory, p *appmock.MockGetExecutionHistoryPaginator) {
+				params := &sfn.GetExecutionHistoryInput{
+					ExecutionArn:         aws.String("arn:exec"),
+					IncludeExecutionData: aws.Bool(true),
+				}
+
+				f.On("NewGetExecutionHistoryPaginator", params).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				result := &sfn.GetExecutionHistoryOutput{
+					Events: []types.HistoryEvent{
+						{
+							ExecutionStartedEventDetails: &types.ExecutionStartedEventDetails{
+								Input: aws.String(`{"source":"aws.rds"}`),
+							},
+						},
+						{
+							StateEnteredEventDetails: &types.StateEnteredEventDetails{
+								Name: aws.String("Setup"),
+							},
+						},
+						{
+							StateEnteredEventDetails: &types.StateEnteredEventDetails{
+								Name: aws.String("StopDB"),
+							},
+						},
+					},
+				}
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(result, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			expected: &ExecutionHistory{
+				Input:         `{"source":"aws.rds"}`,
+				EnteredStates: []string{"Setup", "StopDB"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed execution",
+			mockSetup: func(f *appmock.MockSFNFactory, p *appmock.MockGetExecutionHistoryPaginator) {
+				f.On("NewGetExecutionHistoryPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				result := &sfn.GetExecutionHistoryOutput{
+					Events: []types.HistoryEvent{
+						{
+							ExecutionFailedEventDetails: &types.ExecutionFailedEventDetails{
+								Error: aws.String("Rds.RdsException"),
+								Cause: aws.String("access denied"),
+							},
+						},
+					},
+				}
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(result, nil).
+					Once()
+
+				p.On("HasMorePages").
+					Return(false).
+					Once()
+			},
+			expected: &ExecutionHistory{
+				Error: "Rds.RdsException",
+				Cause: "access denied",
+			},
+			wantErr: false,
+		},
+		{
+			name: "API error",
+			mockSetup: func(f *appmock.MockSFNFactory, p *appmock.MockGetExecutionHistoryPaginator) {
+				f.On("NewGetExecutionHistoryPaginator", mock.Anything).
+					Return(p, nil)
+
+				p.On("HasMorePages").
+					Return(true).
+					Once()
+
+				p.On("NextPage", mock.Anything, mock.Anything).
+					Return(&sfn.GetExecutionHistoryOutput{}, assert.AnError).
+					Once()
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockSFNFactory)
+			mockPaginator := new(appmock.MockGetExecutionHistoryPaginator)
+
+			tc.mockSetup(mockFactory, mockPaginator)
+
+			s := NewStepFunctions(mockFactory)
+
+			got, err := s.GetExecutionHistory("arn:exec")
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Execution history does not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockPaginator.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StartExecution(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mockSetup func(*appmock.MockSFNFactory, *appmock.MockSFNClient)
+		expected  string
+		wantErr   bool
+	}{
+		{
+			name: "Success",
+			mockSetup: func(f *appmock.MockSFNFactory, c *appmock.MockSFNClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &sfn.StartExecutionInput{
+					StateMachineArn: aws.String("arn:sm"),
+					Input:           aws.String(`{"source":"test"}`),
+				}
+
+				c.On("StartExecution", mock.Anything, params, mock.Anything).
+					Return(&sfn.StartExecutionOutput{
+						ExecutionArn: aws.String("arn:exec-1"),
+					}, nil)
+			},
+			expected: "arn:exec-1",
+			wantErr:  false,
+		},
+		{
+			name: "API error",
+			mockSetup: func(f *appmock.MockSFNFactory, c *appmock.MockSFNClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("StartExecution", mock.Anything, mock.Anything, mock.Anything).
+					Return(&sfn.StartExecutionOutput{}, assert.AnError)
+			},
+			expected: "",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockSFNFactory)
+			mockClient := new(appmock.MockSFNClient)
+
+			tc.mockSetup(mockFactory, mockClient)
+
+			s := NewStepFunctions(mockFactory)
+
+			got, err := s.StartExecution("arn:sm", `{"source":"test"}`)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Execution ARN does not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_DescribeExecution(t *testing.T) {
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		mockSetup func(*appmock.MockSFNFactory, *appmock.MockSFNClient)
+		expected  *Execution
+		wantErr   bool
+	}{
+		{
+			name: "Failed execution",
+			mockSetup: func(f *appmock.MockSFNFactory, c *appmock.MockSFNClient) {
+				f.On("GetClient").
+					Return(c)
+
+				params := &sfn.DescribeExecutionInput{
+					ExecutionArn: aws.String("arn:exec-1"),
+				}
+
+				c.On("DescribeExecution", mock.Anything, params, mock.Anything).
+					Return(&sfn.DescribeExecutionOutput{
+						ExecutionArn: aws.String("arn:exec-1"),
+						Name:         aws.String("exec-1"),
+						Status:       types.ExecutionStatusFailed,
+						StartDate:    aws.Time(startDate),
+						StopDate:     aws.Time(startDate.Add(time.Minute)),
+						Error:        aws.String("Rds.RdsException"),
+						Cause:        aws.String("AccessDenied"),
+					}, nil)
+			},
+			expected: &Execution{
+				ARN:       "arn:exec-1",
+				Name:      "exec-1",
+				Status:    "FAILED",
+				StartDate: startDate,
+				StopDate:  startDate.Add(time.Minute),
+				Error:     "Rds.RdsException",
+				Cause:     "AccessDenied",
+			},
+			wantErr: false,
+		},
+		{
+			name: "API error",
+			mockSetup: func(f *appmock.MockSFNFactory, c *appmock.MockSFNClient) {
+				f.On("GetClient").
+					Return(c)
+
+				c.On("DescribeExecution", mock.Anything, mock.Anything, mock.Anything).
+					Return(&sfn.DescribeExecutionOutput{}, assert.AnError)
+			},
+			expected: nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockSFNFactory)
+			mockClient := new(appmock.MockSFNClient)
+
+			tc.mockSetup(mockFactory, mockClient)
+
+			s := NewStepFunctions(mockFactory)
+
+			got, err := s.DescribeExecution("arn:exec-1")
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Execution does not match expected value")
+			}
+
+			mockFactory.AssertExpectations(t)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
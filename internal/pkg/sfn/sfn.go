@@ -0,0 +1,27 @@
+/*
+Package sfn provides functionality for interacting with AWS Step Functions.
+
+It offers utilities to inspect the executions of the state machine that ktnh
+deploys to keep Aurora clusters and RDS instances stopped.
+*/
+package sfn
+
+import (
+	"github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/awsfactory"
+)
+
+/*
+StepFunctions handles interactions with the AWS Step Functions service.
+*/
+type StepFunctions struct {
+	factory awsfactory.SFNFactory // Interface instead of concrete client
+}
+
+/*
+NewStepFunctions creates and returns a new instance of StepFunctions.
+*/
+func NewStepFunctions(factory awsfactory.SFNFactory) *StepFunctions {
+	return &StepFunctions{
+		factory: factory,
+	}
+}
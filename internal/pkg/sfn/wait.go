@@ -0,0 +1,52 @@
+package sfn
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+)
+
+/*
+executionPollInterval is the interval between polls of an execution while waiting for it to finish.
+*/
+var executionPollInterval = 10 * time.Second
+
+/*
+WaitForExecution waits for an execution to reach a terminal state and returns its final state.
+*/
+func (s *StepFunctions) WaitForExecution(executionArn string, timeout time.Duration) (*Execution, error) {
+	slog.Debug("Waiting for execution to finish",
+		"executionArn", executionArn,
+		"timeout", timeout.Seconds(),
+	)
+
+	startTime := time.Now()
+	deadline := startTime.Add(timeout)
+
+	for {
+		execution, err := s.DescribeExecution(executionArn)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe execution: %w", err)
+		}
+
+		if execution.Status != string(types.ExecutionStatusRunning) && execution.Status != string(types.ExecutionStatusPendingRedrive) {
+			slog.Debug("Execution finished", "status", execution.Status)
+
+			return execution, nil
+		}
+
+		if !time.Now().Add(executionPollInterval).Before(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for execution '%s' to finish", timeout, execution.Name)
+		}
+
+		slog.Info("Waiting for execution to finish",
+			"executionName", execution.Name,
+			"elapsed", time.Since(startTime).Seconds(),
+		)
+
+		time.Sleep(executionPollInterval)
+	}
+}
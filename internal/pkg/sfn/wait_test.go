@@ -0,0 +1,115 @@
+package sfn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmock "github.com/quickguard-oss/koreru-toki-no-hiho/internal/pkg/mock"
+)
+
+func Test_WaitForExecution(t *testing.T) {
+	originalInterval := executionPollInterval
+
+	executionPollInterval = time.Millisecond
+
+	defer func() {
+		executionPollInterval = originalInterval
+	}()
+
+	output := func(status types.ExecutionStatus) *sfn.DescribeExecutionOutput {
+		return &sfn.DescribeExecutionOutput{
+			ExecutionArn: aws.String("arn:exec-1"),
+			Name:         aws.String("exec-1"),
+			Status:       status,
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		timeout   time.Duration
+		mockSetup func(*appmock.MockSFNClient)
+		expected  string
+		wantErr   bool
+	}{
+		{
+			name:    "Succeeds after polling",
+			timeout: time.Minute,
+			mockSetup: func(c *appmock.MockSFNClient) {
+				c.On("DescribeExecution", mock.Anything, mock.Anything, mock.Anything).
+					Return(output(types.ExecutionStatusRunning), nil).
+					Twice()
+
+				c.On("DescribeExecution", mock.Anything, mock.Anything, mock.Anything).
+					Return(output(types.ExecutionStatusSucceeded), nil).
+					Once()
+			},
+			expected: "SUCCEEDED",
+			wantErr:  false,
+		},
+		{
+			name:    "Failed execution is returned as is",
+			timeout: time.Minute,
+			mockSetup: func(c *appmock.MockSFNClient) {
+				c.On("DescribeExecution", mock.Anything, mock.Anything, mock.Anything).
+					Return(output(types.ExecutionStatusFailed), nil).
+					Once()
+			},
+			expected: "FAILED",
+			wantErr:  false,
+		},
+		{
+			name:    "Timeout",
+			timeout: 0,
+			mockSetup: func(c *appmock.MockSFNClient) {
+				c.On("DescribeExecution", mock.Anything, mock.Anything, mock.Anything).
+					Return(output(types.ExecutionStatusRunning), nil).
+					Once()
+			},
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:    "API error",
+			timeout: time.Minute,
+			mockSetup: func(c *appmock.MockSFNClient) {
+				c.On("DescribeExecution", mock.Anything, mock.Anything, mock.Anything).
+					Return(&sfn.DescribeExecutionOutput{}, assert.AnError).
+					Once()
+			},
+			expected: "",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFactory := new(appmock.MockSFNFactory)
+			mockClient := new(appmock.MockSFNClient)
+
+			mockFactory.On("GetClient").
+				Return(mockClient)
+
+			tc.mockSetup(mockClient)
+
+			s := NewStepFunctions(mockFactory)
+
+			got, err := s.WaitForExecution("arn:exec-1", tc.timeout)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got.Status, "Execution status does not match expected value")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
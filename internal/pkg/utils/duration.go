@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+/*
+dayDurationPattern matches a duration string starting with a number of days (e.g., "7d", "1d12h").
+*/
+var dayDurationPattern = regexp.MustCompile(`^(\d+)d(.*)$`)
+
+/*
+ParseDuration parses a duration string in the same way as time.ParseDuration,
+additionally accepting "d" as a unit of 24 hours at the beginning (e.g., "7d", "1d12h").
+*/
+func ParseDuration(s string) (time.Duration, error) {
+	match := dayDurationPattern.FindStringSubmatch(s)
+
+	if match == nil {
+		return time.ParseDuration(s)
+	}
+
+	days, err := strconv.Atoi(match[1])
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid number of days '%s': %w", match[1], err)
+	}
+
+	duration := time.Duration(days) * 24 * time.Hour
+
+	if match[2] == "" {
+		return duration, nil
+	}
+
+	rest, err := time.ParseDuration(match[2])
+
+	if err != nil {
+		return 0, err
+	}
+
+	return duration + rest, nil
+}
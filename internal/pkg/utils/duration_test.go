@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseDuration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{
+			name:     "Standard duration",
+			input:    "1h30m",
+			expected: 90 * time.Minute,
+			wantErr:  false,
+		},
+		{
+			name:     "Days only",
+			input:    "7d",
+			expected: 7 * 24 * time.Hour,
+			wantErr:  false,
+		},
+		{
+			name:     "Days and hours",
+			input:    "1d12h",
+			expected: 36 * time.Hour,
+			wantErr:  false,
+		},
+		{
+			name:     "Invalid unit after days",
+			input:    "1dx",
+			expected: 0,
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid string",
+			input:    "abc",
+			expected: 0,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseDuration(tc.input)
+
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error to be returned")
+			} else {
+				assert.NoError(t, err, "Unexpected error occurred")
+
+				assert.Equal(t, tc.expected, got, "Parsed duration does not match expected value")
+			}
+		})
+	}
+}